@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type ApiKeyAction string
+
+const (
+	IssueApiKeyAction  ApiKeyAction = "issue"
+	RevokeApiKeyAction ApiKeyAction = "revoke"
+)
+
+// ApiKeyCommand is the api key management requested through the cli
+type ApiKeyCommand struct {
+	Action ApiKeyAction
+	// Issuance
+	Owner         string
+	Tier          string
+	AllowedRoutes []string
+	ExpiresIn     time.Duration
+	// Revocation
+	Id string
+}
+
+var apiKeyCommand *ApiKeyCommand
+
+func newApiKeysCommand() *cobra.Command {
+	apiKeysCmd := &cobra.Command{
+		Use:   "api-keys",
+		Short: "Issue and revoke the api keys",
+	}
+
+	issued := &ApiKeyCommand{Action: IssueApiKeyAction}
+	issueCmd := &cobra.Command{
+		Use:   "issue",
+		Short: "Issue an api key, the key is printed once and can't be retrieved afterwards",
+		Run: func(cmd *cobra.Command, args []string) {
+			apiKeyCommand = issued
+		},
+	}
+	issueCmd.Flags().StringVar(&issued.Owner, "owner", "", "owner of the api key")
+	issueCmd.Flags().StringVar(&issued.Tier, "tier", "", "tier of the api key, which sets its rate limits")
+	issueCmd.Flags().StringSliceVar(
+		&issued.AllowedRoutes, "routes", nil,
+		"paths the api key is allowed to call, a trailing * matches any path with the prefix (default any path)",
+	)
+	issueCmd.Flags().DurationVar(
+		&issued.ExpiresIn, "expires-in", 0, "validity of the api key, e.g. 720h (default never expires)",
+	)
+	_ = issueCmd.MarkFlagRequired("owner")
+	_ = issueCmd.MarkFlagRequired("tier")
+
+	revoked := &ApiKeyCommand{Action: RevokeApiKeyAction}
+	revokeCmd := &cobra.Command{
+		Use:   "revoke",
+		Short: "Revoke an api key",
+		Run: func(cmd *cobra.Command, args []string) {
+			apiKeyCommand = revoked
+		},
+	}
+	revokeCmd.Flags().StringVar(&revoked.Id, "id", "", "id of the api key")
+	_ = revokeCmd.MarkFlagRequired("id")
+
+	apiKeysCmd.AddCommand(issueCmd, revokeCmd)
+	return apiKeysCmd
+}
+
+// GetApiKeyCommand returns the api key management requested through the cli,
+// if any
+func GetApiKeyCommand() *ApiKeyCommand {
+	return apiKeyCommand
+}
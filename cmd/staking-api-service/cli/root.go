@@ -19,6 +19,8 @@ var (
 	globalParamsPath      string
 	finalityProvidersPath string
 	replayFlag            bool
+	backfillAddressesFlag bool
+	backfillFpStakersFlag bool
 	rootCmd               = &cobra.Command{
 		Use: "start-server",
 	}
@@ -58,6 +60,19 @@ func Setup() error {
 		false,
 		"Replay unprocessable messages",
 	)
+	rootCmd.PersistentFlags().BoolVar(
+		&backfillAddressesFlag,
+		"backfill-addresses",
+		false,
+		"Backfill the staker btc addresses of existing delegations",
+	)
+	rootCmd.PersistentFlags().BoolVar(
+		&backfillFpStakersFlag,
+		"backfill-fp-stakers",
+		false,
+		"Backfill the finality provider staker counts of existing delegations",
+	)
+	rootCmd.AddCommand(newApiKeysCommand())
 	if err := rootCmd.Execute(); err != nil {
 		return err
 	}
@@ -83,4 +98,12 @@ func GetFinalityProvidersPath() string {
 
 func GetReplayFlag() bool {
 	return replayFlag
-}
\ No newline at end of file
+}
+
+func GetBackfillAddressesFlag() bool {
+	return backfillAddressesFlag
+}
+
+func GetBackfillFpStakersFlag() bool {
+	return backfillFpStakersFlag
+}
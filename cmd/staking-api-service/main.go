@@ -67,8 +67,26 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("error while setting up staking services layer")
 	}
+	// Check if an api key command is given
+	if apiKeyCommand := cli.GetApiKeyCommand(); apiKeyCommand != nil {
+		switch apiKeyCommand.Action {
+		case cli.IssueApiKeyAction:
+			err = scripts.IssueApiKey(
+				ctx, cfg, services, apiKeyCommand.Owner, apiKeyCommand.Tier,
+				apiKeyCommand.AllowedRoutes, apiKeyCommand.ExpiresIn,
+			)
+		case cli.RevokeApiKeyAction:
+			err = scripts.RevokeApiKey(ctx, services, apiKeyCommand.Id)
+		}
+		if err != nil {
+			log.Fatal().Err(err).Msg("error while managing api key")
+		}
+		return
+	}
+
 	// Start the event queue processing
 	queues := queue.New(cfg.Queue, services)
+	services.SetQueueMessageSender(queues.SendMessage)
 
 	// Check if the replay flag is set
 	if cli.GetReplayFlag() {
@@ -80,10 +98,46 @@ func main() {
 		return
 	}
 
+	// Check if the backfill addresses flag is set
+	if cli.GetBackfillAddressesFlag() {
+		log.Info().Msg("Backfill addresses flag is set. Starting backfill of staker btc addresses.")
+		err := scripts.BackfillStakerBtcAddresses(ctx, cfg, services.DbClient)
+		if err != nil {
+			log.Fatal().Err(err).Msg("error while backfilling staker btc addresses")
+		}
+		return
+	}
+
+	// Check if the backfill finality provider stakers flag is set
+	if cli.GetBackfillFpStakersFlag() {
+		log.Info().Msg("Backfill finality provider stakers flag is set. Starting backfill of finality provider staker stats.")
+		err := scripts.BackfillFinalityProviderStakerStats(ctx, services.DbClient)
+		if err != nil {
+			log.Fatal().Err(err).Msg("error while backfilling finality provider staker stats")
+		}
+		return
+	}
+
 	queues.StartReceivingMessages()
 
 	healthcheck.StartHealthCheckCron(ctx, queues, cfg.Server.HealthCheckInterval)
 
+	if err = services.StartConfigWatcher(ctx, paramsPath, finalityProvidersPath); err != nil {
+		log.Fatal().Err(err).Msg("error while starting config watcher")
+	}
+
+	services.StartDelegationStream(ctx)
+
+	if cfg.Webhooks != nil {
+		services.StartWebhookDelivery(ctx)
+	}
+
+	if cfg.StatsSnapshot != nil {
+		if err = services.StartStatsSnapshotCron(ctx); err != nil {
+			log.Fatal().Err(err).Msg("error while starting stats snapshot cron")
+		}
+	}
+
 	apiServer, err := api.New(ctx, cfg, services)
 	if err != nil {
 		log.Fatal().Err(err).Msg("error while setting up staking api service")
@@ -0,0 +1,50 @@
+package scripts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/babylonchain/staking-api-service/internal/config"
+	"github.com/babylonchain/staking-api-service/internal/services"
+)
+
+// IssueApiKey issues the api key and prints it, the key can't be retrieved
+// afterwards. The tier must have a rate limit configured.
+func IssueApiKey(
+	ctx context.Context, cfg *config.Config, s *services.Services,
+	owner, tier string, allowedRoutes []string, expiresIn time.Duration,
+) error {
+	if cfg.RateLimit == nil || !cfg.RateLimit.HasTier(tier) {
+		return fmt.Errorf("tier %s is not configured in any rate limit group", tier)
+	}
+
+	var expiresAt int64
+	if expiresIn > 0 {
+		expiresAt = time.Now().Add(expiresIn).Unix()
+	}
+	apiKey, err := s.IssueApiKey(ctx, owner, tier, allowedRoutes, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to issue api key: %w", err)
+	}
+
+	fmt.Printf("Issued api key %s for %s with tier %s.\n", apiKey.Id, apiKey.Owner, apiKey.Tier)
+	if len(apiKey.AllowedRoutes) > 0 {
+		fmt.Printf("Allowed routes: %v\n", apiKey.AllowedRoutes)
+	}
+	if apiKey.ExpiresAt != "" {
+		fmt.Printf("Expires at: %s\n", apiKey.ExpiresAt)
+	}
+	fmt.Printf("Key: %s\n", apiKey.Key)
+	fmt.Println("Store the key securely, it can't be retrieved afterwards.")
+	return nil
+}
+
+func RevokeApiKey(ctx context.Context, s *services.Services, id string) error {
+	if err := s.RevokeApiKey(ctx, id); err != nil {
+		return fmt.Errorf("failed to revoke api key %s: %w", id, err)
+	}
+
+	fmt.Printf("Revoked api key %s.\n", id)
+	return nil
+}
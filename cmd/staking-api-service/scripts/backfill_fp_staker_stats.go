@@ -0,0 +1,24 @@
+package scripts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/rs/zerolog/log"
+)
+
+// BackfillFinalityProviderStakerStats rebuilds the unique and active staker
+// counts of the finality providers from the delegations processed before they
+// were introduced. The backfill can be safely re-run.
+func BackfillFinalityProviderStakerStats(ctx context.Context, db db.DBClient) error {
+	fmt.Println("Starting to backfill finality provider staker stats...")
+
+	if err := db.BackfillFinalityProviderStakerStats(ctx); err != nil {
+		return fmt.Errorf("failed to backfill finality provider staker stats: %w", err)
+	}
+
+	log.Info().Msg("Backfill of finality provider staker stats completed.")
+	fmt.Println("Backfill of finality provider staker stats completed.")
+	return nil
+}
@@ -0,0 +1,40 @@
+package scripts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/babylonchain/staking-api-service/internal/config"
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/rs/zerolog/log"
+)
+
+// BackfillStakerBtcAddresses derives the segwit addresses for the delegations
+// saved before they were introduced. The backfill can be safely re-run.
+func BackfillStakerBtcAddresses(ctx context.Context, cfg *config.Config, db db.DBClient) error {
+	fmt.Println("Starting to backfill staker btc addresses...")
+
+	stakerPks, err := db.FindStakerPksWithoutSegwitAddresses(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve stakers without segwit addresses: %w", err)
+	}
+
+	stakerCount := len(stakerPks)
+	fmt.Printf("There are %d stakers to backfill.\n", stakerCount)
+
+	for i, stakerPkHex := range stakerPks {
+		stakerBtcAddress, err := model.NewStakerBtcAddress(stakerPkHex, cfg.Server.BTCNetParam)
+		if err != nil {
+			return fmt.Errorf("failed to derive btc addresses for staker %s: %w", stakerPkHex, err)
+		}
+		if err := db.UpdateStakerBtcAddress(ctx, stakerPkHex, stakerBtcAddress); err != nil {
+			return fmt.Errorf("failed to update btc addresses for staker %s: %w", stakerPkHex, err)
+		}
+		fmt.Printf("Staker %d/%d backfilled successfully.\n", i+1, stakerCount)
+	}
+
+	log.Info().Msg("Backfill of staker btc addresses completed.")
+	fmt.Println("Backfill of staker btc addresses completed.")
+	return nil
+}
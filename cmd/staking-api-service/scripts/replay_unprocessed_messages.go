@@ -2,21 +2,16 @@ package scripts
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 
 	"github.com/babylonchain/staking-api-service/internal/config"
 	"github.com/babylonchain/staking-api-service/internal/db"
 	"github.com/babylonchain/staking-api-service/internal/queue"
-	queueClient "github.com/babylonchain/staking-queue-client/client"
+	"github.com/babylonchain/staking-api-service/internal/services"
 	"github.com/rs/zerolog/log"
 )
 
-type GenericEvent struct {
-	EventType queueClient.EventType `json:"event_type"`
-}
-
 func ReplayUnprocessableMessages(ctx context.Context, cfg *config.Config, queues *queue.Queues, db db.DBClient) (err error) {
 	fmt.Println("Starting to replay unprocessable messages...")
 
@@ -39,13 +34,19 @@ func ReplayUnprocessableMessages(ctx context.Context, cfg *config.Config, queues
 	for i, msg := range unprocessableMessages {
 		fmt.Printf("Processing message %d/%d: %s\n", i+1, messageCount, msg.MessageBody)
 
-		var genericEvent GenericEvent
-		if err := json.Unmarshal([]byte(msg.MessageBody), &genericEvent); err != nil {
-			return errors.New("failed to unmarshal event message")
+		// The messages dead-lettered before their queue was recorded are
+		// routed by their event type
+		queueName := msg.QueueName
+		if queueName == "" {
+			queueName, err = services.QueueNameFromMessageBody(msg.MessageBody)
+			if err != nil {
+				return fmt.Errorf("failed to determine the queue of the message: %w", err)
+			}
 		}
 
-		// Process the event message
-		if err := processEventMessage(ctx, queues, genericEvent, msg.MessageBody); err != nil {
+		// Send the message back to its queue
+		fmt.Printf("Sending message to the queue: %s\n", queueName)
+		if err := queues.SendMessage(ctx, queueName, msg.MessageBody); err != nil {
 			return errors.New("failed to process message")
 		}
 
@@ -61,26 +62,3 @@ func ReplayUnprocessableMessages(ctx context.Context, cfg *config.Config, queues
 	fmt.Println("Reprocessing of unprocessable messages completed.")
 	return
 }
-
-// processEventMessage processes the event message based on its EventType.
-func processEventMessage(ctx context.Context, queues *queue.Queues, event GenericEvent, messageBody string) error {
-	fmt.Printf("Sending message to the queue for event type: %v\n", event.EventType)
-
-	switch event.EventType {
-	case queueClient.ActiveStakingEventType:
-		return queues.ActiveStakingQueueClient.SendMessage(ctx, messageBody)
-	case queueClient.UnbondingStakingEventType:
-		return queues.UnbondingStakingQueueClient.SendMessage(ctx, messageBody)
-	case queueClient.WithdrawStakingEventType:
-		return queues.WithdrawStakingQueueClient.SendMessage(ctx, messageBody)
-	case queueClient.ExpiredStakingEventType:
-		return queues.ExpiredStakingQueueClient.SendMessage(ctx, messageBody)
-	case queueClient.StatsEventType:
-		return queues.StatsQueueClient.SendMessage(ctx, messageBody)
-	case queueClient.BtcInfoEventType:
-		return queues.BtcInfoQueueClient.SendMessage(ctx, messageBody)
-	default:
-		fmt.Printf("Error: unknown event type: %v\n", event.EventType)
-		return fmt.Errorf("unknown event type: %v", event.EventType)
-	}
-}
\ No newline at end of file
@@ -17,11 +17,19 @@ const docTemplate = `{
     "paths": {
         "/healthcheck": {
             "get": {
-                "description": "Health check the service, including ping database connection",
+                "description": "Health check the service, including ping database connection\nProvide details=true to also get the versions of the loaded global params and finality providers files",
                 "produces": [
                     "application/json"
                 ],
                 "summary": "Health check endpoint",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include the loaded config file versions",
+                        "name": "details",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "Server is up and running",
@@ -32,30 +40,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/delegation": {
-            "get": {
-                "description": "Retrieves a delegation by a given transaction hash",
+        "/v1/admin/unbonding/{staking_tx_hash_hex}/state": {
+            "post": {
+                "description": "Records the state of an unbonding request reported by the unbonding pipeline. The request only moves\nforward through PICKED_UP, COVENANT_SIGNATURES_COLLECTED, BROADCAST and CONFIRMED, and can be FAILED\nwith a reason before it is confirmed. Reporting the current state again is a no-op.\nRequires the admin api key in the X-Admin-Api-Key header.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
+                "summary": "Report unbonding request state",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Staking transaction hash in hex format",
+                        "description": "Staking Transaction Hash Hex",
                         "name": "staking_tx_hash_hex",
-                        "in": "query",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Reported state",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateUnbondingStateRequestPayload"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Delegation",
+                        "description": "Unbonding request status",
                         "schema": {
-                            "$ref": "#/definitions/handlers.PublicResponse-services_DelegationPublic"
+                            "$ref": "#/definitions/handlers.PublicResponse-services_UnbondingStatusPublic"
                         }
                     },
                     "400": {
-                        "description": "Error: Bad Request",
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin api key"
+                    },
+                    "403": {
+                        "description": "The unbonding request can not transition to the state",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Unbonding request not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
                         }
@@ -63,81 +99,126 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/finality-providers": {
+        "/v1/admin/unprocessable-messages": {
             "get": {
-                "description": "Fetches details of all active finality providers sorted by their active total value locked (ActiveTvl) in descending order.",
+                "description": "Lists the queue messages which exceeded the retry attempts, the latest first.\nRequires the admin api key in the X-Admin-Api-Key header.",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get Active Finality Providers",
+                "summary": "List unprocessable messages",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Pagination key to fetch the next page of finality providers",
+                        "description": "Filter by the source queue",
+                        "name": "queue_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by the failure unix timestamp, inclusive",
+                        "name": "failed_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by the failure unix timestamp, inclusive",
+                        "name": "failed_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of messages",
                         "name": "pagination_key",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "A list of finality providers sorted by ActiveTvl in descending order",
+                        "description": "Unprocessable messages",
                         "schema": {
-                            "$ref": "#/definitions/handlers.PublicResponse-array_services_FpDetailsPublic"
+                            "$ref": "#/definitions/handlers.PublicResponse-array_services_UnprocessableMessagePublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter or pagination key",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
                         }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin api key"
                     }
                 }
             }
         },
-        "/v1/global-params": {
-            "get": {
-                "description": "Retrieves the global parameters for Babylon, including finality provider details.",
+        "/v1/admin/unprocessable-messages/replay": {
+            "post": {
+                "description": "Replays the messages matching the filters, the oldest first. A failed replay doesn't stop the\nremaining ones, the ids of the replayed and failed messages are returned.\nRequires the admin api key in the X-Admin-Api-Key header.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get Babylon global parameters",
+                "summary": "Replay unprocessable messages",
+                "parameters": [
+                    {
+                        "description": "Filters of the messages to replay",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReplayUnprocessableMessagesRequestPayload"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Global parameters",
+                        "description": "Replay result",
                         "schema": {
-                            "$ref": "#/definitions/handlers.PublicResponse-services_GlobalParamsPublic"
+                            "$ref": "#/definitions/handlers.PublicResponse-services_UnprocessableMessagesReplayPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
                         }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin api key"
                     }
                 }
             }
         },
-        "/v1/staker/delegation/check": {
+        "/v1/admin/unprocessable-messages/{id}": {
             "get": {
-                "description": "Check if a staker has an active delegation by the staker BTC address (Taproot only)\nOptionally, you can provide a timeframe to check if the delegation is active within the provided timeframe\nThe available timeframe is \"today\" which checks after UTC 12AM of the current day",
+                "description": "Requires the admin api key in the X-Admin-Api-Key header.",
                 "produces": [
                     "application/json"
                 ],
+                "summary": "Get unprocessable message",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Staker BTC address in Taproot format",
-                        "name": "address",
-                        "in": "query",
+                        "description": "Unprocessable Message Id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "enum": [
-                            "today"
-                        ],
-                        "type": "string",
-                        "description": "Check if the delegation is active within the provided timeframe",
-                        "name": "timeframe",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Result",
+                        "description": "Unprocessable message",
                         "schema": {
-                            "$ref": "#/definitions/handlers.Result"
+                            "$ref": "#/definitions/handlers.PublicResponse-services_UnprocessableMessagePublic"
                         }
                     },
-                    "400": {
-                        "description": "Error: Bad Request",
+                    "401": {
+                        "description": "Missing or invalid admin api key"
+                    },
+                    "404": {
+                        "description": "Unprocessable message not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
                         }
@@ -145,36 +226,81 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/staker/delegations": {
-            "get": {
-                "description": "Retrieves delegations for a given staker",
-                "produces": [
+        "/v1/admin/unprocessable-messages/{id}/discard": {
+            "post": {
+                "description": "Removes the message without replaying it, the message is kept in the audit along with the reason.\nRequires the admin api key in the X-Admin-Api-Key header.",
+                "consumes": [
                     "application/json"
                 ],
+                "summary": "Discard unprocessable message",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Staker BTC Public Key",
-                        "name": "staker_btc_pk",
-                        "in": "query",
+                        "description": "Unprocessable Message Id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
+                    {
+                        "description": "Discard reason",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DiscardUnprocessableMessageRequestPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The message is discarded"
+                    },
+                    "400": {
+                        "description": "Missing reason",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin api key"
+                    },
+                    "404": {
+                        "description": "Unprocessable message not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/unprocessable-messages/{id}/replay": {
+            "post": {
+                "description": "Sends the message back to its original queue and removes it from the unprocessable messages.\nRequires the admin api key in the X-Admin-Api-Key header.",
+                "summary": "Replay unprocessable message",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Pagination key to fetch the next page of delegations",
-                        "name": "pagination_key",
-                        "in": "query"
+                        "description": "Unprocessable Message Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of delegations and pagination token",
+                        "description": "The message is replayed"
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin api key"
+                    },
+                    "404": {
+                        "description": "Unprocessable message not found",
                         "schema": {
-                            "$ref": "#/definitions/handlers.PublicResponse-array_services_DelegationPublic"
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
                         }
                     },
-                    "400": {
-                        "description": "Error: Bad Request",
+                    "422": {
+                        "description": "The original queue of the message is unknown",
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
                         }
@@ -182,47 +308,133 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/stats": {
+        "/v1/admin/webhooks": {
             "get": {
-                "description": "Fetches overall stats for babylon staking including tvl, total delegations, active tvl, active delegations and total stakers.",
+                "description": "Requires the admin api key in the X-Admin-Api-Key header.",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get Overall Stats",
+                "summary": "List webhook subscriptions",
                 "responses": {
                     "200": {
-                        "description": "Overall stats for babylon staking",
+                        "description": "Webhook subscriptions",
                         "schema": {
-                            "$ref": "#/definitions/handlers.PublicResponse-services_OverallStatsPublic"
+                            "$ref": "#/definitions/handlers.PublicResponse-array_services_WebhookSubscriptionPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin api key"
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers a webhook receiving a POST request for each delegation state transition matching the filters.\nEach request is signed with the returned secret: the X-Webhook-Signature header holds the hex encoded\nHMAC-SHA256 of \"{X-Webhook-Timestamp}.{body}\". Failed deliveries are retried with exponential backoff.\nRequires the admin api key in the X-Admin-Api-Key header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create webhook subscription",
+                "parameters": [
+                    {
+                        "description": "Webhook Subscription",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateWebhookSubscriptionRequestPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The subscription along with its secret",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_WebhookSubscriptionPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
                         }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin api key"
                     }
                 }
             }
         },
-        "/v1/stats/staker": {
+        "/v1/admin/webhooks/{id}": {
+            "delete": {
+                "description": "The pending deliveries of the subscription are not sent anymore.\nRequires the admin api key in the X-Admin-Api-Key header.",
+                "summary": "Delete webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook Subscription Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The subscription is deleted"
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin api key"
+                    },
+                    "404": {
+                        "description": "Webhook subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/webhooks/{id}/deliveries": {
             "get": {
-                "description": "Fetches details of top stakers by their active total value locked (ActiveTvl) in descending order.",
+                "description": "Lists the deliveries of the subscription along with their attempts, the latest first.\nRequires the admin api key in the X-Admin-Api-Key header.",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get Top Staker Stats by Active TVL",
+                "summary": "List webhook deliveries",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Pagination key to fetch the next page of top stakers",
+                        "description": "Webhook Subscription Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of deliveries",
                         "name": "pagination_key",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of top stakers by active tvl",
+                        "description": "Webhook deliveries",
                         "schema": {
-                            "$ref": "#/definitions/handlers.PublicResponse-array_services_StakerStatsPublic"
+                            "$ref": "#/definitions/handlers.PublicResponse-array_services_WebhookDeliveryPublic"
                         }
                     },
                     "400": {
-                        "description": "Error: Bad Request",
+                        "description": "Invalid pagination key",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin api key"
+                    },
+                    "404": {
+                        "description": "Webhook subscription not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
                         }
@@ -230,33 +442,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/unbonding": {
-            "post": {
-                "description": "Unbonds a delegation by processing the provided transaction details. This is an async operation.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/v1/delegation": {
+            "get": {
+                "description": "Retrieves a delegation by a given transaction hash",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Unbond delegation",
                 "parameters": [
                     {
-                        "description": "Unbonding Request Payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.UnbondDelegationRequestPayload"
-                        }
+                        "type": "string",
+                        "description": "Staking transaction hash in hex format",
+                        "name": "staking_tx_hash_hex",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Embed the state transition timeline of the delegation",
+                        "name": "include_timeline",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Request accepted and will be processed asynchronously"
+                    "200": {
+                        "description": "Delegation",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_DelegationPublic"
+                        }
                     },
                     "400": {
-                        "description": "Invalid request payload",
+                        "description": "Error: Bad Request",
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
                         }
@@ -264,17 +479,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/unbonding/eligibility": {
+        "/v1/delegation/history": {
             "get": {
-                "description": "Checks if a delegation identified by its staking transaction hash is eligible for unbonding.",
+                "description": "Retrieves the state transitions of a delegation by a given transaction hash in the order they happened",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Check unbonding eligibility",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Staking Transaction Hash Hex",
+                        "description": "Staking transaction hash in hex format",
                         "name": "staking_tx_hash_hex",
                         "in": "query",
                         "required": true
@@ -282,335 +496,2291 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "The delegation is eligible for unbonding"
+                        "description": "Delegation state transitions",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-array_services_DelegationStateHistoryPublic"
+                        }
                     },
                     "400": {
-                        "description": "Missing or invalid 'staking_tx_hash_hex' query parameter",
+                        "description": "Error: Bad Request",
                         "schema": {
                             "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
                         }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/delegations/lookup": {
+            "post": {
+                "description": "Retrieves the delegations of multiple staking transaction hashes at once.\nThe unknown transaction hashes are listed as not found, the duplicates are reported once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "description": "Staking transaction hashes in hex format, up to the configured lookup size",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DelegationsLookupRequestPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Found delegations and not found transaction hashes",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_DelegationsLookupPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/expirations": {
+            "get": {
+                "description": "Forecasts the delegations and staking value unlocked by the timelock expiries within the blocks following the\nlatest btc height, bucketed by btc height. The unlock time of each bucket is estimated from a 10 minutes block time.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Expirations Forecast",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of upcoming btc blocks to forecast, defaults to 1008 and is at most 4320",
+                        "name": "within_blocks",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of btc blocks in each bucket, defaults to 144",
+                        "name": "bucket_blocks",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Expirations forecast",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_ExpirationsForecastPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/finality-providers": {
+            "get": {
+                "description": "Fetches details of all active finality providers sorted by their active total value locked (ActiveTvl) in descending order.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Active Finality Providers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of finality providers",
+                        "name": "pagination_key",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A list of finality providers sorted by ActiveTvl in descending order",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-array_services_FpDetailsPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/finality-providers/{fp_pk}": {
+            "get": {
+                "description": "Fetches the details of a finality provider, including its TVL, delegation counts and number of active and total unique stakers,\nalong with its delegations sorted by the staking start height in descending order.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Finality Provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Finality provider BTC public key",
+                        "name": "fp_pk",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "active",
+                                "unbonding_requested",
+                                "unbonding",
+                                "unbonded",
+                                "withdrawn"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter delegations by states, can be provided multiple times",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of delegations",
+                        "name": "pagination_key",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Finality provider details and delegations",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_FpDetailsWithDelegationsPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/global-params": {
+            "get": {
+                "description": "Retrieves the global parameters for Babylon, including finality provider details.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Babylon global parameters",
+                "responses": {
+                    "200": {
+                        "description": "Global parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_GlobalParamsPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/global-params/at-height": {
+            "get": {
+                "description": "Retrieves the global parameters version applied at the given BTC height,\nalong with the next scheduled version and the number of blocks until it activates.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Babylon global parameters at a BTC height",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "BTC height",
+                        "name": "height",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Global parameters at the height",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_GlobalParamsAtHeightPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/global-params/current": {
+            "get": {
+                "description": "Retrieves the global parameters version applied at the latest BTC height,\nalong with the next scheduled version and the number of blocks until it activates.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get current Babylon global parameters",
+                "responses": {
+                    "200": {
+                        "description": "Current global parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_GlobalParamsAtHeightPublic"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/search": {
+            "get": {
+                "description": "Classifies the query as a transaction hash, a public key or a BTC address and returns the matching\ndelegations, unbonding transactions, stakers and finality providers, each with a link to its endpoint.\nA 32 bytes hex query is looked up both as a transaction hash and as a public key.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction hash, public key or BTC address",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Matching entities",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-array_services_SearchResultPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/delegation/check": {
+            "get": {
+                "description": "Check if a staker has an active delegation by the staker BTC address\nThe address can be in any format derived from the staker public key: Taproot, native SegWit or nested SegWit\nOptionally, you can provide a timeframe to check if the delegation is active within the provided timeframe\nThe available timeframe is \"today\" which checks after UTC 12AM of the current day",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC address in Taproot, native SegWit or nested SegWit format",
+                        "name": "address",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "today"
+                        ],
+                        "type": "string",
+                        "description": "Check if the delegation is active within the provided timeframe",
+                        "name": "timeframe",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Result",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Result"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/delegations": {
+            "get": {
+                "description": "Retrieves delegations for a given staker sorted by the staking start height.\nThe same filters and sort order shall be provided when fetching the next page with the pagination key,\notherwise the pagination key is rejected.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key, either staker_btc_pk or address is required",
+                        "name": "staker_btc_pk",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Staker BTC address in Taproot, native SegWit or nested SegWit format",
+                        "name": "address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "active",
+                                "unbonding_requested",
+                                "unbonding",
+                                "unbonded",
+                                "withdrawn"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter by delegation states, can be provided multiple times",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by finality provider public key",
+                        "name": "finality_provider_pk_hex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum staking value in satoshis (inclusive)",
+                        "name": "min_staking_value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum staking value in satoshis (inclusive)",
+                        "name": "max_staking_value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum staking start height (inclusive)",
+                        "name": "min_start_height",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum staking start height (inclusive)",
+                        "name": "max_start_height",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order by staking start height, defaults to desc",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of delegations",
+                        "name": "pagination_key",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of delegations and pagination token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-array_services_DelegationPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staker/portfolio": {
+            "get": {
+                "description": "Aggregates the delegations of several staker public keys or addresses. Returns the active and total TVL\nof each staker and combined, the number and staking value of their delegations by state, and their\ndelegations merged into a single list sorted by the staking start height.\nThe filters only apply to the delegations. The same stakers, filters and sort order shall be provided\nwhen fetching the next page with the pagination key, otherwise the pagination key is rejected.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Staker BTC public keys, can be provided multiple times",
+                        "name": "staker_btc_pk",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Staker BTC addresses in Taproot, native SegWit or nested SegWit format, can be provided multiple times",
+                        "name": "address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "active",
+                                "unbonding_requested",
+                                "unbonding",
+                                "unbonded",
+                                "withdrawn"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter delegations by states, can be provided multiple times",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter delegations by finality provider public key",
+                        "name": "finality_provider_pk_hex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum staking value in satoshis (inclusive)",
+                        "name": "min_staking_value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum staking value in satoshis (inclusive)",
+                        "name": "max_staking_value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum staking start height (inclusive)",
+                        "name": "min_start_height",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum staking start height (inclusive)",
+                        "name": "max_start_height",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order by staking start height, defaults to desc",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of delegations",
+                        "name": "pagination_key",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Staker portfolio and pagination token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_StakerPortfolioPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/staking/validate": {
+            "post": {
+                "description": "Validates an unsigned or signed staking transaction against the global params applied at the btc height\nand the registered finality providers, without broadcasting it. Returns the result of each validation rule.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Validate staking transaction",
+                "parameters": [
+                    {
+                        "description": "Staking Transaction Validation Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidateStakingTxRequestPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Validation result per rule",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_StakingTxValidationPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats": {
+            "get": {
+                "description": "Fetches overall stats for babylon staking including tvl, total delegations, active tvl, active delegations and total stakers.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Overall Stats",
+                "responses": {
+                    "200": {
+                        "description": "Overall stats for babylon staking",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_OverallStatsPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/history": {
+            "get": {
+                "description": "Fetches the historical stats snapshots between the from and to timestamps, bucketed by the given interval.\nThe latest snapshot within each bucket is returned. By default the overall stats are returned,\nprovide either finality_provider_pk_hex or staker_btc_pk to fetch the history of a single finality provider or staker.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Stats History",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Start of the range in unix timestamp (seconds), defaults to 24 hours before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End of the range in unix timestamp (seconds), defaults to now",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bucket interval as a duration, e.g. 15m, 1h, 24h. Defaults to 1h",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Finality provider public key in hex format",
+                        "name": "finality_provider_pk_hex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Staker BTC public key in hex format",
+                        "name": "staker_btc_pk",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Overall stats history, or the finality provider / staker stats history if requested",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-array_services_OverallStatsHistoryPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/staker": {
+            "get": {
+                "description": "Fetches details of top stakers by their active total value locked (ActiveTvl) in descending order.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Top Staker Stats by Active TVL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pagination key to fetch the next page of top stakers",
+                        "name": "pagination_key",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of top stakers by active tvl",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-array_services_StakerStatsPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/staker/{pk}": {
+            "get": {
+                "description": "Fetches the active and total tvl and delegation counts of a staker, the number and staking value of its\ndelegations by state, and its rank among the stakers by active tvl. The stakers with the same active tvl share the rank.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get Staker Stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC public key in hex format",
+                        "name": "pk",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Staker stats and rank",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_StakerStatsDetailsPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Error: Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stream/staker": {
+            "get": {
+                "description": "Opens a server-sent events stream emitting a \"delegation\" event with the latest version of the delegation\nwhenever one of the staker's delegations is created or changes state, e.g. once an unbonding request is\npicked up. The changes are read from the database, hence they are emitted regardless of the service\nreplica which processed them.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "summary": "Stream staker delegation changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staker BTC Public Key",
+                        "name": "staker_btc_pk",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of delegation events",
+                        "schema": {
+                            "$ref": "#/definitions/services.DelegationPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Error: Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/unbonding": {
+            "post": {
+                "description": "Unbonds a delegation by processing the provided transaction details. This is an async operation.\nThe request can be retried safely with the same Idempotency-Key header, the retries get the response\nof the original request for 24 hours. With dry_run, the request is only verified and nothing is saved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Unbond delegation",
+                "parameters": [
+                    {
+                        "description": "Unbonding Request Payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UnbondDelegationRequestPayload"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key of the request for the caller, up to 255 characters",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only verify the request, responds with 200 if it would be accepted",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The dry run request would be accepted"
+                    },
+                    "202": {
+                        "description": "Request accepted and will be processed asynchronously"
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Delegation not eligible for unbonding or invalid unbonding request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "A request with the same idempotency key is being processed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "The idempotency key has been used for another request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/unbonding/eligibility": {
+            "get": {
+                "description": "Checks if a delegation identified by its staking transaction hash is eligible for unbonding.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Check unbonding eligibility",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staking Transaction Hash Hex",
+                        "name": "staking_tx_hash_hex",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The delegation is eligible for unbonding"
+                    },
+                    "400": {
+                        "description": "Missing or invalid 'staking_tx_hash_hex' query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/unbonding/status": {
+            "get": {
+                "description": "Retrieves the progress of the unbonding request of a delegation through the unbonding pipeline,\nalong with the states it went through. The state is one of INSERTED, PICKED_UP,\nCOVENANT_SIGNATURES_COLLECTED, BROADCAST, CONFIRMED or FAILED, the latter with a failure reason.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get unbonding request status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staking Transaction Hash Hex",
+                        "name": "staking_tx_hash_hex",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unbonding request status",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_UnbondingStatusPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or invalid 'staking_tx_hash_hex' query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Unbonding request not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/unbonding/template": {
+            "get": {
+                "description": "Builds the unsigned unbonding transaction of an active delegation, as expected by the unbonding endpoint.\nThe unbonding fee and script are taken from the global params version applied to the delegation.\nThe staker must sign the returned sighash, or the PSBT, and submit the signature to the unbonding endpoint.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get unsigned unbonding transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staking Transaction Hash Hex",
+                        "name": "staking_tx_hash_hex",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unsigned unbonding transaction",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_UnbondingTemplatePublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or invalid 'staking_tx_hash_hex' query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Delegation is not active",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Delegation not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/withdraw/template": {
+            "get": {
+                "description": "Builds the unsigned withdrawal transaction of an unbonded delegation, spending the timelock path\nof the unbonding output if the delegation was unbonded early, or of the staking output otherwise.\nThe staker must sign the returned sighash, or the PSBT, and place the signature in the witness\nfollowed by the timelock script and the control block.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get unsigned withdrawal transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Staking Transaction Hash Hex",
+                        "name": "staking_tx_hash_hex",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Taproot or native SegWit address receiving the withdrawn funds",
+                        "name": "destination",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Fee rate in satoshis per virtual byte, at most 10000",
+                        "name": "fee_rate",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unsigned withdrawal transaction",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicResponse-services_WithdrawalTemplatePublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters or the withdrawal output would be dust",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Delegation is not unbonded",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Delegation not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_babylonchain_staking-api-service_internal_types.Error"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_babylonchain_staking-api-service_internal_types.Error": {
+            "type": "object",
+            "properties": {
+                "err": {},
+                "errorCode": {
+                    "$ref": "#/definitions/types.ErrorCode"
+                },
+                "statusCode": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.CreateWebhookSubscriptionRequestPayload": {
+            "type": "object",
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "finality_provider_pk_hex": {
+                    "type": "string"
+                },
+                "staker_pk_hex": {
+                    "description": "Optional filters, an empty filter matches any value",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.DelegationsLookupRequestPayload": {
+            "type": "object",
+            "properties": {
+                "staking_tx_hash_hexes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handlers.DiscardUnprocessableMessageRequestPayload": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublicResponse-array_services_DelegationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.DelegationPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-array_services_DelegationStateHistoryPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.DelegationStateHistoryPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-array_services_FpDetailsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.FpDetailsPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-array_services_OverallStatsHistoryPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.OverallStatsHistoryPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-array_services_SearchResultPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.SearchResultPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-array_services_StakerStatsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.StakerStatsPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-array_services_UnprocessableMessagePublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.UnprocessableMessagePublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-array_services_WebhookDeliveryPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.WebhookDeliveryPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-array_services_WebhookSubscriptionPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.WebhookSubscriptionPublic"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_DelegationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.DelegationPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_DelegationsLookupPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.DelegationsLookupPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_ExpirationsForecastPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.ExpirationsForecastPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_FpDetailsWithDelegationsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.FpDetailsWithDelegationsPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_GlobalParamsAtHeightPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.GlobalParamsAtHeightPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_GlobalParamsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.GlobalParamsPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_OverallStatsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.OverallStatsPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_StakerPortfolioPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.StakerPortfolioPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_StakerStatsDetailsPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.StakerStatsDetailsPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_StakingTxValidationPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.StakingTxValidationPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_UnbondingStatusPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.UnbondingStatusPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_UnbondingTemplatePublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.UnbondingTemplatePublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_UnprocessableMessagePublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.UnprocessableMessagePublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_UnprocessableMessagesReplayPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.UnprocessableMessagesReplayPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_WebhookSubscriptionPublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.WebhookSubscriptionPublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.PublicResponse-services_WithdrawalTemplatePublic": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/services.WithdrawalTemplatePublic"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/handlers.paginationResponse"
+                }
+            }
+        },
+        "handlers.ReplayUnprocessableMessagesRequestPayload": {
+            "type": "object",
+            "properties": {
+                "failed_after": {
+                    "type": "integer"
+                },
+                "failed_before": {
+                    "type": "integer"
+                },
+                "queue_name": {
+                    "description": "Optional filters, an empty filter matches any value",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.Result": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "etag": {
+                    "description": "Whether the response is served with an ETag and answers the\nconditional requests with 304 Not Modified",
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.UnbondDelegationRequestPayload": {
+            "type": "object",
+            "properties": {
+                "staker_signed_signature_hex": {
+                    "type": "string"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "unbonding_tx_hash_hex": {
+                    "type": "string"
+                },
+                "unbonding_tx_hex": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.UpdateUnbondingStateRequestPayload": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "Required for the FAILED state",
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ValidateStakingTxRequestPayload": {
+            "type": "object",
+            "properties": {
+                "btc_height": {
+                    "description": "Optional, defaults to the height of the next btc block",
+                    "type": "integer"
+                },
+                "staking_tx_hex": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.paginationResponse": {
+            "type": "object",
+            "properties": {
+                "next_key": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.DelegationPublic": {
+            "type": "object",
+            "properties": {
+                "blocks_remaining": {
+                    "description": "Only populated once the latest btc height is known",
+                    "type": "integer"
+                },
+                "estimated_unlock_time": {
+                    "type": "string"
+                },
+                "expire_height": {
+                    "description": "The btc height at which the timelock of the unbonding tx, or of the\nstaking tx if not unbonded early, expires",
+                    "type": "integer"
+                },
+                "finality_provider_pk_hex": {
+                    "type": "string"
+                },
+                "is_overflow": {
+                    "type": "boolean"
+                },
+                "staker_pk_hex": {
+                    "type": "string"
+                },
+                "staking_tx": {
+                    "$ref": "#/definitions/services.TransactionPublic"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "staking_value": {
+                    "type": "integer"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "timeline": {
+                    "description": "Only populated if the timeline is requested",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.DelegationStateHistoryPublic"
+                    }
+                },
+                "unbonding_tx": {
+                    "$ref": "#/definitions/services.TransactionPublic"
+                }
+            }
+        },
+        "services.DelegationStateHistoryPublic": {
+            "type": "object",
+            "properties": {
+                "btc_height": {
+                    "type": "integer"
+                },
+                "new_state": {
+                    "type": "string"
+                },
+                "previous_state": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.DelegationStateTotalPublic": {
+            "type": "object",
+            "properties": {
+                "delegations": {
+                    "type": "integer"
+                },
+                "staking_value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.DelegationsLookupPublic": {
+            "type": "object",
+            "properties": {
+                "found": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.DelegationPublic"
+                    }
+                },
+                "not_found": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "services.ExpirationBucketPublic": {
+            "type": "object",
+            "properties": {
+                "delegations": {
+                    "type": "integer"
+                },
+                "end_height": {
+                    "type": "integer"
+                },
+                "estimated_unlock_time": {
+                    "description": "Estimated time at which the end height is reached",
+                    "type": "string"
+                },
+                "staking_value": {
+                    "type": "integer"
+                },
+                "start_height": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.ExpirationsForecastPublic": {
+            "type": "object",
+            "properties": {
+                "btc_height": {
+                    "type": "integer"
+                },
+                "bucket_blocks": {
+                    "type": "integer"
+                },
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ExpirationBucketPublic"
                     }
+                },
+                "total_delegations": {
+                    "type": "integer"
+                },
+                "total_staking_value": {
+                    "type": "integer"
+                },
+                "within_blocks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.FpDescriptionPublic": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "string"
+                },
+                "identity": {
+                    "type": "string"
+                },
+                "moniker": {
+                    "type": "string"
+                },
+                "security_contact": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_babylonchain_staking-api-service_internal_types.Error": {
+        },
+        "services.FpDetailsPublic": {
             "type": "object",
             "properties": {
-                "err": {},
-                "errorCode": {
-                    "$ref": "#/definitions/types.ErrorCode"
+                "active_delegations": {
+                    "type": "integer"
                 },
-                "statusCode": {
+                "active_stakers": {
+                    "type": "integer"
+                },
+                "active_tvl": {
+                    "type": "integer"
+                },
+                "btc_pk": {
+                    "type": "string"
+                },
+                "commission": {
+                    "type": "string"
+                },
+                "description": {
+                    "$ref": "#/definitions/services.FpDescriptionPublic"
+                },
+                "total_delegations": {
+                    "type": "integer"
+                },
+                "total_stakers": {
+                    "type": "integer"
+                },
+                "total_tvl": {
                     "type": "integer"
                 }
             }
         },
-        "handlers.PublicResponse-array_services_DelegationPublic": {
+        "services.FpDetailsWithDelegationsPublic": {
             "type": "object",
             "properties": {
-                "data": {
+                "active_delegations": {
+                    "type": "integer"
+                },
+                "active_stakers": {
+                    "type": "integer"
+                },
+                "active_tvl": {
+                    "type": "integer"
+                },
+                "btc_pk": {
+                    "type": "string"
+                },
+                "commission": {
+                    "type": "string"
+                },
+                "delegations": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/services.DelegationPublic"
                     }
                 },
-                "pagination": {
-                    "$ref": "#/definitions/handlers.paginationResponse"
+                "description": {
+                    "$ref": "#/definitions/services.FpDescriptionPublic"
+                },
+                "total_delegations": {
+                    "type": "integer"
+                },
+                "total_stakers": {
+                    "type": "integer"
+                },
+                "total_tvl": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.PublicResponse-array_services_FpDetailsPublic": {
+        "services.GlobalParamsAtHeightPublic": {
             "type": "object",
             "properties": {
-                "data": {
+                "blocks_until_next": {
+                    "description": "Number of blocks until the next version activates, omitted if there is no next version",
+                    "type": "integer"
+                },
+                "btc_height": {
+                    "type": "integer"
+                },
+                "current": {
+                    "$ref": "#/definitions/services.VersionedGlobalParamsPublic"
+                },
+                "next": {
+                    "$ref": "#/definitions/services.VersionedGlobalParamsPublic"
+                }
+            }
+        },
+        "services.GlobalParamsPublic": {
+            "type": "object",
+            "properties": {
+                "versions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/services.FpDetailsPublic"
+                        "$ref": "#/definitions/services.VersionedGlobalParamsPublic"
                     }
+                }
+            }
+        },
+        "services.MessageProcessingAttemptPublic": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
                 },
-                "pagination": {
-                    "$ref": "#/definitions/handlers.paginationResponse"
+                "error_code": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "trace_id": {
+                    "type": "string"
                 }
             }
         },
-        "handlers.PublicResponse-array_services_StakerStatsPublic": {
+        "services.OverallStatsHistoryPublic": {
             "type": "object",
             "properties": {
-                "data": {
+                "active_delegations": {
+                    "type": "integer"
+                },
+                "active_tvl": {
+                    "type": "integer"
+                },
+                "btc_height": {
+                    "type": "integer"
+                },
+                "pending_tvl": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "description": "Start of the interval bucket in unix timestamp (seconds)",
+                    "type": "integer"
+                },
+                "total_delegations": {
+                    "type": "integer"
+                },
+                "total_stakers": {
+                    "type": "integer"
+                },
+                "total_tvl": {
+                    "type": "integer"
+                },
+                "unconfirmed_tvl": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.OverallStatsPublic": {
+            "type": "object",
+            "properties": {
+                "active_delegations": {
+                    "type": "integer"
+                },
+                "active_tvl": {
+                    "type": "integer"
+                },
+                "pending_tvl": {
+                    "type": "integer"
+                },
+                "total_delegations": {
+                    "type": "integer"
+                },
+                "total_stakers": {
+                    "type": "integer"
+                },
+                "total_tvl": {
+                    "type": "integer"
+                },
+                "unconfirmed_tvl": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.PortfolioStatsPublic": {
+            "type": "object",
+            "properties": {
+                "active_delegations": {
+                    "type": "integer"
+                },
+                "active_tvl": {
+                    "type": "integer"
+                },
+                "total_delegations": {
+                    "type": "integer"
+                },
+                "total_tvl": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.SearchResultPublic": {
+            "type": "object",
+            "properties": {
+                "finality_provider_pk_hex": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "staker_pk_hex": {
+                    "type": "string"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/services.SearchResultType"
+                }
+            }
+        },
+        "services.SearchResultType": {
+            "type": "string",
+            "enum": [
+                "staking_tx",
+                "unbonding_tx",
+                "staker",
+                "finality_provider"
+            ],
+            "x-enum-varnames": [
+                "SearchResultStakingTx",
+                "SearchResultUnbondingTx",
+                "SearchResultStaker",
+                "SearchResultFinalityProvider"
+            ]
+        },
+        "services.StakerPortfolioPublic": {
+            "type": "object",
+            "properties": {
+                "combined": {
+                    "$ref": "#/definitions/services.PortfolioStatsPublic"
+                },
+                "delegations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.DelegationPublic"
+                    }
+                },
+                "stakers": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/services.StakerStatsPublic"
                     }
                 },
-                "pagination": {
-                    "$ref": "#/definitions/handlers.paginationResponse"
+                "state_totals": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/services.DelegationStateTotalPublic"
+                    }
                 }
             }
         },
-        "handlers.PublicResponse-services_DelegationPublic": {
+        "services.StakerStatsDetailsPublic": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/services.DelegationPublic"
+                "active_delegations": {
+                    "type": "integer"
                 },
-                "pagination": {
-                    "$ref": "#/definitions/handlers.paginationResponse"
+                "active_tvl": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "staker_pk_hex": {
+                    "type": "string"
+                },
+                "state_totals": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/services.DelegationStateTotalPublic"
+                    }
+                },
+                "total_delegations": {
+                    "type": "integer"
+                },
+                "total_tvl": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.PublicResponse-services_GlobalParamsPublic": {
+        "services.StakerStatsPublic": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/services.GlobalParamsPublic"
+                "active_delegations": {
+                    "type": "integer"
                 },
-                "pagination": {
-                    "$ref": "#/definitions/handlers.paginationResponse"
+                "active_tvl": {
+                    "type": "integer"
+                },
+                "staker_pk_hex": {
+                    "type": "string"
+                },
+                "total_delegations": {
+                    "type": "integer"
+                },
+                "total_tvl": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.PublicResponse-services_OverallStatsPublic": {
+        "services.StakingTxValidationPublic": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/services.OverallStatsPublic"
+                "btc_height": {
+                    "description": "The btc height used to select the params version",
+                    "type": "integer"
+                },
+                "params_version": {
+                    "type": "integer"
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.StakingTxValidationRulePublic"
+                    }
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "staking_value": {
+                    "type": "integer"
                 },
-                "pagination": {
-                    "$ref": "#/definitions/handlers.paginationResponse"
+                "valid": {
+                    "type": "boolean"
                 }
             }
         },
-        "handlers.Result": {
+        "services.StakingTxValidationRulePublic": {
             "type": "object",
             "properties": {
-                "data": {},
-                "status": {
-                    "type": "integer"
+                "message": {
+                    "type": "string"
+                },
+                "passed": {
+                    "type": "boolean"
+                },
+                "rule": {
+                    "type": "string"
                 }
             }
         },
-        "handlers.UnbondDelegationRequestPayload": {
+        "services.TransactionPublic": {
             "type": "object",
             "properties": {
-                "staker_signed_signature_hex": {
-                    "type": "string"
+                "output_index": {
+                    "type": "integer"
                 },
-                "staking_tx_hash_hex": {
-                    "type": "string"
+                "start_height": {
+                    "type": "integer"
                 },
-                "unbonding_tx_hash_hex": {
+                "start_timestamp": {
                     "type": "string"
                 },
-                "unbonding_tx_hex": {
+                "timelock": {
+                    "type": "integer"
+                },
+                "tx_hex": {
                     "type": "string"
                 }
             }
         },
-        "handlers.paginationResponse": {
+        "services.UnbondingStateTransitionPublic": {
             "type": "object",
             "properties": {
-                "next_key": {
+                "reason": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "timestamp": {
                     "type": "string"
                 }
             }
         },
-        "services.DelegationPublic": {
+        "services.UnbondingStatusPublic": {
             "type": "object",
             "properties": {
-                "finality_provider_pk_hex": {
+                "failure_reason": {
                     "type": "string"
                 },
-                "is_overflow": {
-                    "type": "boolean"
-                },
-                "staker_pk_hex": {
-                    "type": "string"
-                },
-                "staking_tx": {
-                    "$ref": "#/definitions/services.TransactionPublic"
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.UnbondingStateTransitionPublic"
+                    }
                 },
                 "staking_tx_hash_hex": {
                     "type": "string"
                 },
-                "staking_value": {
-                    "type": "integer"
-                },
                 "state": {
                     "type": "string"
                 },
-                "unbonding_tx": {
-                    "$ref": "#/definitions/services.TransactionPublic"
+                "unbonding_tx_hash_hex": {
+                    "type": "string"
                 }
             }
         },
-        "services.FpDescriptionPublic": {
+        "services.UnbondingTemplatePublic": {
             "type": "object",
             "properties": {
-                "details": {
+                "params_version": {
+                    "type": "integer"
+                },
+                "sighash_hex": {
+                    "description": "The sighash of the unbonding path of the staking output to be signed by the staker",
                     "type": "string"
                 },
-                "identity": {
+                "staking_tx_hash_hex": {
                     "type": "string"
                 },
-                "moniker": {
+                "unbonding_fee": {
+                    "type": "integer"
+                },
+                "unbonding_time": {
+                    "type": "integer"
+                },
+                "unbonding_tx_hash_hex": {
                     "type": "string"
                 },
-                "security_contact": {
+                "unbonding_tx_hex": {
                     "type": "string"
                 },
-                "website": {
+                "unbonding_tx_psbt": {
+                    "description": "Base64 encoded PSBT of the unsigned unbonding tx",
                     "type": "string"
+                },
+                "unbonding_value": {
+                    "type": "integer"
                 }
             }
         },
-        "services.FpDetailsPublic": {
+        "services.UnprocessableMessagePublic": {
             "type": "object",
             "properties": {
-                "active_delegations": {
-                    "type": "integer"
+                "attempt_history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.MessageProcessingAttemptPublic"
+                    }
                 },
-                "active_tvl": {
+                "attempts": {
                     "type": "integer"
                 },
-                "btc_pk": {
+                "error": {
                     "type": "string"
                 },
-                "commission": {
+                "error_code": {
                     "type": "string"
                 },
-                "description": {
-                    "$ref": "#/definitions/services.FpDescriptionPublic"
+                "failed_at": {
+                    "type": "string"
                 },
-                "total_delegations": {
-                    "type": "integer"
+                "id": {
+                    "type": "string"
                 },
-                "total_tvl": {
+                "message_body": {
+                    "type": "string"
+                },
+                "queue_name": {
+                    "type": "string"
+                },
+                "receipt": {
+                    "type": "string"
+                },
+                "status_code": {
                     "type": "integer"
+                },
+                "trace_id": {
+                    "type": "string"
                 }
             }
         },
-        "services.GlobalParamsPublic": {
+        "services.UnprocessableMessagesReplayPublic": {
             "type": "object",
             "properties": {
-                "versions": {
+                "failed": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/services.VersionedGlobalParamsPublic"
+                        "type": "string"
+                    }
+                },
+                "replayed": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
                 }
             }
         },
-        "services.OverallStatsPublic": {
+        "services.VersionedGlobalParamsPublic": {
             "type": "object",
             "properties": {
-                "active_delegations": {
+                "activation_height": {
                     "type": "integer"
                 },
-                "active_tvl": {
+                "cap_height": {
                     "type": "integer"
                 },
-                "total_delegations": {
+                "confirmation_depth": {
                     "type": "integer"
                 },
-                "total_stakers": {
+                "covenant_pks": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "covenant_quorum": {
                     "type": "integer"
                 },
-                "total_tvl": {
+                "max_staking_amount": {
                     "type": "integer"
                 },
-                "unconfirmed_tvl": {
+                "max_staking_time": {
                     "type": "integer"
-                }
-            }
-        },
-        "services.StakerStatsPublic": {
-            "type": "object",
-            "properties": {
-                "active_delegations": {
+                },
+                "min_staking_amount": {
                     "type": "integer"
                 },
-                "active_tvl": {
+                "min_staking_time": {
                     "type": "integer"
                 },
-                "staker_pk_hex": {
+                "staking_cap": {
+                    "type": "integer"
+                },
+                "tag": {
                     "type": "string"
                 },
-                "total_delegations": {
+                "unbonding_fee": {
                     "type": "integer"
                 },
-                "total_tvl": {
+                "unbonding_time": {
+                    "type": "integer"
+                },
+                "version": {
                     "type": "integer"
                 }
             }
         },
-        "services.TransactionPublic": {
+        "services.WebhookDeliveryAttemptPublic": {
             "type": "object",
             "properties": {
-                "output_index": {
-                    "type": "integer"
+                "error": {
+                    "type": "string"
                 },
-                "start_height": {
+                "status_code": {
                     "type": "integer"
                 },
-                "start_timestamp": {
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.WebhookDeliveryPublic": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.WebhookDeliveryAttemptPublic"
+                    }
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "timelock": {
-                    "type": "integer"
+                "event": {
+                    "$ref": "#/definitions/services.WebhookEventPublic"
                 },
-                "tx_hex": {
+                "id": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "description": "Only set for pending deliveries",
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "subscription_id": {
                     "type": "string"
                 }
             }
         },
-        "services.VersionedGlobalParamsPublic": {
+        "services.WebhookEventPublic": {
             "type": "object",
             "properties": {
-                "activation_height": {
+                "btc_height": {
                     "type": "integer"
                 },
-                "cap_height": {
-                    "type": "integer"
+                "event_type": {
+                    "type": "string"
                 },
-                "confirmation_depth": {
+                "finality_provider_pk_hex": {
+                    "type": "string"
+                },
+                "new_state": {
+                    "type": "string"
+                },
+                "previous_state": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "staker_pk_hex": {
+                    "type": "string"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "staking_value": {
                     "type": "integer"
                 },
-                "covenant_pks": {
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.WebhookSubscriptionPublic": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "event_types": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "covenant_quorum": {
-                    "type": "integer"
+                "finality_provider_pk_hex": {
+                    "type": "string"
                 },
-                "max_staking_amount": {
-                    "type": "integer"
+                "id": {
+                    "type": "string"
                 },
-                "max_staking_time": {
-                    "type": "integer"
+                "secret": {
+                    "description": "The HMAC key of the payload signatures, only returned on creation",
+                    "type": "string"
                 },
-                "min_staking_amount": {
+                "staker_pk_hex": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.WithdrawalTemplatePublic": {
+            "type": "object",
+            "properties": {
+                "control_block_hex": {
+                    "type": "string"
+                },
+                "destination": {
+                    "type": "string"
+                },
+                "fee": {
                     "type": "integer"
                 },
-                "min_staking_time": {
+                "fee_rate": {
                     "type": "integer"
                 },
-                "staking_cap": {
+                "params_version": {
                     "type": "integer"
                 },
-                "tag": {
+                "sighash_hex": {
                     "type": "string"
                 },
-                "unbonding_fee": {
+                "spending_from_unbonding": {
+                    "type": "boolean"
+                },
+                "spending_output_index": {
                     "type": "integer"
                 },
-                "unbonding_time": {
+                "spending_tx_hash_hex": {
+                    "description": "The tx whose timelock output is spent, either the staking or the unbonding tx",
+                    "type": "string"
+                },
+                "staking_tx_hash_hex": {
+                    "type": "string"
+                },
+                "timelock": {
                     "type": "integer"
                 },
-                "version": {
+                "timelock_script_hex": {
+                    "description": "The timelock path script and its control block, to be placed in the\nwitness after the staker signature",
+                    "type": "string"
+                },
+                "withdrawal_tx_hash_hex": {
+                    "type": "string"
+                },
+                "withdrawal_tx_hex": {
+                    "type": "string"
+                },
+                "withdrawal_tx_psbt": {
+                    "description": "Base64 encoded PSBT of the unsigned withdrawal tx",
+                    "type": "string"
+                },
+                "withdrawal_value": {
                     "type": "integer"
                 }
             }
@@ -623,8 +2793,10 @@ const docTemplate = `{
                 "NOT_FOUND",
                 "BAD_REQUEST",
                 "FORBIDDEN",
+                "UNAUTHORIZED",
                 "UNPROCESSABLE_ENTITY",
-                "REQUEST_TIMEOUT"
+                "REQUEST_TIMEOUT",
+                "CONFLICT"
             ],
             "x-enum-varnames": [
                 "InternalServiceError",
@@ -632,8 +2804,10 @@ const docTemplate = `{
                 "NotFound",
                 "BadRequest",
                 "Forbidden",
+                "Unauthorized",
                 "UnprocessableEntity",
-                "RequestTimeout"
+                "RequestTimeout",
+                "Conflict"
             ]
         }
     }
@@ -1,16 +1,20 @@
 package handlers
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/babylonchain/staking-api-service/internal/services"
 	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
 )
 
 // GetDelegationByTxHash @Summary Get a delegation
 // @Description Retrieves a delegation by a given transaction hash
 // @Produce json
 // @Param staking_tx_hash_hex query string true "Staking transaction hash in hex format"
+// @Param include_timeline query bool false "Embed the state transition timeline of the delegation"
 // @Success 200 {object} PublicResponse[services.DelegationPublic] "Delegation"
 // @Failure 400 {object} types.Error "Error: Bad Request"
 // @Router /v1/delegation [get]
@@ -19,10 +23,105 @@ func (h *Handler) GetDelegationByTxHash(request *http.Request) (*Result, *types.
 	if err != nil {
 		return nil, err
 	}
+	includeTimeline, err := parseBoolQuery(request, "include_timeline")
+	if err != nil {
+		return nil, err
+	}
 	delegation, err := h.services.GetDelegation(request.Context(), stakingTxHash)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewResult(services.FromDelegationDocument(delegation)), nil
+	delegations := []services.DelegationPublic{services.FromDelegationDocument(delegation)}
+	h.services.SetDelegationsExpiry(request.Context(), delegations)
+	delegationPublic := delegations[0]
+	if includeTimeline {
+		delegationPublic.Timeline, err = h.services.GetDelegationStateHistory(request.Context(), stakingTxHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewResult(delegationPublic), nil
+}
+
+type DelegationsLookupRequestPayload struct {
+	StakingTxHashHexes []string `json:"staking_tx_hash_hexes"`
+}
+
+func parseDelegationsLookupRequestPayload(
+	request *http.Request, maxLookupSize int,
+) (*DelegationsLookupRequestPayload, *types.Error) {
+	payload := &DelegationsLookupRequestPayload{}
+	err := json.NewDecoder(request.Body).Decode(payload)
+	if err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid request payload")
+	}
+	if len(payload.StakingTxHashHexes) == 0 {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "staking_tx_hash_hexes is required",
+		)
+	}
+	if len(payload.StakingTxHashHexes) > maxLookupSize {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("at most %d staking tx hashes can be looked up at once", maxLookupSize),
+		)
+	}
+	for _, txHashHex := range payload.StakingTxHashHexes {
+		if !utils.IsValidTxHash(txHashHex) {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "invalid staking tx hash: "+txHashHex,
+			)
+		}
+	}
+	return payload, nil
+}
+
+// LookupDelegations @Summary Look up delegations in bulk
+// @Description Retrieves the delegations of multiple staking transaction hashes at once.
+// @Description The unknown transaction hashes are listed as not found, the duplicates are reported once.
+// @Accept json
+// @Produce json
+// @Param payload body DelegationsLookupRequestPayload true "Staking transaction hashes in hex format, up to the configured lookup size"
+// @Success 200 {object} PublicResponse[services.DelegationsLookupPublic] "Found delegations and not found transaction hashes"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/delegations/lookup [post]
+func (h *Handler) LookupDelegations(request *http.Request) (*Result, *types.Error) {
+	payload, err := parseDelegationsLookupRequestPayload(request, h.config.Server.MaxDelegationLookupSize)
+	if err != nil {
+		return nil, err
+	}
+	result, err := h.services.LookupDelegations(request.Context(), payload.StakingTxHashHexes)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(result), nil
+}
+
+// GetDelegationHistory @Summary Get the state history of a delegation
+// @Description Retrieves the state transitions of a delegation by a given transaction hash in the order they happened
+// @Produce json
+// @Param staking_tx_hash_hex query string true "Staking transaction hash in hex format"
+// @Success 200 {object} PublicResponse[[]services.DelegationStateHistoryPublic]{array} "Delegation state transitions"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/delegation/history [get]
+func (h *Handler) GetDelegationHistory(request *http.Request) (*Result, *types.Error) {
+	stakingTxHash, err := parseTxHashQuery(request, "staking_tx_hash_hex")
+	if err != nil {
+		return nil, err
+	}
+	// Make sure the delegation exists so that unknown tx hashes are reported as not found
+	_, err = h.services.GetDelegation(request.Context(), stakingTxHash)
+	if err != nil {
+		return nil, err
+	}
+	history, err := h.services.GetDelegationStateHistory(request.Context(), stakingTxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(history), nil
 }
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+const (
+	// One week of btc blocks bucketed by day
+	defaultExpirationsWithinBlocks = 1008
+	defaultExpirationsBucketBlocks = 144
+	// About 30 days of btc blocks
+	maxExpirationsWithinBlocks = 4320
+)
+
+// GetExpirationsForecast gets the upcoming timelock expiries
+// @Summary Get Expirations Forecast
+// @Description Forecasts the delegations and staking value unlocked by the timelock expiries within the blocks following the
+// @Description latest btc height, bucketed by btc height. The unlock time of each bucket is estimated from a 10 minutes block time.
+// @Produce json
+// @Param within_blocks query int false "Number of upcoming btc blocks to forecast, defaults to 1008 and is at most 4320"
+// @Param bucket_blocks query int false "Number of btc blocks in each bucket, defaults to 144"
+// @Success 200 {object} PublicResponse[services.ExpirationsForecastPublic] "Expirations forecast"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/expirations [get]
+func (h *Handler) GetExpirationsForecast(request *http.Request) (*Result, *types.Error) {
+	withinBlocks, err := parseUint64Query(request, "within_blocks")
+	if err != nil {
+		return nil, err
+	}
+	if withinBlocks == 0 {
+		withinBlocks = defaultExpirationsWithinBlocks
+	}
+	if withinBlocks > maxExpirationsWithinBlocks {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("within_blocks must be at most %d", maxExpirationsWithinBlocks),
+		)
+	}
+	bucketBlocks, err := parseUint64Query(request, "bucket_blocks")
+	if err != nil {
+		return nil, err
+	}
+	if bucketBlocks == 0 {
+		bucketBlocks = defaultExpirationsBucketBlocks
+	}
+
+	forecast, err := h.services.GetExpirationsForecast(request.Context(), withinBlocks, bucketBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(forecast), nil
+}
@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
+	"github.com/go-chi/chi"
 )
 
 // GetFinalityProviders gets active finality providers sorted by ActiveTvl.
@@ -22,5 +24,41 @@ func (h *Handler) GetFinalityProviders(request *http.Request) (*Result, *types.E
 	if err != nil {
 		return nil, err
 	}
-	return NewResultWithPagination(fps, paginationToken), nil
+	return NewResultWithPagination(fps, paginationToken).WithETag(), nil
+}
+
+// GetFinalityProvider gets the details and delegations of a finality provider.
+// @Summary Get Finality Provider
+// @Description Fetches the details of a finality provider, including its TVL, delegation counts and number of active and total unique stakers,
+// @Description along with its delegations sorted by the staking start height in descending order.
+// @Produce json
+// @Param fp_pk path string true "Finality provider BTC public key"
+// @Param state query []string false "Filter delegations by states, can be provided multiple times" collectionFormat(multi) Enums(active, unbonding_requested, unbonding, unbonded, withdrawn)
+// @Param pagination_key query string false "Pagination key to fetch the next page of delegations"
+// @Success 200 {object} PublicResponse[services.FpDetailsWithDelegationsPublic] "Finality provider details and delegations"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/finality-providers/{fp_pk} [get]
+func (h *Handler) GetFinalityProvider(request *http.Request) (*Result, *types.Error) {
+	fpPkHex := chi.URLParam(request, "fp_pk")
+	if _, err := utils.GetSchnorrPkFromHex(fpPkHex); err != nil {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid fp_pk",
+		)
+	}
+	states, err := parseDelegationStatesQuery(request, "state")
+	if err != nil {
+		return nil, err
+	}
+	paginationKey, err := parsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	fp, paginationToken, err := h.services.GetFinalityProvider(
+		request.Context(), fpPkHex, states, paginationKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return NewResultWithPagination(fp, paginationToken), nil
 }
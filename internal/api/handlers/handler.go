@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"net/http"
+	"strconv"
 
 	"github.com/babylonchain/staking-api-service/internal/config"
 	"github.com/babylonchain/staking-api-service/internal/services"
@@ -28,6 +29,15 @@ type PublicResponse[T any] struct {
 type Result struct {
 	Data   interface{}
 	Status int
+	// Whether the response is served with an ETag and answers the
+	// conditional requests with 304 Not Modified
+	ETag bool
+}
+
+// WithETag serves the result with an ETag computed from its content
+func (r *Result) WithETag() *Result {
+	r.ETag = true
+	return r
 }
 
 // NewResult returns a successful result, with default status code 200
@@ -94,7 +104,9 @@ func parseTxHashQuery(r *http.Request, queryName string) (string, *types.Error)
 	return txHashHex, nil
 }
 
-func parseBtcAddressQuery(
+// parseStakerBtcAddressQuery parses the BTC address in any of the formats
+// that can be derived from a staker public key
+func parseStakerBtcAddressQuery(
 	r *http.Request, queryName string, netParam *chaincfg.Params,
 ) (string, *types.Error) {
 	address := r.URL.Query().Get(queryName)
@@ -103,7 +115,7 @@ func parseBtcAddressQuery(
 			http.StatusBadRequest, types.BadRequest, queryName+" is required",
 		)
 	}
-	err := utils.IsValidBtcAddress(address, netParam)
+	err := utils.IsValidStakerBtcAddress(address, netParam)
 	if err != nil {
 		return "", types.NewErrorWithMsg(
 			http.StatusBadRequest, types.BadRequest, err.Error(),
@@ -111,3 +123,82 @@ func parseBtcAddressQuery(
 	}
 	return address, nil
 }
+
+func parseBoolQuery(r *http.Request, queryName string) (bool, *types.Error) {
+	value := r.URL.Query().Get(queryName)
+	if value == "" {
+		return false, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid "+queryName,
+		)
+	}
+	return parsed, nil
+}
+
+func parseUint64Query(r *http.Request, queryName string) (uint64, *types.Error) {
+	value := r.URL.Query().Get(queryName)
+	if value == "" {
+		return 0, nil
+	}
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid "+queryName,
+		)
+	}
+	return parsed, nil
+}
+
+// parseOptionalUint64Query returns nil if the query is not provided, unlike
+// parseUint64Query a provided 0 is kept apart from an absent value.
+func parseOptionalUint64Query(r *http.Request, queryName string) (*uint64, *types.Error) {
+	value := r.URL.Query().Get(queryName)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid "+queryName,
+		)
+	}
+	return &parsed, nil
+}
+
+// parseDelegationStatesQuery parses the multi-valued delegation state query,
+// e.g. ?state=active&state=unbonded
+func parseDelegationStatesQuery(r *http.Request, queryName string) ([]types.DelegationState, *types.Error) {
+	values := r.URL.Query()[queryName]
+	if len(values) == 0 {
+		return nil, nil
+	}
+	states := make([]types.DelegationState, 0, len(values))
+	for _, value := range values {
+		state, err := types.FromStringToDelegationState(value)
+		if err != nil {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "invalid "+queryName,
+			)
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// parseSortOrderQuery parses the sort order query, defaults to descending order
+func parseSortOrderQuery(r *http.Request, queryName string) (types.SortOrder, *types.Error) {
+	value := r.URL.Query().Get(queryName)
+	if value == "" {
+		return types.SortDescending, nil
+	}
+	sortOrder, err := types.SortOrderFromString(value)
+	if err != nil {
+		return "", types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid "+queryName,
+		)
+	}
+	return sortOrder, nil
+}
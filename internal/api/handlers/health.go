@@ -3,13 +3,24 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/babylonchain/staking-api-service/internal/services"
 	"github.com/babylonchain/staking-api-service/internal/types"
 )
 
+const healthyMessage = "Server is up and running"
+
+type HealthCheckDetailsPublic struct {
+	Status            string                         `json:"status"`
+	GlobalParams      *services.ConfigFileInfoPublic `json:"global_params"`
+	FinalityProviders *services.ConfigFileInfoPublic `json:"finality_providers"`
+}
+
 // HealthCheck godoc
 // @Summary Health check endpoint
 // @Description Health check the service, including ping database connection
+// @Description Provide details=true to also get the versions of the loaded global params and finality providers files
 // @Produce json
+// @Param details query boolean false "Include the loaded config file versions"
 // @Success 200 {string} PublicResponse[string] "Server is up and running"
 // @Router /healthcheck [get]
 func (h *Handler) HealthCheck(request *http.Request) (*Result, *types.Error) {
@@ -18,5 +29,17 @@ func (h *Handler) HealthCheck(request *http.Request) (*Result, *types.Error) {
 		return nil, types.NewInternalServiceError(err)
 	}
 
-	return NewResult("Server is up and running"), nil
+	details, parseErr := parseBoolQuery(request, "details")
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	if details {
+		return NewResult(HealthCheckDetailsPublic{
+			Status:            healthyMessage,
+			GlobalParams:      h.services.GetGlobalParamsFileInfo(),
+			FinalityProviders: h.services.GetFinalityProvidersFileInfo(),
+		}), nil
+	}
+
+	return NewResult(healthyMessage), nil
 }
@@ -13,6 +13,47 @@ import (
 // @Success 200 {object} PublicResponse[services.GlobalParamsPublic] "Global parameters"
 // @Router /v1/global-params [get]
 func (h *Handler) GetBabylonGlobalParams(request *http.Request) (*Result, *types.Error) {
-	params := h.services.GetGlobalParamsPublic()
+	params, err := h.services.GetCachedGlobalParamsPublic(request.Context())
+	if err != nil {
+		return nil, err
+	}
+	return NewResult(params).WithETag(), nil
+}
+
+// GetBabylonGlobalParamsAtHeight godoc
+// @Summary Get Babylon global parameters at a BTC height
+// @Description Retrieves the global parameters version applied at the given BTC height,
+// @Description along with the next scheduled version and the number of blocks until it activates.
+// @Produce json
+// @Param height query integer true "BTC height"
+// @Success 200 {object} PublicResponse[services.GlobalParamsAtHeightPublic] "Global parameters at the height"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/global-params/at-height [get]
+func (h *Handler) GetBabylonGlobalParamsAtHeight(request *http.Request) (*Result, *types.Error) {
+	if request.URL.Query().Get("height") == "" {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "height is required",
+		)
+	}
+	height, err := parseUint64Query(request, "height")
+	if err != nil {
+		return nil, err
+	}
+	return NewResult(h.services.GetGlobalParamsAtHeight(height)), nil
+}
+
+// GetCurrentBabylonGlobalParams godoc
+// @Summary Get current Babylon global parameters
+// @Description Retrieves the global parameters version applied at the latest BTC height,
+// @Description along with the next scheduled version and the number of blocks until it activates.
+// @Produce json
+// @Success 200 {object} PublicResponse[services.GlobalParamsAtHeightPublic] "Current global parameters"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/global-params/current [get]
+func (h *Handler) GetCurrentBabylonGlobalParams(request *http.Request) (*Result, *types.Error) {
+	params, err := h.services.GetCurrentGlobalParams(request.Context())
+	if err != nil {
+		return nil, err
+	}
 	return NewResult(params), nil
 }
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+// Search looks up the entities matching the query
+// @Summary Search
+// @Description Classifies the query as a transaction hash, a public key or a BTC address and returns the matching
+// @Description delegations, unbonding transactions, stakers and finality providers, each with a link to its endpoint.
+// @Description A 32 bytes hex query is looked up both as a transaction hash and as a public key.
+// @Produce json
+// @Param q query string true "Transaction hash, public key or BTC address"
+// @Success 200 {object} PublicResponse[[]services.SearchResultPublic]{array} "Matching entities"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/search [get]
+func (h *Handler) Search(request *http.Request) (*Result, *types.Error) {
+	query := request.URL.Query().Get("q")
+	if query == "" {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "q is required",
+		)
+	}
+	results, err := h.services.Search(request.Context(), query)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(results), nil
+}
@@ -1,22 +1,43 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/services"
 	"github.com/babylonchain/staking-api-service/internal/types"
 	"github.com/babylonchain/staking-api-service/internal/utils"
 )
 
 // GetStakerDelegations @Summary Get staker delegations
-// @Description Retrieves delegations for a given staker
+// @Description Retrieves delegations for a given staker sorted by the staking start height.
+// @Description The same filters and sort order shall be provided when fetching the next page with the pagination key,
+// @Description otherwise the pagination key is rejected.
 // @Produce json
-// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Param staker_btc_pk query string false "Staker BTC Public Key, either staker_btc_pk or address is required"
+// @Param address query string false "Staker BTC address in Taproot, native SegWit or nested SegWit format"
+// @Param state query []string false "Filter by delegation states, can be provided multiple times" collectionFormat(multi) Enums(active, unbonding_requested, unbonding, unbonded, withdrawn)
+// @Param finality_provider_pk_hex query string false "Filter by finality provider public key"
+// @Param min_staking_value query int false "Minimum staking value in satoshis (inclusive)"
+// @Param max_staking_value query int false "Maximum staking value in satoshis (inclusive)"
+// @Param min_start_height query int false "Minimum staking start height (inclusive)"
+// @Param max_start_height query int false "Maximum staking start height (inclusive)"
+// @Param sort_order query string false "Sort order by staking start height, defaults to desc" Enums(asc, desc)
 // @Param pagination_key query string false "Pagination key to fetch the next page of delegations"
 // @Success 200 {object} PublicResponse[[]services.DelegationPublic]{array} "List of delegations and pagination token"
 // @Failure 400 {object} types.Error "Error: Bad Request"
 // @Router /v1/staker/delegations [get]
 func (h *Handler) GetStakerDelegations(request *http.Request) (*Result, *types.Error) {
-	stakerBtcPk, err := parsePublicKeyQuery(request, "staker_btc_pk")
+	stakerBtcPk, err := h.parseStakerPkOrAddressQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := parseDelegationFilterQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	sortOrder, err := parseSortOrderQuery(request, "sort_order")
 	if err != nil {
 		return nil, err
 	}
@@ -25,7 +46,14 @@ func (h *Handler) GetStakerDelegations(request *http.Request) (*Result, *types.E
 		return nil, err
 	}
 
-	delegations, newPaginationKey, err := h.services.DelegationsByStakerPk(request.Context(), stakerBtcPk, paginationKey)
+	// The address has never been used for staking
+	if stakerBtcPk == "" {
+		return NewResultWithPagination([]services.DelegationPublic{}, ""), nil
+	}
+
+	delegations, newPaginationKey, err := h.services.DelegationsByStakerPk(
+		request.Context(), stakerBtcPk, filter, sortOrder, paginationKey,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -33,18 +61,67 @@ func (h *Handler) GetStakerDelegations(request *http.Request) (*Result, *types.E
 	return NewResultWithPagination(delegations, newPaginationKey), nil
 }
 
+// GetStakerPortfolio @Summary Get the portfolio of several stakers
+// @Description Aggregates the delegations of several staker public keys or addresses. Returns the active and total TVL
+// @Description of each staker and combined, the number and staking value of their delegations by state, and their
+// @Description delegations merged into a single list sorted by the staking start height.
+// @Description The filters only apply to the delegations. The same stakers, filters and sort order shall be provided
+// @Description when fetching the next page with the pagination key, otherwise the pagination key is rejected.
+// @Produce json
+// @Param staker_btc_pk query []string false "Staker BTC public keys, can be provided multiple times" collectionFormat(multi)
+// @Param address query []string false "Staker BTC addresses in Taproot, native SegWit or nested SegWit format, can be provided multiple times" collectionFormat(multi)
+// @Param state query []string false "Filter delegations by states, can be provided multiple times" collectionFormat(multi) Enums(active, unbonding_requested, unbonding, unbonded, withdrawn)
+// @Param finality_provider_pk_hex query string false "Filter delegations by finality provider public key"
+// @Param min_staking_value query int false "Minimum staking value in satoshis (inclusive)"
+// @Param max_staking_value query int false "Maximum staking value in satoshis (inclusive)"
+// @Param min_start_height query int false "Minimum staking start height (inclusive)"
+// @Param max_start_height query int false "Maximum staking start height (inclusive)"
+// @Param sort_order query string false "Sort order by staking start height, defaults to desc" Enums(asc, desc)
+// @Param pagination_key query string false "Pagination key to fetch the next page of delegations"
+// @Success 200 {object} PublicResponse[services.StakerPortfolioPublic] "Staker portfolio and pagination token"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/staker/portfolio [get]
+func (h *Handler) GetStakerPortfolio(request *http.Request) (*Result, *types.Error) {
+	stakerPks, err := h.parsePortfolioStakersQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := parseDelegationFilterQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	sortOrder, err := parseSortOrderQuery(request, "sort_order")
+	if err != nil {
+		return nil, err
+	}
+	paginationKey, err := parsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	portfolio, newPaginationKey, err := h.services.GetStakerPortfolio(
+		request.Context(), stakerPks, filter, sortOrder, paginationKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResultWithPagination(portfolio, newPaginationKey), nil
+}
+
 // CheckStakerDelegationExist @Summary Check if a staker has an active delegation
-// @Description Check if a staker has an active delegation by the staker BTC address (Taproot only)
+// @Description Check if a staker has an active delegation by the staker BTC address
+// @Description The address can be in any format derived from the staker public key: Taproot, native SegWit or nested SegWit
 // @Description Optionally, you can provide a timeframe to check if the delegation is active within the provided timeframe
 // @Description The available timeframe is "today" which checks after UTC 12AM of the current day
 // @Produce json
-// @Param address query string true "Staker BTC address in Taproot format"
+// @Param address query string true "Staker BTC address in Taproot, native SegWit or nested SegWit format"
 // @Param timeframe query string false "Check if the delegation is active within the provided timeframe" Enums(today)
 // @Success 200 {object} Result "Result"
 // @Failure 400 {object} types.Error "Error: Bad Request"
 // @Router /v1/staker/delegation/check [get]
 func (h *Handler) CheckStakerDelegationExist(request *http.Request) (*Result, *types.Error) {
-	address, err := parseBtcAddressQuery(request, "address", h.config.Server.BTCNetParam)
+	address, err := parseStakerBtcAddressQuery(request, "address", h.config.Server.BTCNetParam)
 	if err != nil {
 		return nil, err
 	}
@@ -64,6 +141,70 @@ func (h *Handler) CheckStakerDelegationExist(request *http.Request) (*Result, *t
 	return NewResult(exist), nil
 }
 
+// parseStakerPkOrAddressQuery returns the staker public key from the
+// staker_btc_pk query, or resolves it from the address query if the public
+// key is not provided. An empty string is returned if the address is unknown.
+func (h *Handler) parseStakerPkOrAddressQuery(r *http.Request) (string, *types.Error) {
+	if r.URL.Query().Get("staker_btc_pk") != "" || r.URL.Query().Get("address") == "" {
+		return parsePublicKeyQuery(r, "staker_btc_pk")
+	}
+	address, err := parseStakerBtcAddressQuery(r, "address", h.config.Server.BTCNetParam)
+	if err != nil {
+		return "", err
+	}
+	return h.services.GetStakerPkByAddress(r.Context(), address)
+}
+
+// parsePortfolioStakersQuery returns the distinct staker public keys from the
+// staker_btc_pk queries along with the ones resolved from the address queries.
+// The addresses which have never been used for staking are skipped.
+func (h *Handler) parsePortfolioStakersQuery(r *http.Request) ([]string, *types.Error) {
+	pks := r.URL.Query()["staker_btc_pk"]
+	addresses := r.URL.Query()["address"]
+	if len(pks)+len(addresses) == 0 {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "either staker_btc_pk or address is required",
+		)
+	}
+	maxPortfolioStakers := h.config.Server.MaxPortfolioStakers
+	if len(pks)+len(addresses) > maxPortfolioStakers {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("at most %d staker public keys and addresses can be provided", maxPortfolioStakers),
+		)
+	}
+
+	stakerPks := make([]string, 0, len(pks)+len(addresses))
+	seen := make(map[string]bool, len(pks)+len(addresses))
+	for _, pk := range pks {
+		if _, err := utils.GetSchnorrPkFromHex(pk); err != nil {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "invalid staker_btc_pk",
+			)
+		}
+		if !seen[pk] {
+			seen[pk] = true
+			stakerPks = append(stakerPks, pk)
+		}
+	}
+	for _, address := range addresses {
+		if err := utils.IsValidStakerBtcAddress(address, h.config.Server.BTCNetParam); err != nil {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, err.Error(),
+			)
+		}
+		pk, err := h.services.GetStakerPkByAddress(r.Context(), address)
+		if err != nil {
+			return nil, err
+		}
+		if pk != "" && !seen[pk] {
+			seen[pk] = true
+			stakerPks = append(stakerPks, pk)
+		}
+	}
+	return stakerPks, nil
+}
+
 func parseTimeframeToAfterTimestamp(timeframe string) (int64, *types.Error) {
 	switch timeframe {
 	case "": // We ignore and return 0 if no timeframe is provided
@@ -76,3 +217,52 @@ func parseTimeframeToAfterTimestamp(timeframe string) (int64, *types.Error) {
 		)
 	}
 }
+
+func parseDelegationFilterQuery(r *http.Request) (*db.DelegationFilter, *types.Error) {
+	states, err := parseDelegationStatesQuery(r, "state")
+	if err != nil {
+		return nil, err
+	}
+	var fpPkHex string
+	if r.URL.Query().Get("finality_provider_pk_hex") != "" {
+		fpPkHex, err = parsePublicKeyQuery(r, "finality_provider_pk_hex")
+		if err != nil {
+			return nil, err
+		}
+	}
+	minStakingValue, err := parseOptionalUint64Query(r, "min_staking_value")
+	if err != nil {
+		return nil, err
+	}
+	maxStakingValue, err := parseOptionalUint64Query(r, "max_staking_value")
+	if err != nil {
+		return nil, err
+	}
+	if minStakingValue != nil && maxStakingValue != nil && *minStakingValue > *maxStakingValue {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "min_staking_value must not be greater than max_staking_value",
+		)
+	}
+	minStartHeight, err := parseOptionalUint64Query(r, "min_start_height")
+	if err != nil {
+		return nil, err
+	}
+	maxStartHeight, err := parseOptionalUint64Query(r, "max_start_height")
+	if err != nil {
+		return nil, err
+	}
+	if minStartHeight != nil && maxStartHeight != nil && *minStartHeight > *maxStartHeight {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "min_start_height must not be greater than max_start_height",
+		)
+	}
+
+	return &db.DelegationFilter{
+		States:                states,
+		FinalityProviderPkHex: fpPkHex,
+		MinStakingValue:       minStakingValue,
+		MaxStakingValue:       maxStakingValue,
+		MinStartHeight:        minStartHeight,
+		MaxStartHeight:        maxStartHeight,
+	}, nil
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
+)
+
+type ValidateStakingTxRequestPayload struct {
+	StakingTxHex string `json:"staking_tx_hex"`
+	// Optional, defaults to the height of the next btc block
+	BtcHeight uint64 `json:"btc_height,omitempty"`
+}
+
+func parseValidateStakingTxRequestPayload(request *http.Request) (*ValidateStakingTxRequestPayload, *types.Error) {
+	payload := &ValidateStakingTxRequestPayload{}
+	err := json.NewDecoder(request.Body).Decode(payload)
+	if err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid request payload")
+	}
+	if !utils.IsValidTxHex(payload.StakingTxHex) {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid staking transaction hex",
+		)
+	}
+
+	return payload, nil
+}
+
+// ValidateStakingTx godoc
+// @Summary Validate staking transaction
+// @Description Validates an unsigned or signed staking transaction against the global params applied at the btc height
+// @Description and the registered finality providers, without broadcasting it. Returns the result of each validation rule.
+// @Accept json
+// @Produce json
+// @Param payload body ValidateStakingTxRequestPayload true "Staking Transaction Validation Payload"
+// @Success 200 {object} PublicResponse[services.StakingTxValidationPublic] "Validation result per rule"
+// @Failure 400 {object} types.Error "Invalid request payload"
+// @Router /v1/staking/validate [post]
+func (h *Handler) ValidateStakingTx(request *http.Request) (*Result, *types.Error) {
+	payload, err := parseValidateStakingTxRequestPayload(request)
+	if err != nil {
+		return nil, err
+	}
+	validation, err := h.services.ValidateStakingTx(
+		request.Context(), payload.StakingTxHex, payload.BtcHeight,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(validation), nil
+}
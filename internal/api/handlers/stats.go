@@ -1,9 +1,14 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
+	"github.com/go-chi/chi"
 )
 
 // GetOverallStats gets overall stats for babylon staking
@@ -18,7 +23,7 @@ func (h *Handler) GetOverallStats(request *http.Request) (*Result, *types.Error)
 		return nil, err
 	}
 
-	return NewResult(stats), nil
+	return NewResult(stats).WithETag(), nil
 }
 
 // GetTopStakerStats gets top stakers by active tvl
@@ -41,3 +46,152 @@ func (h *Handler) GetTopStakerStats(request *http.Request) (*Result, *types.Erro
 
 	return NewResultWithPagination(topStakerStats, paginationToken), nil
 }
+
+// GetStakerStats gets the stats of a single staker
+// @Summary Get Staker Stats
+// @Description Fetches the active and total tvl and delegation counts of a staker, the number and staking value of its
+// @Description delegations by state, and its rank among the stakers by active tvl. The stakers with the same active tvl share the rank.
+// @Produce json
+// @Param pk path string true "Staker BTC public key in hex format"
+// @Success 200 {object} PublicResponse[services.StakerStatsDetailsPublic] "Staker stats and rank"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Failure 404 {object} types.Error "Error: Not Found"
+// @Router /v1/stats/staker/{pk} [get]
+func (h *Handler) GetStakerStats(request *http.Request) (*Result, *types.Error) {
+	stakerPkHex := chi.URLParam(request, "pk")
+	if _, err := utils.GetSchnorrPkFromHex(stakerPkHex); err != nil {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid pk",
+		)
+	}
+	stats, err := h.services.GetStakerStats(request.Context(), stakerPkHex)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(stats), nil
+}
+
+const (
+	defaultStatsHistoryRange    = 24 * time.Hour
+	defaultStatsHistoryInterval = time.Hour
+)
+
+// GetStatsHistory gets the historical stats bucketed by the given interval
+// @Summary Get Stats History
+// @Description Fetches the historical stats snapshots between the from and to timestamps, bucketed by the given interval.
+// @Description The latest snapshot within each bucket is returned. By default the overall stats are returned,
+// @Description provide either finality_provider_pk_hex or staker_btc_pk to fetch the history of a single finality provider or staker.
+// @Produce json
+// @Param from query int false "Start of the range in unix timestamp (seconds), defaults to 24 hours before to"
+// @Param to query int false "End of the range in unix timestamp (seconds), defaults to now"
+// @Param interval query string false "Bucket interval as a duration, e.g. 15m, 1h, 24h. Defaults to 1h"
+// @Param finality_provider_pk_hex query string false "Finality provider public key in hex format"
+// @Param staker_btc_pk query string false "Staker BTC public key in hex format"
+// @Success 200 {object} PublicResponse[[]services.OverallStatsHistoryPublic]{array} "Overall stats history, or the finality provider / staker stats history if requested"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/stats/history [get]
+func (h *Handler) GetStatsHistory(request *http.Request) (*Result, *types.Error) {
+	from, to, interval, err := h.parseStatsHistoryQuery(request)
+	if err != nil {
+		return nil, err
+	}
+
+	fpPkHex := request.URL.Query().Get("finality_provider_pk_hex")
+	stakerPkHex := request.URL.Query().Get("staker_btc_pk")
+	if fpPkHex != "" && stakerPkHex != "" {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			"only one of finality_provider_pk_hex and staker_btc_pk can be provided",
+		)
+	}
+
+	if fpPkHex != "" {
+		fpPkHex, err = parsePublicKeyQuery(request, "finality_provider_pk_hex")
+		if err != nil {
+			return nil, err
+		}
+		history, err := h.services.GetFinalityProviderStatsHistory(
+			request.Context(), fpPkHex, from, to, interval,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return NewResult(history), nil
+	}
+
+	if stakerPkHex != "" {
+		stakerPkHex, err = parsePublicKeyQuery(request, "staker_btc_pk")
+		if err != nil {
+			return nil, err
+		}
+		history, err := h.services.GetStakerStatsHistory(
+			request.Context(), stakerPkHex, from, to, interval,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return NewResult(history), nil
+	}
+
+	history, err := h.services.GetOverallStatsHistory(request.Context(), from, to, interval)
+	if err != nil {
+		return nil, err
+	}
+	return NewResult(history), nil
+}
+
+// parseStatsHistoryQuery parses the from, to and interval query params into
+// unix timestamps and interval in seconds
+func (h *Handler) parseStatsHistoryQuery(r *http.Request) (int64, int64, int64, *types.Error) {
+	query := r.URL.Query()
+
+	to := time.Now().Unix()
+	if toStr := query.Get("to"); toStr != "" {
+		parsed, err := strconv.ParseInt(toStr, 10, 64)
+		if err != nil || parsed < 0 {
+			return 0, 0, 0, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "invalid to",
+			)
+		}
+		to = parsed
+	}
+
+	from := to - int64(defaultStatsHistoryRange.Seconds())
+	if fromStr := query.Get("from"); fromStr != "" {
+		parsed, err := strconv.ParseInt(fromStr, 10, 64)
+		if err != nil || parsed < 0 {
+			return 0, 0, 0, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "invalid from",
+			)
+		}
+		from = parsed
+	}
+	if from > to {
+		return 0, 0, 0, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "from must not be after to",
+		)
+	}
+
+	interval := defaultStatsHistoryInterval
+	if intervalStr := query.Get("interval"); intervalStr != "" {
+		parsed, err := time.ParseDuration(intervalStr)
+		if err != nil || parsed < time.Second {
+			return 0, 0, 0, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "invalid interval",
+			)
+		}
+		interval = parsed
+	}
+	intervalSeconds := int64(interval.Seconds())
+
+	maxBuckets := h.config.StatsSnapshot.MaxHistoryBuckets
+	if (to-from)/intervalSeconds+1 > maxBuckets {
+		return 0, 0, 0, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			fmt.Sprintf("too many data points requested, the range can contain at most %d intervals", maxBuckets),
+		)
+	}
+
+	return from, to, intervalSeconds, nil
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+// streamKeepAliveInterval is the interval of the comment lines sent on idle
+// streams, so that proxies do not close the connection
+const streamKeepAliveInterval = 15 * time.Second
+
+// StreamStakerDelegations godoc
+// @Summary Stream staker delegation changes
+// @Description Opens a server-sent events stream emitting a "delegation" event with the latest version of the delegation
+// @Description whenever one of the staker's delegations is created or changes state, e.g. once an unbonding request is
+// @Description picked up. The changes are read from the database, hence they are emitted regardless of the service
+// @Description replica which processed them.
+// @Produce text/event-stream
+// @Param staker_btc_pk query string true "Staker BTC Public Key"
+// @Success 200 {object} services.DelegationPublic "Stream of delegation events"
+// @Failure 400 {object} types.Error "Error: Bad Request"
+// @Router /v1/stream/staker [get]
+func (h *Handler) StreamStakerDelegations(w http.ResponseWriter, request *http.Request) *types.Error {
+	stakerBtcPk, err := parsePublicKeyQuery(request, "staker_btc_pk")
+	if err != nil {
+		return err
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return types.NewErrorWithMsg(
+			http.StatusInternalServerError, types.InternalServiceError, "streaming is not supported",
+		)
+	}
+	// The stream is long-lived, hence it's not bound to the server write timeout
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return types.NewInternalServiceError(err)
+	}
+
+	events, unsubscribe := h.services.SubscribeStakerDelegations(stakerBtcPk)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(streamKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		var writeErr error
+		select {
+		case <-request.Context().Done():
+			return nil
+		case <-keepAlive.C:
+			_, writeErr = fmt.Fprint(w, ": keep-alive\n\n")
+		case event := <-events:
+			data, err := json.Marshal(event)
+			if err != nil {
+				log.Ctx(request.Context()).Error().Err(err).Msg("failed to marshal delegation event")
+				continue
+			}
+			_, writeErr = fmt.Fprintf(w, "event: delegation\ndata: %s\n\n", data)
+		}
+		if writeErr != nil {
+			// The client is gone
+			return nil
+		}
+		flusher.Flush()
+	}
+}
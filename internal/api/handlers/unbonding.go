@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/go-chi/chi"
+
+	"github.com/babylonchain/staking-api-service/internal/api/middlewares"
 	"github.com/babylonchain/staking-api-service/internal/types"
 	"github.com/babylonchain/staking-api-service/internal/utils"
 )
@@ -46,25 +49,71 @@ func parseUnbondDelegationRequestPayload(request *http.Request) (*UnbondDelegati
 	return payload, nil
 }
 
+const (
+	IdempotencyKeyHeader    = "Idempotency-Key"
+	maxIdempotencyKeyLength = 255
+)
+
 // UnbondDelegation godoc
 // @Summary Unbond delegation
 // @Description Unbonds a delegation by processing the provided transaction details. This is an async operation.
+// @Description The request can be retried safely with the same Idempotency-Key header, the retries get the response
+// @Description of the original request for 24 hours. With dry_run, the request is only verified and nothing is saved.
 // @Accept json
 // @Produce json
 // @Param payload body UnbondDelegationRequestPayload true "Unbonding Request Payload"
+// @Param Idempotency-Key header string false "Unique key of the request for the caller, up to 255 characters"
+// @Param dry_run query bool false "Only verify the request, responds with 200 if it would be accepted"
+// @Success 200 "The dry run request would be accepted"
 // @Success 202 "Request accepted and will be processed asynchronously"
 // @Failure 400 {object} types.Error "Invalid request payload"
+// @Failure 403 {object} types.Error "Delegation not eligible for unbonding or invalid unbonding request"
+// @Failure 409 {object} types.Error "A request with the same idempotency key is being processed"
+// @Failure 422 {object} types.Error "The idempotency key has been used for another request"
 // @Router /v1/unbonding [post]
 func (h *Handler) UnbondDelegation(request *http.Request) (*Result, *types.Error) {
 	payload, err := parseUnbondDelegationRequestPayload(request)
 	if err != nil {
 		return nil, err
 	}
-	unbondErr := h.services.UnbondDelegation(
-		request.Context(), payload.StakingTxHashHex,
-		payload.UnbondingTxHashHex, payload.UnbondingTxHex,
-		payload.StakerSignedSignatureHex,
-	)
+	dryRun, err := parseBoolQuery(request, "dry_run")
+	if err != nil {
+		return nil, err
+	}
+	idempotencyKey := request.Header.Get(IdempotencyKeyHeader)
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid idempotency key",
+		)
+	}
+
+	if dryRun {
+		verifyErr := h.services.VerifyUnbondDelegation(
+			request.Context(), payload.StakingTxHashHex,
+			payload.UnbondingTxHashHex, payload.UnbondingTxHex,
+			payload.StakerSignedSignatureHex,
+		)
+		if verifyErr != nil {
+			return nil, verifyErr
+		}
+		return &Result{Status: http.StatusOK}, nil
+	}
+
+	var unbondErr *types.Error
+	if idempotencyKey != "" {
+		unbondErr = h.services.UnbondDelegationWithIdempotencyKey(
+			request.Context(), middlewares.CallerIdFromRequest(h.config, request),
+			idempotencyKey, payload.StakingTxHashHex,
+			payload.UnbondingTxHashHex, payload.UnbondingTxHex,
+			payload.StakerSignedSignatureHex,
+		)
+	} else {
+		unbondErr = h.services.UnbondDelegation(
+			request.Context(), payload.StakingTxHashHex,
+			payload.UnbondingTxHashHex, payload.UnbondingTxHex,
+			payload.StakerSignedSignatureHex,
+		)
+	}
 	if unbondErr != nil {
 		return nil, unbondErr
 	}
@@ -92,3 +141,95 @@ func (h *Handler) GetUnbondingEligibility(request *http.Request) (*Result, *type
 
 	return &Result{Status: http.StatusOK}, nil
 }
+
+// GetUnbondingTemplate godoc
+// @Summary Get unsigned unbonding transaction
+// @Description Builds the unsigned unbonding transaction of an active delegation, as expected by the unbonding endpoint.
+// @Description The unbonding fee and script are taken from the global params version applied to the delegation.
+// @Description The staker must sign the returned sighash, or the PSBT, and submit the signature to the unbonding endpoint.
+// @Produce json
+// @Param staking_tx_hash_hex query string true "Staking Transaction Hash Hex"
+// @Success 200 {object} PublicResponse[services.UnbondingTemplatePublic] "Unsigned unbonding transaction"
+// @Failure 400 {object} types.Error "Missing or invalid 'staking_tx_hash_hex' query parameter"
+// @Failure 403 {object} types.Error "Delegation is not active"
+// @Failure 404 {object} types.Error "Delegation not found"
+// @Router /v1/unbonding/template [get]
+func (h *Handler) GetUnbondingTemplate(request *http.Request) (*Result, *types.Error) {
+	stakingTxHashHex, err := parseTxHashQuery(request, "staking_tx_hash_hex")
+	if err != nil {
+		return nil, err
+	}
+	template, err := h.services.GetUnbondingTemplate(request.Context(), stakingTxHashHex)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(template), nil
+}
+
+// GetUnbondingStatus godoc
+// @Summary Get unbonding request status
+// @Description Retrieves the progress of the unbonding request of a delegation through the unbonding pipeline,
+// @Description along with the states it went through. The state is one of INSERTED, PICKED_UP,
+// @Description COVENANT_SIGNATURES_COLLECTED, BROADCAST, CONFIRMED or FAILED, the latter with a failure reason.
+// @Produce json
+// @Param staking_tx_hash_hex query string true "Staking Transaction Hash Hex"
+// @Success 200 {object} PublicResponse[services.UnbondingStatusPublic] "Unbonding request status"
+// @Failure 400 {object} types.Error "Missing or invalid 'staking_tx_hash_hex' query parameter"
+// @Failure 404 {object} types.Error "Unbonding request not found"
+// @Router /v1/unbonding/status [get]
+func (h *Handler) GetUnbondingStatus(request *http.Request) (*Result, *types.Error) {
+	stakingTxHashHex, err := parseTxHashQuery(request, "staking_tx_hash_hex")
+	if err != nil {
+		return nil, err
+	}
+	status, err := h.services.GetUnbondingStatus(request.Context(), stakingTxHashHex)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(status), nil
+}
+
+type UpdateUnbondingStateRequestPayload struct {
+	State string `json:"state"`
+	// Required for the FAILED state
+	Reason string `json:"reason,omitempty"`
+}
+
+// UpdateUnbondingState godoc
+// @Summary Report unbonding request state
+// @Description Records the state of an unbonding request reported by the unbonding pipeline. The request only moves
+// @Description forward through PICKED_UP, COVENANT_SIGNATURES_COLLECTED, BROADCAST and CONFIRMED, and can be FAILED
+// @Description with a reason before it is confirmed. Reporting the current state again is a no-op.
+// @Description Requires the admin api key in the X-Admin-Api-Key header.
+// @Accept json
+// @Produce json
+// @Param staking_tx_hash_hex path string true "Staking Transaction Hash Hex"
+// @Param payload body UpdateUnbondingStateRequestPayload true "Reported state"
+// @Success 200 {object} PublicResponse[services.UnbondingStatusPublic] "Unbonding request status"
+// @Failure 400 {object} types.Error "Invalid request payload"
+// @Failure 401 "Missing or invalid admin api key"
+// @Failure 403 {object} types.Error "The unbonding request can not transition to the state"
+// @Failure 404 {object} types.Error "Unbonding request not found"
+// @Router /v1/admin/unbonding/{staking_tx_hash_hex}/state [post]
+func (h *Handler) UpdateUnbondingState(request *http.Request) (*Result, *types.Error) {
+	stakingTxHashHex := chi.URLParam(request, "staking_tx_hash_hex")
+	if !utils.IsValidTxHash(stakingTxHashHex) {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid staking_tx_hash_hex",
+		)
+	}
+	payload := &UpdateUnbondingStateRequestPayload{}
+	if err := json.NewDecoder(request.Body).Decode(payload); err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid request payload")
+	}
+	status, err := h.services.UpdateUnbondingState(
+		request.Context(), stakingTxHashHex, payload.State, payload.Reason,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(status), nil
+}
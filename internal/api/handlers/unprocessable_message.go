@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi"
+
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+type ReplayUnprocessableMessagesRequestPayload struct {
+	// Optional filters, an empty filter matches any value
+	QueueName    string `json:"queue_name,omitempty"`
+	FailedAfter  int64  `json:"failed_after,omitempty"`
+	FailedBefore int64  `json:"failed_before,omitempty"`
+}
+
+type DiscardUnprocessableMessageRequestPayload struct {
+	Reason string `json:"reason"`
+}
+
+func parseUnprocessableMessageFilterQuery(r *http.Request) (*db.UnprocessableMessageFilter, *types.Error) {
+	failedAfter, err := parseUint64Query(r, "failed_after")
+	if err != nil {
+		return nil, err
+	}
+	failedBefore, err := parseUint64Query(r, "failed_before")
+	if err != nil {
+		return nil, err
+	}
+	return &db.UnprocessableMessageFilter{
+		QueueName:    r.URL.Query().Get("queue_name"),
+		FailedAfter:  int64(failedAfter),
+		FailedBefore: int64(failedBefore),
+	}, nil
+}
+
+// GetUnprocessableMessages godoc
+// @Summary List unprocessable messages
+// @Description Lists the queue messages which exceeded the retry attempts, the latest first.
+// @Description Requires the admin api key in the X-Admin-Api-Key header.
+// @Produce json
+// @Param queue_name query string false "Filter by the source queue"
+// @Param failed_after query integer false "Filter by the failure unix timestamp, inclusive"
+// @Param failed_before query integer false "Filter by the failure unix timestamp, inclusive"
+// @Param pagination_key query string false "Pagination key to fetch the next page of messages"
+// @Success 200 {object} PublicResponse[[]services.UnprocessableMessagePublic]{array} "Unprocessable messages"
+// @Failure 400 {object} types.Error "Invalid filter or pagination key"
+// @Failure 401 "Missing or invalid admin api key"
+// @Router /v1/admin/unprocessable-messages [get]
+func (h *Handler) GetUnprocessableMessages(request *http.Request) (*Result, *types.Error) {
+	filter, err := parseUnprocessableMessageFilterQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	paginationKey, err := parsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	messages, paginationToken, err := h.services.GetUnprocessableMessages(
+		request.Context(), filter, paginationKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResultWithPagination(messages, paginationToken), nil
+}
+
+// GetUnprocessableMessage godoc
+// @Summary Get unprocessable message
+// @Description Requires the admin api key in the X-Admin-Api-Key header.
+// @Produce json
+// @Param id path string true "Unprocessable Message Id"
+// @Success 200 {object} PublicResponse[services.UnprocessableMessagePublic] "Unprocessable message"
+// @Failure 401 "Missing or invalid admin api key"
+// @Failure 404 {object} types.Error "Unprocessable message not found"
+// @Router /v1/admin/unprocessable-messages/{id} [get]
+func (h *Handler) GetUnprocessableMessage(request *http.Request) (*Result, *types.Error) {
+	message, err := h.services.GetUnprocessableMessage(request.Context(), chi.URLParam(request, "id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(message), nil
+}
+
+// ReplayUnprocessableMessage godoc
+// @Summary Replay unprocessable message
+// @Description Sends the message back to its original queue and removes it from the unprocessable messages.
+// @Description Requires the admin api key in the X-Admin-Api-Key header.
+// @Param id path string true "Unprocessable Message Id"
+// @Success 200 "The message is replayed"
+// @Failure 401 "Missing or invalid admin api key"
+// @Failure 404 {object} types.Error "Unprocessable message not found"
+// @Failure 422 {object} types.Error "The original queue of the message is unknown"
+// @Router /v1/admin/unprocessable-messages/{id}/replay [post]
+func (h *Handler) ReplayUnprocessableMessage(request *http.Request) (*Result, *types.Error) {
+	err := h.services.ReplayUnprocessableMessage(request.Context(), chi.URLParam(request, "id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Status: http.StatusOK}, nil
+}
+
+// ReplayUnprocessableMessages godoc
+// @Summary Replay unprocessable messages
+// @Description Replays the messages matching the filters, the oldest first. A failed replay doesn't stop the
+// @Description remaining ones, the ids of the replayed and failed messages are returned.
+// @Description Requires the admin api key in the X-Admin-Api-Key header.
+// @Accept json
+// @Produce json
+// @Param payload body ReplayUnprocessableMessagesRequestPayload true "Filters of the messages to replay"
+// @Success 200 {object} PublicResponse[services.UnprocessableMessagesReplayPublic] "Replay result"
+// @Failure 400 {object} types.Error "Invalid request payload"
+// @Failure 401 "Missing or invalid admin api key"
+// @Router /v1/admin/unprocessable-messages/replay [post]
+func (h *Handler) ReplayUnprocessableMessages(request *http.Request) (*Result, *types.Error) {
+	payload := &ReplayUnprocessableMessagesRequestPayload{}
+	if err := json.NewDecoder(request.Body).Decode(payload); err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid request payload")
+	}
+	if payload.FailedAfter < 0 || payload.FailedBefore < 0 {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid failure timestamp")
+	}
+	result, err := h.services.ReplayUnprocessableMessages(request.Context(), &db.UnprocessableMessageFilter{
+		QueueName:    payload.QueueName,
+		FailedAfter:  payload.FailedAfter,
+		FailedBefore: payload.FailedBefore,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(result), nil
+}
+
+// DiscardUnprocessableMessage godoc
+// @Summary Discard unprocessable message
+// @Description Removes the message without replaying it, the message is kept in the audit along with the reason.
+// @Description Requires the admin api key in the X-Admin-Api-Key header.
+// @Accept json
+// @Param id path string true "Unprocessable Message Id"
+// @Param payload body DiscardUnprocessableMessageRequestPayload true "Discard reason"
+// @Success 200 "The message is discarded"
+// @Failure 400 {object} types.Error "Missing reason"
+// @Failure 401 "Missing or invalid admin api key"
+// @Failure 404 {object} types.Error "Unprocessable message not found"
+// @Router /v1/admin/unprocessable-messages/{id}/discard [post]
+func (h *Handler) DiscardUnprocessableMessage(request *http.Request) (*Result, *types.Error) {
+	payload := &DiscardUnprocessableMessageRequestPayload{}
+	if err := json.NewDecoder(request.Body).Decode(payload); err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid request payload")
+	}
+	reason := strings.TrimSpace(payload.Reason)
+	if reason == "" {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "reason is required")
+	}
+	err := h.services.DiscardUnprocessableMessage(request.Context(), chi.URLParam(request, "id"), reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Status: http.StatusOK}, nil
+}
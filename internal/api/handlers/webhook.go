@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi"
+
+	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
+)
+
+type CreateWebhookSubscriptionRequestPayload struct {
+	Url string `json:"url"`
+	// Optional filters, an empty filter matches any value
+	StakerPkHex           string   `json:"staker_pk_hex,omitempty"`
+	FinalityProviderPkHex string   `json:"finality_provider_pk_hex,omitempty"`
+	EventTypes            []string `json:"event_types,omitempty"`
+}
+
+func parseCreateWebhookSubscriptionRequestPayload(
+	request *http.Request,
+) (*CreateWebhookSubscriptionRequestPayload, []types.WebhookEventType, *types.Error) {
+	payload := &CreateWebhookSubscriptionRequestPayload{}
+	err := json.NewDecoder(request.Body).Decode(payload)
+	if err != nil {
+		return nil, nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid request payload")
+	}
+	parsedUrl, err := url.ParseRequestURI(payload.Url)
+	if err != nil || (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") || parsedUrl.Host == "" {
+		return nil, nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid url, an absolute http or https url is expected",
+		)
+	}
+	if payload.StakerPkHex != "" {
+		if _, err := utils.GetSchnorrPkFromHex(payload.StakerPkHex); err != nil {
+			return nil, nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "invalid staker_pk_hex",
+			)
+		}
+	}
+	if payload.FinalityProviderPkHex != "" {
+		if _, err := utils.GetSchnorrPkFromHex(payload.FinalityProviderPkHex); err != nil {
+			return nil, nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest, "invalid finality_provider_pk_hex",
+			)
+		}
+	}
+	eventTypes := make([]types.WebhookEventType, 0, len(payload.EventTypes))
+	for _, value := range payload.EventTypes {
+		eventType, err := types.WebhookEventTypeFromString(value)
+		if err != nil {
+			return nil, nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, err.Error())
+		}
+		eventTypes = append(eventTypes, eventType)
+	}
+
+	return payload, eventTypes, nil
+}
+
+// CreateWebhookSubscription godoc
+// @Summary Create webhook subscription
+// @Description Registers a webhook receiving a POST request for each delegation state transition matching the filters.
+// @Description Each request is signed with the returned secret: the X-Webhook-Signature header holds the hex encoded
+// @Description HMAC-SHA256 of "{X-Webhook-Timestamp}.{body}". Failed deliveries are retried with exponential backoff.
+// @Description Requires the admin api key in the X-Admin-Api-Key header.
+// @Accept json
+// @Produce json
+// @Param payload body CreateWebhookSubscriptionRequestPayload true "Webhook Subscription"
+// @Success 200 {object} PublicResponse[services.WebhookSubscriptionPublic] "The subscription along with its secret"
+// @Failure 400 {object} types.Error "Invalid request payload"
+// @Failure 401 "Missing or invalid admin api key"
+// @Router /v1/admin/webhooks [post]
+func (h *Handler) CreateWebhookSubscription(request *http.Request) (*Result, *types.Error) {
+	payload, eventTypes, err := parseCreateWebhookSubscriptionRequestPayload(request)
+	if err != nil {
+		return nil, err
+	}
+	subscription, err := h.services.CreateWebhookSubscription(
+		request.Context(), payload.Url, payload.StakerPkHex,
+		payload.FinalityProviderPkHex, eventTypes,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(subscription), nil
+}
+
+// GetWebhookSubscriptions godoc
+// @Summary List webhook subscriptions
+// @Description Requires the admin api key in the X-Admin-Api-Key header.
+// @Produce json
+// @Success 200 {object} PublicResponse[[]services.WebhookSubscriptionPublic]{array} "Webhook subscriptions"
+// @Failure 401 "Missing or invalid admin api key"
+// @Router /v1/admin/webhooks [get]
+func (h *Handler) GetWebhookSubscriptions(request *http.Request) (*Result, *types.Error) {
+	subscriptions, err := h.services.GetWebhookSubscriptions(request.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(subscriptions), nil
+}
+
+// DeleteWebhookSubscription godoc
+// @Summary Delete webhook subscription
+// @Description The pending deliveries of the subscription are not sent anymore.
+// @Description Requires the admin api key in the X-Admin-Api-Key header.
+// @Param id path string true "Webhook Subscription Id"
+// @Success 200 "The subscription is deleted"
+// @Failure 401 "Missing or invalid admin api key"
+// @Failure 404 {object} types.Error "Webhook subscription not found"
+// @Router /v1/admin/webhooks/{id} [delete]
+func (h *Handler) DeleteWebhookSubscription(request *http.Request) (*Result, *types.Error) {
+	err := h.services.DeleteWebhookSubscription(request.Context(), chi.URLParam(request, "id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Status: http.StatusOK}, nil
+}
+
+// GetWebhookDeliveries godoc
+// @Summary List webhook deliveries
+// @Description Lists the deliveries of the subscription along with their attempts, the latest first.
+// @Description Requires the admin api key in the X-Admin-Api-Key header.
+// @Produce json
+// @Param id path string true "Webhook Subscription Id"
+// @Param pagination_key query string false "Pagination key to fetch the next page of deliveries"
+// @Success 200 {object} PublicResponse[[]services.WebhookDeliveryPublic]{array} "Webhook deliveries"
+// @Failure 400 {object} types.Error "Invalid pagination key"
+// @Failure 401 "Missing or invalid admin api key"
+// @Failure 404 {object} types.Error "Webhook subscription not found"
+// @Router /v1/admin/webhooks/{id}/deliveries [get]
+func (h *Handler) GetWebhookDeliveries(request *http.Request) (*Result, *types.Error) {
+	paginationKey, err := parsePaginationQuery(request)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, paginationToken, err := h.services.GetWebhookDeliveries(
+		request.Context(), chi.URLParam(request, "id"), paginationKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResultWithPagination(deliveries, paginationToken), nil
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
+)
+
+// maxWithdrawalFeeRate is the highest accepted fee rate in satoshis per
+// virtual byte, well above any fee rate seen on the bitcoin network
+const maxWithdrawalFeeRate = 10000
+
+// GetWithdrawalTemplate godoc
+// @Summary Get unsigned withdrawal transaction
+// @Description Builds the unsigned withdrawal transaction of an unbonded delegation, spending the timelock path
+// @Description of the unbonding output if the delegation was unbonded early, or of the staking output otherwise.
+// @Description The staker must sign the returned sighash, or the PSBT, and place the signature in the witness
+// @Description followed by the timelock script and the control block.
+// @Produce json
+// @Param staking_tx_hash_hex query string true "Staking Transaction Hash Hex"
+// @Param destination query string true "Taproot or native SegWit address receiving the withdrawn funds"
+// @Param fee_rate query integer true "Fee rate in satoshis per virtual byte, at most 10000"
+// @Success 200 {object} PublicResponse[services.WithdrawalTemplatePublic] "Unsigned withdrawal transaction"
+// @Failure 400 {object} types.Error "Invalid query parameters or the withdrawal output would be dust"
+// @Failure 403 {object} types.Error "Delegation is not unbonded"
+// @Failure 404 {object} types.Error "Delegation not found"
+// @Router /v1/withdraw/template [get]
+func (h *Handler) GetWithdrawalTemplate(request *http.Request) (*Result, *types.Error) {
+	stakingTxHashHex, err := parseTxHashQuery(request, "staking_tx_hash_hex")
+	if err != nil {
+		return nil, err
+	}
+	destination := request.URL.Query().Get("destination")
+	if destination == "" {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "destination is required",
+		)
+	}
+	if err := utils.IsValidBtcAddress(destination, h.config.Server.BTCNetParam); err != nil {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid destination: "+err.Error(),
+		)
+	}
+	feeRate, err := parseUint64Query(request, "fee_rate")
+	if err != nil {
+		return nil, err
+	}
+	if feeRate == 0 {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "fee_rate is required and must be greater than 0",
+		)
+	}
+	if feeRate > maxWithdrawalFeeRate {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, fmt.Sprintf("fee_rate must not exceed %d", maxWithdrawalFeeRate),
+		)
+	}
+
+	template, err := h.services.GetWithdrawalTemplate(
+		request.Context(), stakingTxHashHex, destination, feeRate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResult(template), nil
+}
@@ -1,8 +1,11 @@
 package api
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	logger "github.com/rs/zerolog"
 
@@ -64,11 +67,36 @@ func registerHandler(handlerFunc func(*http.Request) (*handlers.Result, *types.E
 			return
 		}
 
+		if result.ETag {
+			timer(writeResponseWithETag(w, r, result.Status, result.Data))
+			return
+		}
+
 		defer timer(result.Status)
 		writeResponse(w, r, result.Status, result.Data)
 	}
 }
 
+// registerStreamHandler registers a handler writing a streamed response.
+// The returned error is only written if the handler fails before streaming.
+func registerStreamHandler(handlerFunc func(http.ResponseWriter, *http.Request) *types.Error) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := handlerFunc(w, r)
+		if err == nil {
+			return
+		}
+		errorResponse := &ErrorResponse{
+			ErrorCode: string(err.ErrorCode),
+			Message:   err.Err.Error(),
+		}
+		if err.StatusCode >= http.StatusInternalServerError {
+			logger.Ctx(r.Context()).Error().Err(errorResponse).Msg("request failed with 5xx error")
+			errorResponse.Message = "Internal service error" // Hide the internal message error from client
+		}
+		writeResponse(w, r, err.StatusCode, errorResponse)
+	}
+}
+
 // Write and return response
 func writeResponse(w http.ResponseWriter, r *http.Request, statusCode int, res interface{}) {
 	respBytes, err := json.Marshal(res)
@@ -86,3 +114,46 @@ func writeResponse(w http.ResponseWriter, r *http.Request, statusCode int, res i
 		metrics.RecordHttpResponseWriteFailure(statusCode)
 	}
 }
+
+// writeResponseWithETag writes the response along with an ETag computed from
+// its content, or 304 Not Modified if the client already holds the content.
+// It returns the written status code.
+func writeResponseWithETag(w http.ResponseWriter, r *http.Request, statusCode int, res interface{}) int {
+	respBytes, err := json.Marshal(res)
+	if err != nil {
+		logger.Ctx(r.Context()).Err(err).Msg("failed to marshal response")
+		http.Error(w, "Failed to process the request. Please try again later.", http.StatusInternalServerError)
+		return http.StatusInternalServerError
+	}
+
+	hash := sha256.Sum256(respBytes)
+	etag := `"` + hex.EncodeToString(hash[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return http.StatusNotModified
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if _, err := w.Write(respBytes); err != nil {
+		logger.Ctx(r.Context()).Err(err).Msg("failed to write response")
+		metrics.RecordHttpResponseWriteFailure(statusCode)
+	}
+	return statusCode
+}
+
+// etagMatches tells whether the If-None-Match header matches the ETag. The
+// weak comparison applies, as required for If-None-Match.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
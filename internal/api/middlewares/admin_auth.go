@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/babylonchain/staking-api-service/internal/config"
+)
+
+// AdminApiKeyHeader carries the api key of the admin endpoints
+const AdminApiKeyHeader = "X-Admin-Api-Key"
+
+// AdminAuthMiddleware rejects the requests without the configured admin api key
+func AdminAuthMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
+	apiKey := []byte(cfg.Admin.ApiKey)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			providedKey := []byte(r.Header.Get(AdminApiKeyHeader))
+			if subtle.ConstantTimeCompare(providedKey, apiKey) != 1 {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
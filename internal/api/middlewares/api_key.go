@@ -0,0 +1,76 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/babylonchain/staking-api-service/internal/config"
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+type apiCallerContextKey struct{}
+
+// ApiKeyAuthenticator returns the caller of the api key if the key is valid
+// and allowed to call the path
+type ApiKeyAuthenticator interface {
+	AuthenticateApiKey(ctx context.Context, key, path string) (*types.ApiCaller, *types.Error)
+}
+
+// ApiCallerFromContext returns the caller authenticated by its api key, or
+// nil for the anonymous requests
+func ApiCallerFromContext(ctx context.Context) *types.ApiCaller {
+	caller, _ := ctx.Value(apiCallerContextKey{}).(*types.ApiCaller)
+	return caller
+}
+
+// CallerIdFromRequest identifies the caller of the request by its api key, or
+// by its ip for the anonymous requests
+func CallerIdFromRequest(cfg *config.Config, r *http.Request) string {
+	if caller := ApiCallerFromContext(r.Context()); caller != nil {
+		return "apikey:" + caller.KeyId
+	}
+	rateLimitCfg := cfg.RateLimit
+	if rateLimitCfg == nil {
+		// Without the client ip header, the ip of the remote address is used
+		rateLimitCfg = &config.RateLimitConfig{}
+	}
+	return "ip:" + clientIp(rateLimitCfg, r)
+}
+
+// ApiKeyMiddleware authenticates the requests carrying an api key and attaches
+// the caller to the request context and logs. The requests without an api key
+// are served anonymously.
+func ApiKeyMiddleware(cfg *config.Config, authenticator ApiKeyAuthenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if cfg.ApiKeys == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(cfg.ApiKeys.Header)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			caller, err := authenticator.AuthenticateApiKey(r.Context(), key, r.URL.Path)
+			if err != nil {
+				if err.StatusCode >= http.StatusInternalServerError {
+					http.Error(w, "Internal Server Error", err.StatusCode)
+					return
+				}
+				log.Ctx(r.Context()).Debug().Err(err).Msg("api key rejected")
+				http.Error(w, err.Err.Error(), err.StatusCode)
+				return
+			}
+
+			logger := log.Ctx(r.Context()).With().
+				Str("apiKeyId", caller.KeyId).
+				Str("apiKeyOwner", caller.Owner).
+				Str("apiKeyTier", caller.Tier).
+				Logger()
+			ctx := context.WithValue(r.Context(), apiCallerContextKey{}, caller)
+			next.ServeHTTP(w, r.WithContext(logger.WithContext(ctx)))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package middlewares
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/babylonchain/staking-api-service/internal/config"
 	"github.com/rs/cors"
@@ -13,6 +14,25 @@ const (
 	galxeOrigin               = "https://app.galxe.com"
 )
 
+// exposedHeaders can be read by the browsers, the rate limited clients are
+// told when to retry
+var exposedHeaders = []string{"Retry-After"}
+
+// allowedHeaders returns the defaults along with the idempotency key of the
+// unbonding requests and the configured api key and rate limit key headers
+func allowedHeaders(cfg *config.Config) []string {
+	headers := []string{"Accept", "Content-Type", "X-Requested-With", "Idempotency-Key"}
+	// ApiKeys is optional
+	if cfg.ApiKeys != nil {
+		headers = append(headers, cfg.ApiKeys.Header)
+	}
+	// RateLimit is optional
+	if cfg.RateLimit != nil && cfg.RateLimit.KeyHeader != "" {
+		headers = append(headers, cfg.RateLimit.KeyHeader)
+	}
+	return headers
+}
+
 func CorsMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		// Define a custom CORS policy function
@@ -23,6 +43,8 @@ func CorsMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
 				return cors.Options{
 					AllowedOrigins: []string{galxeOrigin},
 					AllowedMethods: []string{"GET", "OPTIONS", "POST"},
+					AllowedHeaders: allowedHeaders(cfg),
+					ExposedHeaders: exposedHeaders,
 					MaxAge:         maxAge,
 					// Below is a workaround to allow the custom CORS header to be set.
 					// i.e OPTIONS will be manually injected into `Access-Control-Allow-Methods` header
@@ -33,6 +55,8 @@ func CorsMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
 			// Default CORS options for other routes
 			return cors.Options{
 				AllowedOrigins: cfg.Server.AllowedOrigins,
+				AllowedHeaders: allowedHeaders(cfg),
+				ExposedHeaders: exposedHeaders,
 				MaxAge:         maxAge,
 			}
 		}
@@ -48,6 +72,7 @@ func CorsMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
 			if r.URL.Path == stakerDelegationCheckPath {
 				w.Header().Set("Access-Control-Allow-Origin", galxeOrigin)
 				w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS, POST")
+				w.Header().Set("Access-Control-Allow-Headers", strings.Join(allowedHeaders(cfg), ", "))
 				if r.Method == http.MethodOptions {
 					// This is a preflight request, respond with 204 immediately
 					w.WriteHeader(204)
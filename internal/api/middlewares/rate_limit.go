@@ -0,0 +1,133 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/babylonchain/staking-api-service/internal/config"
+	"github.com/babylonchain/staking-api-service/internal/observability/metrics"
+)
+
+// RateLimiter takes a token from the bucket of the key under the rule. If the
+// bucket is empty, it returns false along with the time to wait for the next
+// token.
+type RateLimiter interface {
+	TakeRateLimitToken(ctx context.Context, key string, rule *config.RateLimitRule) (bool, time.Duration, error)
+}
+
+// RateLimitMiddleware limits the requests of the route group per api key
+// caller, per client key, or per client ip for the other requests. The
+// requests are not limited if the group has no limits.
+func RateLimitMiddleware(cfg *config.Config, limiter RateLimiter, group string) func(http.Handler) http.Handler {
+	var groupCfg *config.RateLimitGroupConfig
+	if cfg.RateLimit != nil {
+		groupCfg = cfg.RateLimit.Groups[group]
+	}
+	return func(next http.Handler) http.Handler {
+		if groupCfg == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clientType, clientId, rule := identifyRateLimitClient(cfg.RateLimit, groupCfg, r)
+			limitRequest(w, r, next, limiter, group, clientType, clientId, rule)
+		})
+	}
+}
+
+// ApiKeyRateLimitMiddleware limits the requests carrying an api key per client
+// ip before they are authenticated, so that invalid api keys are throttled
+// before they are looked up. The limit is the most permissive rule of the
+// group, hence the callers are not limited below the limit of their tier.
+func ApiKeyRateLimitMiddleware(cfg *config.Config, limiter RateLimiter, group string) func(http.Handler) http.Handler {
+	var groupCfg *config.RateLimitGroupConfig
+	if cfg.RateLimit != nil {
+		groupCfg = cfg.RateLimit.Groups[group]
+	}
+	return func(next http.Handler) http.Handler {
+		if groupCfg == nil || cfg.ApiKeys == nil {
+			return next
+		}
+		rule := groupCfg.MostPermissiveRule()
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(cfg.ApiKeys.Header) == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			limitRequest(w, r, next, limiter, group, "apikey-ip", clientIp(cfg.RateLimit, r), rule)
+		})
+	}
+}
+
+// limitRequest takes a token from the bucket of the client under the rule and
+// serves the request, or rejects it if the bucket is empty
+func limitRequest(
+	w http.ResponseWriter, r *http.Request, next http.Handler, limiter RateLimiter,
+	group, clientType, clientId string, rule *config.RateLimitRule,
+) {
+	key := group + ":" + clientType + ":" + clientId
+	allowed, retryAfter, err := limiter.TakeRateLimitToken(r.Context(), key, rule)
+	if err != nil {
+		// The requests are let through rather than failing them all
+		log.Ctx(r.Context()).Error().Err(err).Str("group", group).
+			Msg("error while taking rate limit token, request is not limited")
+		next.ServeHTTP(w, r)
+		return
+	}
+	if !allowed {
+		metrics.RecordRateLimitedRequest(group, clientType)
+		retryAfterSeconds := int(math.Ceil(retryAfter.Seconds()))
+		w.Header().Set("Retry-After", strconv.Itoa(max(retryAfterSeconds, 1)))
+		http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+		return
+	}
+	next.ServeHTTP(w, r)
+}
+
+// identifyRateLimitClient returns the type and id of the client along with
+// its rule. The client keys are hashed, so that they are not stored as is.
+func identifyRateLimitClient(
+	cfg *config.RateLimitConfig, groupCfg *config.RateLimitGroupConfig, r *http.Request,
+) (string, string, *config.RateLimitRule) {
+	if caller := ApiCallerFromContext(r.Context()); caller != nil {
+		if rule, ok := groupCfg.Tiers[caller.Tier]; ok {
+			return "apikey", caller.KeyId, rule
+		}
+		return "apikey", caller.KeyId, &groupCfg.RateLimitRule
+	}
+	if providedKey := r.Header.Get(cfg.KeyHeader); providedKey != "" {
+		for _, client := range groupCfg.Clients {
+			if subtle.ConstantTimeCompare([]byte(providedKey), []byte(client.Key)) == 1 {
+				hash := sha256.Sum256([]byte(client.Key))
+				return "key", hex.EncodeToString(hash[:]), &client.RateLimitRule
+			}
+		}
+	}
+	return "ip", clientIp(cfg, r), &groupCfg.RateLimitRule
+}
+
+func clientIp(cfg *config.RateLimitConfig, r *http.Request) string {
+	if cfg.ClientIpHeader != "" {
+		// Each proxy appends the address it received the request from, hence
+		// only the rightmost entries set by the trusted proxies can be relied on.
+		forwarded := strings.Split(r.Header.Get(cfg.ClientIpHeader), ",")
+		trustedProxies := max(cfg.TrustedProxies, 1)
+		if ip := strings.TrimSpace(forwarded[max(0, len(forwarded)-trustedProxies)]); ip != "" {
+			return ip
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
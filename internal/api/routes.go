@@ -2,6 +2,8 @@ package api
 
 import (
 	_ "github.com/babylonchain/staking-api-service/docs"
+	"github.com/babylonchain/staking-api-service/internal/api/middlewares"
+	"github.com/babylonchain/staking-api-service/internal/config"
 	"github.com/go-chi/chi"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
@@ -10,20 +12,73 @@ func (a *Server) SetupRoutes(r *chi.Mux) {
 	handlers := a.handlers
 	r.Get("/healthcheck", registerHandler(handlers.HealthCheck))
 
-	r.Get("/v1/staker/delegations", registerHandler(handlers.GetStakerDelegations))
-	r.Post("/v1/unbonding", registerHandler(handlers.UnbondDelegation))
-	r.Get("/v1/unbonding/eligibility", registerHandler(handlers.GetUnbondingEligibility))
-	r.Get("/v1/global-params", registerHandler(handlers.GetBabylonGlobalParams))
-	r.Get("/v1/finality-providers", registerHandler(handlers.GetFinalityProviders))
-	r.Get("/v1/stats", registerHandler(handlers.GetOverallStats))
-	r.Get("/v1/stats/staker", registerHandler(handlers.GetTopStakerStats))
-	r.Get("/v1/staker/delegation/check", registerHandler(handlers.CheckStakerDelegationExist))
-	r.Get("/v1/delegation", registerHandler(handlers.GetDelegationByTxHash))
+	// The public routes are served anonymously unless an api key is provided,
+	// and are rate limited per caller if configured. The requests carrying an
+	// api key are rate limited per ip before the api key is looked up.
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.ApiKeyRateLimitMiddleware(a.cfg, a.rateLimiter, config.RateLimitPublicGroup))
+		r.Use(middlewares.ApiKeyMiddleware(a.cfg, a.apiKeyAuthenticator))
+		r.Use(middlewares.RateLimitMiddleware(a.cfg, a.rateLimiter, config.RateLimitPublicGroup))
+		r.Get("/v1/staker/delegations", registerHandler(handlers.GetStakerDelegations))
+		r.Get("/v1/staker/portfolio", registerHandler(handlers.GetStakerPortfolio))
+		r.Get("/v1/stream/staker", registerStreamHandler(handlers.StreamStakerDelegations))
+		r.Post("/v1/unbonding", registerHandler(handlers.UnbondDelegation))
+		r.Get("/v1/unbonding/eligibility", registerHandler(handlers.GetUnbondingEligibility))
+		r.Get("/v1/unbonding/template", registerHandler(handlers.GetUnbondingTemplate))
+		r.Get("/v1/unbonding/status", registerHandler(handlers.GetUnbondingStatus))
+		r.Get("/v1/withdraw/template", registerHandler(handlers.GetWithdrawalTemplate))
+		r.Post("/v1/staking/validate", registerHandler(handlers.ValidateStakingTx))
+		r.Get("/v1/global-params", registerHandler(handlers.GetBabylonGlobalParams))
+		r.Get("/v1/global-params/at-height", registerHandler(handlers.GetBabylonGlobalParamsAtHeight))
+		r.Get("/v1/global-params/current", registerHandler(handlers.GetCurrentBabylonGlobalParams))
+		r.Get("/v1/finality-providers", registerHandler(handlers.GetFinalityProviders))
+		r.Get("/v1/finality-providers/{fp_pk}", registerHandler(handlers.GetFinalityProvider))
+		r.Get("/v1/stats", registerHandler(handlers.GetOverallStats))
+		r.Get("/v1/stats/staker", registerHandler(handlers.GetTopStakerStats))
+		r.Get("/v1/stats/staker/{pk}", registerHandler(handlers.GetStakerStats))
+		if a.cfg.StatsSnapshot != nil {
+			r.Get("/v1/stats/history", registerHandler(handlers.GetStatsHistory))
+		}
+		r.Get("/v1/expirations", registerHandler(handlers.GetExpirationsForecast))
+		r.Get("/v1/staker/delegation/check", registerHandler(handlers.CheckStakerDelegationExist))
+		r.Get("/v1/delegation", registerHandler(handlers.GetDelegationByTxHash))
+		r.Get("/v1/delegation/history", registerHandler(handlers.GetDelegationHistory))
+		r.Get("/v1/search", registerHandler(handlers.Search))
+		r.Post("/v1/delegations/lookup", registerHandler(handlers.LookupDelegations))
+	})
 
 	// Only register these routes if the asset has been configured
 	// The endpoints are used to check ordinals within the UTXOs
 	if a.cfg.Assets != nil {
-		r.Post("/v1/ordinals/verify-utxos", registerHandler(handlers.VerifyUTXOs))
+		// The endpoint fans out to the ordinals and unisat services, hence it
+		// has its own rate limit
+		r.With(
+			middlewares.ApiKeyRateLimitMiddleware(a.cfg, a.rateLimiter, config.RateLimitOrdinalsGroup),
+			middlewares.ApiKeyMiddleware(a.cfg, a.apiKeyAuthenticator),
+			middlewares.RateLimitMiddleware(a.cfg, a.rateLimiter, config.RateLimitOrdinalsGroup),
+		).Post("/v1/ordinals/verify-utxos", registerHandler(handlers.VerifyUTXOs))
+	}
+
+	// Only register the admin routes if the admin api key has been configured
+	if a.cfg.Admin != nil {
+		r.Group(func(r chi.Router) {
+			r.Use(middlewares.AdminAuthMiddleware(a.cfg))
+			r.Get("/v1/admin/unprocessable-messages", registerHandler(handlers.GetUnprocessableMessages))
+			r.Post("/v1/admin/unprocessable-messages/replay", registerHandler(handlers.ReplayUnprocessableMessages))
+			r.Get("/v1/admin/unprocessable-messages/{id}", registerHandler(handlers.GetUnprocessableMessage))
+			r.Post("/v1/admin/unprocessable-messages/{id}/replay", registerHandler(handlers.ReplayUnprocessableMessage))
+			r.Post("/v1/admin/unprocessable-messages/{id}/discard", registerHandler(handlers.DiscardUnprocessableMessage))
+			// Used by the unbonding pipeline to report the progress of the unbonding requests
+			r.Post("/v1/admin/unbonding/{staking_tx_hash_hex}/state", registerHandler(handlers.UpdateUnbondingState))
+
+			// The webhook subscriptions are only managed if the webhooks are enabled
+			if a.cfg.Webhooks != nil {
+				r.Post("/v1/admin/webhooks", registerHandler(handlers.CreateWebhookSubscription))
+				r.Get("/v1/admin/webhooks", registerHandler(handlers.GetWebhookSubscriptions))
+				r.Delete("/v1/admin/webhooks/{id}", registerHandler(handlers.DeleteWebhookSubscription))
+				r.Get("/v1/admin/webhooks/{id}/deliveries", registerHandler(handlers.GetWebhookDeliveries))
+			}
+		})
 	}
 
 	r.Get("/swagger/*", httpSwagger.WrapHandler)
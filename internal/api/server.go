@@ -15,9 +15,11 @@ import (
 )
 
 type Server struct {
-	httpServer *http.Server
-	handlers   *handlers.Handler
-	cfg        *config.Config
+	httpServer          *http.Server
+	handlers            *handlers.Handler
+	cfg                 *config.Config
+	rateLimiter         middlewares.RateLimiter
+	apiKeyAuthenticator middlewares.ApiKeyAuthenticator
 }
 
 func New(
@@ -51,9 +53,11 @@ func New(
 	}
 
 	server := &Server{
-		httpServer: srv,
-		handlers:   handlers,
-		cfg:        cfg,
+		httpServer:          srv,
+		handlers:            handlers,
+		cfg:                 cfg,
+		rateLimiter:         services,
+		apiKeyAuthenticator: services,
 	}
 	server.SetupRoutes(r)
 	return server, nil
@@ -0,0 +1,19 @@
+package config
+
+import "errors"
+
+// minAdminApiKeyLength guards against guessable admin api keys
+const minAdminApiKeyLength = 16
+
+// AdminConfig enables the admin endpoints, which are authenticated by the api key
+type AdminConfig struct {
+	ApiKey string `mapstructure:"api-key"`
+}
+
+func (cfg *AdminConfig) Validate() error {
+	if len(cfg.ApiKey) < minAdminApiKeyLength {
+		return errors.New("admin api key must be at least 16 characters long")
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package config
+
+import "errors"
+
+// ApiKeysConfig enables the authentication of the callers by their api key.
+// The requests without an api key are still served anonymously.
+type ApiKeysConfig struct {
+	Header string `mapstructure:"header"`
+}
+
+func (cfg *ApiKeysConfig) Validate() error {
+	if cfg.Header == "" {
+		return errors.New("api keys header cannot be empty")
+	}
+
+	return nil
+}
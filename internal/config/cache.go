@@ -0,0 +1,34 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	CacheMemoryBackend = "memory"
+	CacheMongoBackend  = "mongo"
+)
+
+// CacheConfig enables the caching of the read heavy endpoints. The cached
+// responses are invalidated whenever their data is written, the TTL bounds
+// the staleness of the data written by other means.
+type CacheConfig struct {
+	// Where the responses are cached, "memory" for a single service replica
+	// or "mongo" to share the cache and its invalidation across replicas
+	Backend string        `mapstructure:"backend"`
+	Ttl     time.Duration `mapstructure:"ttl"`
+}
+
+func (cfg *CacheConfig) Validate() error {
+	if cfg.Backend != CacheMemoryBackend && cfg.Backend != CacheMongoBackend {
+		return fmt.Errorf("cache backend must be either %s or %s", CacheMemoryBackend, CacheMongoBackend)
+	}
+
+	if cfg.Ttl <= 0 {
+		return errors.New("cache ttl must be positive")
+	}
+
+	return nil
+}
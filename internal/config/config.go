@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -10,11 +11,17 @@ import (
 )
 
 type Config struct {
-	Server  *ServerConfig      `mapstructure:"server"`
-	Db      *DbConfig          `mapstructure:"db"`
-	Queue   *queue.QueueConfig `mapstructure:"queue"`
-	Metrics *MetricsConfig     `mapstructure:"metrics"`
-	Assets  *AssetsConfig      `mapstructure:"assets"`
+	Server        *ServerConfig        `mapstructure:"server"`
+	Db            *DbConfig            `mapstructure:"db"`
+	Queue         *queue.QueueConfig   `mapstructure:"queue"`
+	Metrics       *MetricsConfig       `mapstructure:"metrics"`
+	StatsSnapshot *StatsSnapshotConfig `mapstructure:"stats-snapshot"`
+	Assets        *AssetsConfig        `mapstructure:"assets"`
+	Admin         *AdminConfig         `mapstructure:"admin"`
+	Webhooks      *WebhooksConfig      `mapstructure:"webhooks"`
+	RateLimit     *RateLimitConfig     `mapstructure:"rate-limit"`
+	ApiKeys       *ApiKeysConfig       `mapstructure:"api-keys"`
+	Cache         *CacheConfig         `mapstructure:"cache"`
 }
 
 func (cfg *Config) Validate() error {
@@ -34,6 +41,13 @@ func (cfg *Config) Validate() error {
 		return err
 	}
 
+	// StatsSnapshot is optional, the stats history is disabled without it
+	if cfg.StatsSnapshot != nil {
+		if err := cfg.StatsSnapshot.Validate(); err != nil {
+			return err
+		}
+	}
+
 	// Assets is optional
 	if cfg.Assets != nil {
 		if err := cfg.Assets.Validate(); err != nil {
@@ -41,6 +55,44 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	// Admin is optional
+	if cfg.Admin != nil {
+		if err := cfg.Admin.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// Webhooks is optional, the subscriptions are managed through the admin endpoints
+	if cfg.Webhooks != nil {
+		if cfg.Admin == nil {
+			return errors.New("webhooks require the admin config to manage the subscriptions")
+		}
+		if err := cfg.Webhooks.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// RateLimit is optional
+	if cfg.RateLimit != nil {
+		if err := cfg.RateLimit.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// ApiKeys is optional
+	if cfg.ApiKeys != nil {
+		if err := cfg.ApiKeys.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// Cache is optional
+	if cfg.Cache != nil {
+		if err := cfg.Cache.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
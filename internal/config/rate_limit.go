@@ -0,0 +1,126 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	RateLimitMemoryBackend = "memory"
+	RateLimitMongoBackend  = "mongo"
+)
+
+// The route groups which can be rate limited
+const (
+	RateLimitPublicGroup   = "public"
+	RateLimitOrdinalsGroup = "ordinals"
+)
+
+// RateLimitConfig defines the token bucket limits of the route groups. The
+// requests are limited per client ip, unless they carry a configured client
+// key in the key header.
+type RateLimitConfig struct {
+	// Where the buckets are stored, "memory" for a single service replica or
+	// "mongo" to share the buckets across replicas
+	Backend   string `mapstructure:"backend"`
+	KeyHeader string `mapstructure:"key-header"`
+	// Optional header holding the client ip set by a trusted proxy, e.g.
+	// X-Forwarded-For. The connection remote address is used otherwise.
+	ClientIpHeader string `mapstructure:"client-ip-header"`
+	// Number of trusted proxies appending to the client ip header, defaults
+	// to 1. The client ip is the entry appended by the outermost trusted
+	// proxy, as the entries on its left are set by the client.
+	TrustedProxies int `mapstructure:"trusted-proxies"`
+	// The limits by route group, the routes of a group without limits are
+	// not limited
+	Groups map[string]*RateLimitGroupConfig `mapstructure:"groups"`
+}
+
+type RateLimitGroupConfig struct {
+	RateLimitRule `mapstructure:",squash"`
+	// Limits of the clients identified by their key, overriding the ip limit
+	Clients []*RateLimitClientConfig `mapstructure:"clients"`
+	// Limits of the callers authenticated by their api key, by the tier of
+	// the api key. The callers of other tiers are limited by the group limit,
+	// though per api key rather than per ip.
+	Tiers map[string]*RateLimitRule `mapstructure:"tiers"`
+}
+
+type RateLimitClientConfig struct {
+	RateLimitRule `mapstructure:",squash"`
+	Key           string `mapstructure:"key"`
+}
+
+// RateLimitRule is a token bucket refilled at the rate up to the burst
+type RateLimitRule struct {
+	RequestsPerSecond float64 `mapstructure:"requests-per-second"`
+	Burst             int     `mapstructure:"burst"`
+}
+
+func (cfg *RateLimitConfig) Validate() error {
+	if cfg.Backend != RateLimitMemoryBackend && cfg.Backend != RateLimitMongoBackend {
+		return fmt.Errorf("rate limit backend must be either %s or %s", RateLimitMemoryBackend, RateLimitMongoBackend)
+	}
+
+	if cfg.KeyHeader == "" {
+		return errors.New("rate limit key header cannot be empty")
+	}
+
+	if cfg.TrustedProxies < 0 {
+		return errors.New("rate limit trusted proxies cannot be negative")
+	}
+
+	for name, group := range cfg.Groups {
+		if err := group.Validate(); err != nil {
+			return fmt.Errorf("invalid rate limit of group %s: %w", name, err)
+		}
+		for tier, rule := range group.Tiers {
+			if err := rule.Validate(); err != nil {
+				return fmt.Errorf("invalid rate limit of tier %s of group %s: %w", tier, name, err)
+			}
+		}
+		for _, client := range group.Clients {
+			if client.Key == "" {
+				return fmt.Errorf("rate limit client key of group %s cannot be empty", name)
+			}
+			if err := client.Validate(); err != nil {
+				return fmt.Errorf("invalid rate limit of a client of group %s: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// MostPermissiveRule returns the highest rate and burst among the limit of the
+// group and the limits of its tiers
+func (cfg *RateLimitGroupConfig) MostPermissiveRule() *RateLimitRule {
+	rule := cfg.RateLimitRule
+	for _, tierRule := range cfg.Tiers {
+		rule.RequestsPerSecond = max(rule.RequestsPerSecond, tierRule.RequestsPerSecond)
+		rule.Burst = max(rule.Burst, tierRule.Burst)
+	}
+	return &rule
+}
+
+// HasTier returns whether the tier has a limit in any of the groups
+func (cfg *RateLimitConfig) HasTier(tier string) bool {
+	for _, group := range cfg.Groups {
+		if _, ok := group.Tiers[tier]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule *RateLimitRule) Validate() error {
+	if rule.RequestsPerSecond <= 0 {
+		return errors.New("requests per second must be positive")
+	}
+
+	if rule.Burst <= 0 {
+		return errors.New("burst must be a positive integer")
+	}
+
+	return nil
+}
@@ -12,17 +12,31 @@ import (
 	"github.com/babylonchain/staking-api-service/internal/utils"
 )
 
+const (
+	// defaultMaxDelegationLookupSize is the maximum number of staking tx hashes
+	// in a single delegations lookup if not configured
+	defaultMaxDelegationLookupSize = 50
+	// defaultMaxPortfolioStakers is the maximum number of staker public keys
+	// and addresses aggregated into a single portfolio if not configured
+	defaultMaxPortfolioStakers = 20
+	// delegationLookupTxHashSize is the size of a staking tx hash in the
+	// compact lookup payload, i.e. the quoted 64 hex characters and a comma
+	delegationLookupTxHashSize = 67
+)
+
 type ServerConfig struct {
-	Host                string        `mapstructure:"host"`
-	Port                int           `mapstructure:"port"`
-	WriteTimeout        time.Duration `mapstructure:"write-timeout"`
-	ReadTimeout         time.Duration `mapstructure:"read-timeout"`
-	IdleTimeout         time.Duration `mapstructure:"idle-timeout"`
-	AllowedOrigins      []string      `mapstructure:"allowed-origins"`
-	BTCNet              string        `mapstructure:"btc-net"`
-	LogLevel            string        `mapstructure:"log-level"`
-	MaxContentLength    int64         `mapstructure:"max-content-length"`
-	HealthCheckInterval int           `mapstructure:"health-check-interval"`
+	Host                    string        `mapstructure:"host"`
+	Port                    int           `mapstructure:"port"`
+	WriteTimeout            time.Duration `mapstructure:"write-timeout"`
+	ReadTimeout             time.Duration `mapstructure:"read-timeout"`
+	IdleTimeout             time.Duration `mapstructure:"idle-timeout"`
+	AllowedOrigins          []string      `mapstructure:"allowed-origins"`
+	BTCNet                  string        `mapstructure:"btc-net"`
+	LogLevel                string        `mapstructure:"log-level"`
+	MaxContentLength        int64         `mapstructure:"max-content-length"`
+	HealthCheckInterval     int           `mapstructure:"health-check-interval"`
+	MaxDelegationLookupSize int           `mapstructure:"max-delegation-lookup-size"`
+	MaxPortfolioStakers     int           `mapstructure:"max-portfolio-stakers"`
 
 	BTCNetParam *chaincfg.Params
 }
@@ -57,6 +71,28 @@ func (cfg *ServerConfig) Validate() error {
 		return fmt.Errorf("HealthCheckInterval must be a positive integer")
 	}
 
+	if cfg.MaxDelegationLookupSize < 0 {
+		return fmt.Errorf("MaxDelegationLookupSize must not be negative")
+	}
+	if cfg.MaxDelegationLookupSize == 0 {
+		cfg.MaxDelegationLookupSize = defaultMaxDelegationLookupSize
+	}
+	// The lookup payload is capped by the max content length as well, hence
+	// it must fit the maximum number of tx hashes
+	if int64(cfg.MaxDelegationLookupSize*delegationLookupTxHashSize) > cfg.MaxContentLength {
+		return fmt.Errorf(
+			"MaxContentLength of %d bytes can't fit a lookup of MaxDelegationLookupSize %d tx hashes",
+			cfg.MaxContentLength, cfg.MaxDelegationLookupSize,
+		)
+	}
+
+	if cfg.MaxPortfolioStakers < 0 {
+		return fmt.Errorf("MaxPortfolioStakers must not be negative")
+	}
+	if cfg.MaxPortfolioStakers == 0 {
+		cfg.MaxPortfolioStakers = defaultMaxPortfolioStakers
+	}
+
 	btcNet, err := utils.GetBtcNetParamesFromString(cfg.BTCNet)
 	if err != nil {
 		return errors.New("invalid btc-net")
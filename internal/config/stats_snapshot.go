@@ -0,0 +1,36 @@
+package config
+
+import "fmt"
+
+// defaultStatsSnapshotRetention is 90 days in seconds
+const defaultStatsSnapshotRetention = 90 * 24 * 60 * 60
+
+// StatsSnapshotConfig defines how often the stats snapshots are taken, how
+// long they are kept and how many data points can be requested from the stats history
+type StatsSnapshotConfig struct {
+	// Interval in seconds between the periodic stats snapshots
+	Interval int `mapstructure:"interval"`
+	// Retention in seconds of the snapshots, defaults to 90 days
+	Retention int `mapstructure:"retention"`
+	// Maximum number of interval buckets returned by a single history request
+	MaxHistoryBuckets int64 `mapstructure:"max-history-buckets"`
+}
+
+func (cfg *StatsSnapshotConfig) Validate() error {
+	if cfg.Interval <= 0 {
+		return fmt.Errorf("stats snapshot interval must be a positive integer")
+	}
+
+	if cfg.Retention < 0 {
+		return fmt.Errorf("stats snapshot retention must not be negative")
+	}
+	if cfg.Retention == 0 {
+		cfg.Retention = defaultStatsSnapshotRetention
+	}
+
+	if cfg.MaxHistoryBuckets <= 0 {
+		return fmt.Errorf("stats snapshot max history buckets must be a positive integer")
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// WebhooksConfig defines how the webhook deliveries are retried
+type WebhooksConfig struct {
+	// Interval between the polls of the due deliveries
+	DeliveryInterval time.Duration `mapstructure:"delivery-interval"`
+	// Maximum number of deliveries sent per poll
+	BatchSize int `mapstructure:"batch-size"`
+	// A delivery is marked as failed after this number of attempts
+	MaxAttempts int `mapstructure:"max-attempts"`
+	// The backoff between attempts starts from the initial backoff and is
+	// doubled after each failed attempt, up to the max backoff
+	InitialBackoff time.Duration `mapstructure:"initial-backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max-backoff"`
+	// Timeout of a single delivery request
+	RequestTimeout time.Duration `mapstructure:"request-timeout"`
+}
+
+func (cfg *WebhooksConfig) Validate() error {
+	if cfg.DeliveryInterval <= 0 {
+		return errors.New("webhooks delivery interval must be positive")
+	}
+
+	if cfg.BatchSize <= 0 {
+		return errors.New("webhooks batch size must be a positive integer")
+	}
+
+	if cfg.MaxAttempts <= 0 {
+		return errors.New("webhooks max attempts must be a positive integer")
+	}
+
+	if cfg.InitialBackoff <= 0 {
+		return errors.New("webhooks initial backoff must be positive")
+	}
+
+	if cfg.MaxBackoff < cfg.InitialBackoff {
+		return errors.New("webhooks max backoff cannot be smaller than the initial backoff")
+	}
+
+	if cfg.RequestTimeout <= 0 {
+		return errors.New("webhooks request timeout must be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+)
+
+func (db *Database) SaveApiKey(ctx context.Context, apiKey *model.ApiKeyDocument) error {
+	client := db.Client.Database(db.DbName).Collection(model.ApiKeyCollection)
+	_, err := client.InsertOne(ctx, apiKey)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return &DuplicateKeyError{
+				Key:     apiKey.Id,
+				Message: "api key already exists",
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// FindApiKeyByHash returns a NotFoundError if no key has the hash
+func (db *Database) FindApiKeyByHash(ctx context.Context, keyHash string) (*model.ApiKeyDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.ApiKeyCollection)
+	var apiKey model.ApiKeyDocument
+	err := client.FindOne(ctx, bson.M{"key_hash": keyHash}).Decode(&apiKey)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &NotFoundError{
+				Message: "api key not found",
+			}
+		}
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+// RevokeApiKey returns a NotFoundError if the key does not exist or has
+// already been revoked
+func (db *Database) RevokeApiKey(ctx context.Context, id string, revokedAt int64) error {
+	client := db.Client.Database(db.DbName).Collection(model.ApiKeyCollection)
+	filter := bson.M{"_id": id, "revoked_at": 0}
+	update := bson.M{"$set": bson.M{"revoked_at": revokedAt}}
+	result, err := client.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return &NotFoundError{
+			Key:     id,
+			Message: "api key not found or already revoked",
+		}
+	}
+	return nil
+}
@@ -2,6 +2,9 @@ package db
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -16,7 +19,7 @@ import (
 func (db *Database) SaveActiveStakingDelegation(
 	ctx context.Context, stakingTxHashHex, stakerPkHex, fpPkHex string,
 	stakingTxHex string, amount, startHeight, timelock, outputIndex uint64,
-	startTimestamp int64, isOverflow bool, stakerTaprootAddress string,
+	startTimestamp int64, isOverflow bool, stakerBtcAddress *model.StakerBtcAddress,
 ) error {
 	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
 	document := model.DelegationDocument{
@@ -32,39 +35,57 @@ func (db *Database) SaveActiveStakingDelegation(
 			StartHeight:    startHeight,
 			TimeLock:       timelock,
 		},
-		IsOverflow: isOverflow,
-		StakerBtcAddress: &model.StakerBtcAddress{
-			TaprootAddress: stakerTaprootAddress,
-		},
+		IsOverflow:       isOverflow,
+		StakerBtcAddress: stakerBtcAddress,
 	}
-	_, err := client.InsertOne(ctx, document)
-	if err != nil {
-		var writeErr mongo.WriteException
-		if errors.As(err, &writeErr) {
-			for _, e := range writeErr.WriteErrors {
-				if mongo.IsDuplicateKeyError(e) {
-					// Return the custom error type so that we can return 4xx errors to client
-					return &DuplicateKeyError{
-						Key:     stakingTxHashHex,
-						Message: "Delegation already exists",
+
+	// Start a session
+	session, sessionErr := db.Client.StartSession()
+	if sessionErr != nil {
+		return sessionErr
+	}
+	defer session.EndSession(ctx)
+
+	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		_, err := client.InsertOne(sessCtx, document)
+		if err != nil {
+			var writeErr mongo.WriteException
+			if errors.As(err, &writeErr) {
+				for _, e := range writeErr.WriteErrors {
+					if mongo.IsDuplicateKeyError(e) {
+						// Return the custom error type so that we can return 4xx errors to client
+						return nil, &DuplicateKeyError{
+							Key:     stakingTxHashHex,
+							Message: "Delegation already exists",
+						}
 					}
 				}
 			}
+			return nil, err
+		}
+
+		err = db.saveDelegationStateHistory(sessCtx, &document, model.NewDelegationStateHistoryDocument(
+			stakingTxHashHex, "", types.Active, startHeight, startTimestamp,
+			types.ActiveStakingEventSource,
+		))
+		if err != nil {
+			return nil, err
 		}
-		return err
+		return nil, nil
 	}
-	return nil
+
+	// Execute the transaction
+	_, txErr := session.WithTransaction(ctx, transactionWork)
+	return txErr
 }
 
-// CheckDelegationExistByStakerTaprootAddress checks if a staker has any
-// delegation in the specified states by the staker's BTC address in taproot format.
-func (db *Database) CheckDelegationExistByStakerTaprootAddress(
+// CheckDelegationExistByStakerAddress checks if a staker has any delegation in
+// the specified states by the staker's BTC address in any of the derived formats.
+func (db *Database) CheckDelegationExistByStakerAddress(
 	ctx context.Context, address string, extraFilter *DelegationFilter,
 ) (bool, error) {
 	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
-	filter := buildAdditionalDelegationFilter(
-		bson.M{"staker_btc_address.taproot_address": address}, extraFilter,
-	)
+	filter := buildAdditionalDelegationFilter(buildStakerAddressFilter(address), extraFilter)
 	var delegation model.DelegationDocument
 	err := client.FindOne(ctx, filter).Decode(&delegation)
 	if err != nil {
@@ -76,11 +97,159 @@ func (db *Database) CheckDelegationExistByStakerTaprootAddress(
 	return true, nil
 }
 
-func (db *Database) FindDelegationsByStakerPk(ctx context.Context, stakerPk string, paginationToken string) (*DbResultMap[model.DelegationDocument], error) {
+// CheckDelegationExistByStakerPk checks if the staker has any delegation
+func (db *Database) CheckDelegationExistByStakerPk(ctx context.Context, stakerPk string) (bool, error) {
 	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	opts := options.FindOne().SetProjection(bson.M{"_id": 1})
+	var delegation model.DelegationDocument
+	err := client.FindOne(ctx, bson.M{"staker_pk_hex": stakerPk}, opts).Decode(&delegation)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
 
-	filter := bson.M{"staker_pk_hex": stakerPk}
-	options := options.Find().SetSort(bson.M{"staking_tx.start_height": -1}) // Sorting in descending order
+// FindStakerPkByAddress returns the staker public key of the delegations
+// owned by the given BTC address in any of the derived formats.
+func (db *Database) FindStakerPkByAddress(ctx context.Context, address string) (string, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	opts := options.FindOne().SetProjection(bson.M{"staker_pk_hex": 1})
+	var delegation model.DelegationDocument
+	err := client.FindOne(ctx, buildStakerAddressFilter(address), opts).Decode(&delegation)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return "", &NotFoundError{
+				Key:     address,
+				Message: "No delegation found for the staker address",
+			}
+		}
+		return "", err
+	}
+	return delegation.StakerPkHex, nil
+}
+
+// FindStakerPksWithoutSegwitAddresses returns the distinct staker public keys
+// of the delegations saved before the segwit addresses were derived.
+func (db *Database) FindStakerPksWithoutSegwitAddresses(ctx context.Context) ([]string, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	filter := bson.M{"staker_btc_address.native_segwit_even_address": bson.M{"$exists": false}}
+	values, err := client.Distinct(ctx, "staker_pk_hex", filter)
+	if err != nil {
+		return nil, err
+	}
+	stakerPks := make([]string, 0, len(values))
+	for _, v := range values {
+		if pk, ok := v.(string); ok {
+			stakerPks = append(stakerPks, pk)
+		}
+	}
+	return stakerPks, nil
+}
+
+// UpdateStakerBtcAddress overwrites the derived BTC addresses of all the
+// delegations of the given staker.
+func (db *Database) UpdateStakerBtcAddress(
+	ctx context.Context, stakerPkHex string, stakerBtcAddress *model.StakerBtcAddress,
+) error {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	_, err := client.UpdateMany(
+		ctx,
+		bson.M{"staker_pk_hex": stakerPkHex},
+		bson.M{"$set": bson.M{"staker_btc_address": stakerBtcAddress}},
+	)
+	return err
+}
+
+// FindDelegationsByStakerPk fetches the delegations of the staker sorted by the
+// staking start height in the given order, the staking tx hash is used as the
+// secondary sorting key to keep the pagination stable.
+func (db *Database) FindDelegationsByStakerPk(
+	ctx context.Context, stakerPk string, extraFilter *DelegationFilter,
+	sortOrder types.SortOrder, paginationToken string,
+) (*DbResultMap[model.DelegationDocument], error) {
+	filter := buildAdditionalDelegationFilter(bson.M{"staker_pk_hex": stakerPk}, extraFilter)
+	queryHash, err := hashDelegationQuery([]string{stakerPk}, extraFilter, sortOrder)
+	if err != nil {
+		return nil, err
+	}
+	return db.findDelegationsSortedByStartHeight(ctx, filter, queryHash, sortOrder, paginationToken)
+}
+
+// FindDelegationsByStakerPks fetches the delegations of any of the stakers,
+// merged into a single list sorted by the staking start height in the given
+// order, hence paginated with a single keyset cursor.
+func (db *Database) FindDelegationsByStakerPks(
+	ctx context.Context, stakerPks []string, extraFilter *DelegationFilter,
+	sortOrder types.SortOrder, paginationToken string,
+) (*DbResultMap[model.DelegationDocument], error) {
+	filter := buildAdditionalDelegationFilter(bson.M{"staker_pk_hex": bson.M{"$in": stakerPks}}, extraFilter)
+	queryHash, err := hashDelegationQuery(stakerPks, extraFilter, sortOrder)
+	if err != nil {
+		return nil, err
+	}
+	return db.findDelegationsSortedByStartHeight(ctx, filter, queryHash, sortOrder, paginationToken)
+}
+
+// FindDelegationStateTotalsByStakerPks sums up the delegations of the stakers
+// by state, the states without delegations are omitted.
+func (db *Database) FindDelegationStateTotalsByStakerPks(
+	ctx context.Context, stakerPks []string,
+) ([]model.DelegationStateTotal, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"staker_pk_hex": bson.M{"$in": stakerPks}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":           "$state",
+			"delegations":   bson.M{"$sum": 1},
+			"staking_value": bson.M{"$sum": "$staking_value"},
+		}}},
+	}
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	totals := []model.DelegationStateTotal{}
+	if err := cursor.All(ctx, &totals); err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// FindDelegationsByFinalityProviderPk fetches the delegations of the finality
+// provider sorted by the staking start height in descending order.
+func (db *Database) FindDelegationsByFinalityProviderPk(
+	ctx context.Context, fpPkHex string, extraFilter *DelegationFilter, paginationToken string,
+) (*DbResultMap[model.DelegationDocument], error) {
+	filter := buildAdditionalDelegationFilter(bson.M{"finality_provider_pk_hex": fpPkHex}, extraFilter)
+	queryHash, err := hashDelegationQuery([]string{fpPkHex}, extraFilter, types.SortDescending)
+	if err != nil {
+		return nil, err
+	}
+	return db.findDelegationsSortedByStartHeight(ctx, filter, queryHash, types.SortDescending, paginationToken)
+}
+
+// findDelegationsSortedByStartHeight fetches the delegations matching the
+// filter sorted by the staking start height in the given order, the staking tx
+// hash is used as the secondary sorting key to keep the pagination stable.
+// The pagination token is bound to the query hash and rejected for any other query.
+func (db *Database) findDelegationsSortedByStartHeight(
+	ctx context.Context, filter bson.M, queryHash string, sortOrder types.SortOrder, paginationToken string,
+) (*DbResultMap[model.DelegationDocument], error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+
+	heightSort, heightCursorOp := -1, "$lt"
+	if sortOrder == types.SortAscending {
+		heightSort, heightCursorOp = 1, "$gt"
+	}
+	options := options.Find().SetSort(bson.D{
+		{Key: "staking_tx.start_height", Value: heightSort},
+		{Key: "_id", Value: 1},
+	})
 
 	// Decode the pagination token first if it exist
 	if paginationToken != "" {
@@ -90,20 +259,40 @@ func (db *Database) FindDelegationsByStakerPk(ctx context.Context, stakerPk stri
 				Message: "Invalid pagination token",
 			}
 		}
-		filter = bson.M{
-			"$or": []bson.M{
-				{"staker_pk_hex": stakerPk, "staking_tx.start_height": bson.M{"$lt": decodedToken.StakingStartHeight}},
-				{"staker_pk_hex": stakerPk, "staking_tx.start_height": decodedToken.StakingStartHeight, "_id": bson.M{"$gt": decodedToken.StakingTxHashHex}},
-			},
+		if decodedToken.QueryHash != queryHash {
+			return nil, &InvalidPaginationTokenError{
+				Message: "Pagination token does not match the filters or sort order",
+			}
+		}
+		filter["$or"] = []bson.M{
+			{"staking_tx.start_height": bson.M{heightCursorOp: decodedToken.StakingStartHeight}},
+			{"staking_tx.start_height": decodedToken.StakingStartHeight, "_id": bson.M{"$gt": decodedToken.StakingTxHashHex}},
 		}
 	}
 
 	return findWithPagination(
 		ctx, client, filter, options, db.cfg.MaxPaginationLimit,
-		model.BuildDelegationByStakerPaginationToken,
+		model.BuildDelegationByStakerPaginationTokenBuilder(queryHash),
 	)
 }
 
+// hashDelegationQuery fingerprints a paginated delegations query by its base
+// keys, i.e. the staker or finality provider public keys, the filters and the sort order.
+func hashDelegationQuery(
+	baseKeys []string, extraFilter *DelegationFilter, sortOrder types.SortOrder,
+) (string, error) {
+	query, err := json.Marshal(struct {
+		BaseKeys  []string
+		Filter    *DelegationFilter
+		SortOrder types.SortOrder
+	}{baseKeys, extraFilter, sortOrder})
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(query)
+	return hex.EncodeToString(hash[:]), nil
+}
+
 // SaveUnbondingTx saves the unbonding transaction details for a staking transaction
 // It returns an NotFoundError if the staking transaction is not found
 func (db *Database) FindDelegationByTxHashHex(ctx context.Context, stakingTxHashHex string) (*model.DelegationDocument, error) {
@@ -124,40 +313,120 @@ func (db *Database) FindDelegationByTxHashHex(ctx context.Context, stakingTxHash
 }
 
 // TransitionState updates the state of a staking transaction to a new state
-// It returns an NotFoundError if the staking transaction is not found or not in the eligible state to transition
+// and records the transition into the delegation state history.
+// Nothing will be updated nor recorded if the staking transaction is not found
+// or not in the eligible state to transition.
 func (db *Database) transitionState(
-	ctx context.Context, stakingTxHashHex, newState string,
+	ctx context.Context, stakingTxHashHex string, newState types.DelegationState,
 	eligiblePreviousState []types.DelegationState, additionalUpdates map[string]interface{},
+	transition *StateTransitionInfo,
 ) error {
 	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
 	filter := bson.M{"_id": stakingTxHashHex, "state": bson.M{"$in": eligiblePreviousState}}
-	update := bson.M{"$set": bson.M{"state": newState}}
+	update := bson.M{"$set": bson.M{"state": newState.ToString()}}
 	for field, value := range additionalUpdates {
 		// Add additional fields to the $set operation
 		update["$set"].(bson.M)[field] = value
 	}
-	_, err := client.UpdateOne(ctx, filter, update)
-	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return &NotFoundError{
-				Key:     stakingTxHashHex,
-				Message: "Delegation not found or not in eligible state to transition",
+
+	// Start a session
+	session, sessionErr := db.Client.StartSession()
+	if sessionErr != nil {
+		return sessionErr
+	}
+	defer session.EndSession(ctx)
+
+	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		// The document before the update is returned, which holds the previous state
+		var previousDelegation model.DelegationDocument
+		err := client.FindOneAndUpdate(sessCtx, filter, update).Decode(&previousDelegation)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				// Not in the eligible state, hence nothing to record
+				return nil, nil
 			}
+			return nil, err
 		}
-		return err
+
+		err = db.saveDelegationStateHistory(sessCtx, &previousDelegation, model.NewDelegationStateHistoryDocument(
+			stakingTxHashHex, previousDelegation.State, newState,
+			transition.BtcHeight, transition.Timestamp, transition.Source,
+		))
+		if err != nil {
+			return nil, err
+		}
+		return nil, nil
 	}
-	return nil
+
+	// Execute the transaction
+	_, txErr := session.WithTransaction(ctx, transactionWork)
+	return txErr
 }
 
 func buildAdditionalDelegationFilter(
 	baseFilter primitive.M,
 	filters *DelegationFilter,
 ) primitive.M {
+	if filters == nil {
+		return baseFilter
+	}
 	if filters.States != nil {
 		baseFilter["state"] = bson.M{"$in": filters.States}
 	}
 	if filters.AfterTimestamp != 0 {
 		baseFilter["staking_tx.start_timestamp"] = bson.M{"$gte": filters.AfterTimestamp}
 	}
+	if filters.FinalityProviderPkHex != "" {
+		baseFilter["finality_provider_pk_hex"] = filters.FinalityProviderPkHex
+	}
+	if valueFilter := buildRangeFilter(filters.MinStakingValue, filters.MaxStakingValue); valueFilter != nil {
+		baseFilter["staking_value"] = valueFilter
+	}
+	if heightFilter := buildRangeFilter(filters.MinStartHeight, filters.MaxStartHeight); heightFilter != nil {
+		baseFilter["staking_tx.start_height"] = heightFilter
+	}
 	return baseFilter
 }
+
+// buildRangeFilter builds an inclusive range filter, a nil bound is ignored.
+func buildRangeFilter(min, max *uint64) bson.M {
+	if min == nil && max == nil {
+		return nil
+	}
+	rangeFilter := bson.M{}
+	if min != nil {
+		rangeFilter["$gte"] = *min
+	}
+	if max != nil {
+		rangeFilter["$lte"] = *max
+	}
+	return rangeFilter
+}
+
+func buildStakerAddressFilter(address string) bson.M {
+	conditions := make(bson.A, 0, len(model.StakerBtcAddressFields))
+	for _, field := range model.StakerBtcAddressFields {
+		conditions = append(conditions, bson.M{field: address})
+	}
+	return bson.M{"$or": conditions}
+}
+
+// FindDelegationsByTxHashHexes returns the delegations found among the staking
+// tx hashes, in no particular order. The unknown tx hashes are skipped.
+func (db *Database) FindDelegationsByTxHashHexes(
+	ctx context.Context, stakingTxHashHexes []string,
+) ([]model.DelegationDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	filter := bson.M{"_id": bson.M{"$in": stakingTxHashHexes}}
+	cursor, err := client.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	delegations := []model.DelegationDocument{}
+	if err := cursor.All(ctx, &delegations); err != nil {
+		return nil, err
+	}
+	return delegations, nil
+}
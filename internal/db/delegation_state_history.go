@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/utils"
+)
+
+// FindDelegationStateHistory returns all the recorded state transitions of
+// the delegation sorted in the order they happened.
+func (db *Database) FindDelegationStateHistory(
+	ctx context.Context, stakingTxHashHex string,
+) ([]model.DelegationStateHistoryDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationStateHistoryCollection)
+	filter := bson.M{"staking_tx_hash_hex": stakingTxHashHex}
+	cursor, err := client.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	history := make([]model.DelegationStateHistoryDocument, 0)
+	if err = cursor.All(ctx, &history); err != nil {
+		return nil, err
+	}
+	// The recorded btc height and timestamp are not guaranteed to be monotonic
+	// as they come from different sources, hence sort by the lifecycle instead.
+	sort.Slice(history, func(i, j int) bool {
+		return utils.DelegationStateOrder(history[i].NewState) < utils.DelegationStateOrder(history[j].NewState)
+	})
+	return history, nil
+}
+
+// saveDelegationStateHistory records the state transition and queues its
+// webhook deliveries. It shall be called within the same transaction as the
+// state change of the delegation document.
+func (db *Database) saveDelegationStateHistory(
+	ctx context.Context, delegation *model.DelegationDocument,
+	history *model.DelegationStateHistoryDocument,
+) error {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationStateHistoryCollection)
+	if _, err := client.InsertOne(ctx, history); err != nil {
+		return err
+	}
+	return db.enqueueWebhookDeliveries(ctx, delegation, history)
+}
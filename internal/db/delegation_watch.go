@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+)
+
+// changeStreamHistoryLostErrorCode is returned when the resume token is no
+// longer in the oplog
+const changeStreamHistoryLostErrorCode = 286
+
+type delegationChangeEvent struct {
+	FullDocument *model.DelegationDocument `bson:"fullDocument"`
+}
+
+// WatchDelegations opens a change stream on the delegation collection and
+// calls onChange with the latest version of each inserted or updated
+// delegation. As the changes are read from the database, changes made by any
+// service replica are observed. It blocks until the context is cancelled or
+// the change stream fails.
+// The change stream resumes after the given resume token if any, and the
+// resume token of the last observed change is returned to reopen it without
+// missing changes.
+func (db *Database) WatchDelegations(
+	ctx context.Context, resumeToken bson.Raw, onChange func(delegation *model.DelegationDocument),
+) (bson.Raw, error) {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"operationType": bson.M{"$in": bson.A{"insert", "update", "replace"}},
+		}}},
+	}
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if resumeToken != nil {
+		opts.SetResumeAfter(resumeToken)
+	}
+
+	stream, err := client.Watch(ctx, pipeline, opts)
+	var serverErr mongo.ServerError
+	if resumeToken != nil && errors.As(err, &serverErr) &&
+		serverErr.HasErrorCode(changeStreamHistoryLostErrorCode) {
+		// The missed changes can't be recovered, start over from the latest change
+		log.Ctx(ctx).Warn().Err(err).Msg("delegation change stream history lost, restarting from now")
+		stream, err = client.Watch(ctx, pipeline, opts.SetResumeAfter(nil))
+	}
+	if err != nil {
+		return resumeToken, err
+	}
+	defer stream.Close(context.Background())
+
+	for stream.Next(ctx) {
+		var event delegationChangeEvent
+		if err := stream.Decode(&event); err != nil {
+			return stream.ResumeToken(), err
+		}
+		// The full document is missing if the delegation has been deleted
+		// before the update lookup
+		if event.FullDocument != nil {
+			onChange(event.FullDocument)
+		}
+	}
+	return stream.ResumeToken(), stream.Err()
+}
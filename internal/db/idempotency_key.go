@@ -0,0 +1,93 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+)
+
+// SaveIdempotencyKey returns a DuplicateKeyError if the key has already been
+// used and has not expired yet
+func (db *Database) SaveIdempotencyKey(ctx context.Context, idempotencyKey *model.IdempotencyKeyDocument) error {
+	client := db.Client.Database(db.DbName).Collection(model.IdempotencyKeyCollection)
+	_, err := client.InsertOne(ctx, idempotencyKey)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return &DuplicateKeyError{
+				Key:     idempotencyKey.Id,
+				Message: "idempotency key already exists",
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// FindIdempotencyKey returns a NotFoundError if the key does not exist
+func (db *Database) FindIdempotencyKey(ctx context.Context, id string) (*model.IdempotencyKeyDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.IdempotencyKeyCollection)
+	var idempotencyKey model.IdempotencyKeyDocument
+	err := client.FindOne(ctx, bson.M{"_id": id}).Decode(&idempotencyKey)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &NotFoundError{
+				Key:     id,
+				Message: "idempotency key not found",
+			}
+		}
+		return nil, err
+	}
+	return &idempotencyKey, nil
+}
+
+// TakeOverIdempotencyKey locks the key until lockedUntil if the processing of
+// the same request has been abandoned, i.e. it has not completed and its lock
+// has expired. It returns false if the key could not be taken over.
+func (db *Database) TakeOverIdempotencyKey(
+	ctx context.Context, id, requestHash string, lockedUntil time.Time,
+) (bool, error) {
+	client := db.Client.Database(db.DbName).Collection(model.IdempotencyKeyCollection)
+	filter := bson.M{
+		"_id":          id,
+		"request_hash": requestHash,
+		"completed":    false,
+		"locked_until": bson.M{"$lt": time.Now()},
+	}
+	update := bson.M{"$set": bson.M{"locked_until": lockedUntil}}
+	result, err := client.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// CompleteIdempotencyKey records the outcome of the request submitted with
+// the key, the error code and message are empty if it succeeded. The key is
+// left as is if it is no longer locked until lockedUntil, i.e. it has been
+// taken over.
+func (db *Database) CompleteIdempotencyKey(
+	ctx context.Context, id string, lockedUntil time.Time, statusCode int, errorCode, errorMessage string,
+) error {
+	client := db.Client.Database(db.DbName).Collection(model.IdempotencyKeyCollection)
+	update := bson.M{"$set": bson.M{
+		"completed":     true,
+		"status_code":   statusCode,
+		"error_code":    errorCode,
+		"error_message": errorMessage,
+	}}
+	_, err := client.UpdateOne(ctx, bson.M{"_id": id, "locked_until": lockedUntil}, update)
+	return err
+}
+
+// DeleteIdempotencyKey releases the key so that the request can be processed
+// again. The key is left as is if it is no longer locked until lockedUntil.
+func (db *Database) DeleteIdempotencyKey(ctx context.Context, id string, lockedUntil time.Time) error {
+	client := db.Client.Database(db.DbName).Collection(model.IdempotencyKeyCollection)
+	_, err := client.DeleteOne(ctx, bson.M{"_id": id, "locked_until": lockedUntil})
+	return err
+}
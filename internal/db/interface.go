@@ -2,6 +2,10 @@ package db
 
 import (
 	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/babylonchain/staking-api-service/internal/db/model"
 	"github.com/babylonchain/staking-api-service/internal/types"
@@ -12,26 +16,57 @@ type DBClient interface {
 	SaveActiveStakingDelegation(
 		ctx context.Context, stakingTxHashHex, stakerPkHex, fpPkHex string,
 		stakingTxHex string, amount, startHeight, timelock, outputIndex uint64,
-		startTimestamp int64, isOverflow bool, stakerTaprootAddress string,
+		startTimestamp int64, isOverflow bool, stakerBtcAddress *model.StakerBtcAddress,
 	) error
 	FindDelegationsByStakerPk(
-		ctx context.Context, stakerPk string, paginationToken string,
+		ctx context.Context, stakerPk string, extraFilter *DelegationFilter,
+		sortOrder types.SortOrder, paginationToken string,
+	) (*DbResultMap[model.DelegationDocument], error)
+	// FindDelegationsByStakerPks fetches the delegations of any of the
+	// stakers, merged and paginated with a single keyset cursor
+	FindDelegationsByStakerPks(
+		ctx context.Context, stakerPks []string, extraFilter *DelegationFilter,
+		sortOrder types.SortOrder, paginationToken string,
 	) (*DbResultMap[model.DelegationDocument], error)
+	// FindDelegationStateTotalsByStakerPks sums up the delegations of the
+	// stakers by state
+	FindDelegationStateTotalsByStakerPks(ctx context.Context, stakerPks []string) ([]model.DelegationStateTotal, error)
 	SaveUnbondingTx(
 		ctx context.Context, stakingTxHashHex, unbondingTxHashHex, txHex, signatureHex string,
+		transition *StateTransitionInfo,
 	) error
 	FindDelegationByTxHashHex(ctx context.Context, txHashHex string) (*model.DelegationDocument, error)
+	// FindDelegationsByTxHashHexes returns the delegations found among the
+	// staking tx hashes, the unknown tx hashes are skipped
+	FindDelegationsByTxHashHexes(ctx context.Context, txHashHexes []string) ([]model.DelegationDocument, error)
 	SaveTimeLockExpireCheck(ctx context.Context, stakingTxHashHex string, expireHeight uint64, txType string) error
-	SaveUnprocessableMessage(ctx context.Context, messageBody, receipt string) error
+	FindTimeLockExpirationBuckets(
+		ctx context.Context, fromHeight, toHeight, bucketBlocks uint64,
+	) ([]model.TimeLockExpirationBucket, error)
+	SaveUnprocessableMessage(ctx context.Context, document *model.UnprocessableMessageDocument) error
 	FindUnprocessableMessages(ctx context.Context) ([]model.UnprocessableMessageDocument, error)
 	DeleteUnprocessableMessage(ctx context.Context, Receipt interface{}) error
 	TransitionToUnbondedState(
 		ctx context.Context, stakingTxHashHex string, eligiblePreviousState []types.DelegationState,
+		transition *StateTransitionInfo,
+	) error
+	// FindUnbondingTxByHashHex returns a NotFoundError if the unbonding tx
+	// has not been requested through the service
+	FindUnbondingTxByHashHex(ctx context.Context, unbondingTxHashHex string) (*model.UnbondingDocument, error)
+	FindUnbondingTxByStakingTxHashHex(ctx context.Context, stakingTxHashHex string) (*model.UnbondingDocument, error)
+	// TransitionUnbondingState returns a NotFoundError if the unbonding
+	// request is not in any of the eligible previous states
+	TransitionUnbondingState(
+		ctx context.Context, stakingTxHashHex string, eligiblePreviousStates []string,
+		transition *model.UnbondingStateTransition,
 	) error
 	TransitionToUnbondingState(
 		ctx context.Context, txHashHex string, startHeight, timelock, outputIndex uint64, txHex string, startTimestamp int64,
 	) error
-	TransitionToWithdrawnState(ctx context.Context, txHashHex string) error
+	TransitionToWithdrawnState(ctx context.Context, txHashHex string, transition *StateTransitionInfo) error
+	FindDelegationStateHistory(
+		ctx context.Context, stakingTxHashHex string,
+	) ([]model.DelegationStateHistoryDocument, error)
 	GetOrCreateStatsLock(
 		ctx context.Context, stakingTxHashHex string, state string,
 	) (*model.StatsLockDocument, error)
@@ -43,15 +78,16 @@ type DBClient interface {
 	) error
 	GetOverallStats(ctx context.Context) (*model.OverallStatsDocument, error)
 	IncrementFinalityProviderStats(
-		ctx context.Context, stakingTxHashHex, fpPkHex string, amount uint64,
+		ctx context.Context, stakingTxHashHex, fpPkHex, stakerPkHex string, amount uint64,
 	) error
 	SubtractFinalityProviderStats(
-		ctx context.Context, stakingTxHashHex, fpPkHex string, amount uint64,
+		ctx context.Context, stakingTxHashHex, fpPkHex, stakerPkHex string, amount uint64,
 	) error
 	FindFinalityProviderStats(ctx context.Context, paginationToken string) (*DbResultMap[*model.FinalityProviderStatsDocument], error)
 	FindFinalityProviderStatsByFinalityProviderPkHex(
 		ctx context.Context, finalityProviderPkHex []string,
 	) ([]*model.FinalityProviderStatsDocument, error)
+	BackfillFinalityProviderStakerStats(ctx context.Context) error
 	IncrementStakerStats(
 		ctx context.Context, stakingTxHashHex, stakerPkHex string, amount uint64,
 	) error
@@ -59,16 +95,122 @@ type DBClient interface {
 		ctx context.Context, stakingTxHashHex, stakerPkHex string, amount uint64,
 	) error
 	FindTopStakersByTvl(ctx context.Context, paginationToken string) (*DbResultMap[*model.StakerStatsDocument], error)
+	// FindStakerStatsByStakerPks returns the stats of the stakers, the
+	// stakers without any delegation yet are omitted
+	FindStakerStatsByStakerPks(ctx context.Context, stakerPks []string) ([]model.StakerStatsDocument, error)
+	// FindStakerStatsByStakerPk returns a NotFoundError if the staker has no
+	// delegation yet
+	FindStakerStatsByStakerPk(ctx context.Context, stakerPk string) (*model.StakerStatsDocument, error)
+	CountStakersWithHigherActiveTvl(ctx context.Context, activeTvl int64) (int64, error)
 	UpsertLatestBtcInfo(
 		ctx context.Context, height uint64, confirmedTvl uint64, unconfirmedTvl uint64,
 	) error
 	GetLatestBtcInfo(ctx context.Context) (*model.BtcInfo, error)
-	CheckDelegationExistByStakerTaprootAddress(
+	SaveStatsSnapshot(ctx context.Context, snapshot *model.OverallStatsSnapshotDocument) error
+	FindOverallStatsSnapshots(
+		ctx context.Context, from, to, interval int64,
+	) ([]model.OverallStatsSnapshotDocument, error)
+	FindFinalityProviderStatsSnapshots(
+		ctx context.Context, fpPkHex string, from, to, interval int64,
+	) ([]model.FinalityProviderStatsSnapshotDocument, error)
+	FindStakerStatsSnapshots(
+		ctx context.Context, stakerPkHex string, from, to, interval int64,
+	) ([]model.StakerStatsSnapshotDocument, error)
+	CheckDelegationExistByStakerAddress(
 		ctx context.Context, address string, extraFilter *DelegationFilter,
 	) (bool, error)
+	CheckDelegationExistByStakerPk(ctx context.Context, stakerPk string) (bool, error)
+	FindDelegationsByFinalityProviderPk(
+		ctx context.Context, fpPkHex string, extraFilter *DelegationFilter, paginationToken string,
+	) (*DbResultMap[model.DelegationDocument], error)
+	FindStakerPkByAddress(ctx context.Context, address string) (string, error)
+	FindStakerPksWithoutSegwitAddresses(ctx context.Context) ([]string, error)
+	UpdateStakerBtcAddress(
+		ctx context.Context, stakerPkHex string, stakerBtcAddress *model.StakerBtcAddress,
+	) error
+	WatchDelegations(
+		ctx context.Context, resumeToken bson.Raw, onChange func(delegation *model.DelegationDocument),
+	) (bson.Raw, error)
+	SaveWebhookSubscription(ctx context.Context, subscription *model.WebhookSubscriptionDocument) error
+	FindWebhookSubscriptions(ctx context.Context) ([]model.WebhookSubscriptionDocument, error)
+	FindWebhookSubscriptionById(ctx context.Context, id string) (*model.WebhookSubscriptionDocument, error)
+	DeleteWebhookSubscription(ctx context.Context, id string) error
+	FindWebhookDeliveriesBySubscriptionId(
+		ctx context.Context, subscriptionId, paginationToken string,
+	) (*DbResultMap[model.WebhookDeliveryDocument], error)
+	ClaimDueWebhookDelivery(ctx context.Context, now, leaseUntil int64) (*model.WebhookDeliveryDocument, error)
+	RecordWebhookDeliveryAttempt(
+		ctx context.Context, id primitive.ObjectID, attempt *model.WebhookDeliveryAttempt,
+		state types.WebhookDeliveryState, nextAttemptAt int64,
+	) error
+	FindUnprocessableMessagesByFilter(
+		ctx context.Context, filter *UnprocessableMessageFilter,
+	) ([]model.UnprocessableMessageDocument, error)
+	FindUnprocessableMessagesWithPagination(
+		ctx context.Context, filter *UnprocessableMessageFilter, paginationToken string,
+	) (*DbResultMap[model.UnprocessableMessageDocument], error)
+	FindUnprocessableMessageById(ctx context.Context, id primitive.ObjectID) (*model.UnprocessableMessageDocument, error)
+	DeleteUnprocessableMessageWithAudit(
+		ctx context.Context, id primitive.ObjectID, audit *model.UnprocessableMessageAuditDocument,
+	) error
+	SaveUnprocessableMessageAudit(ctx context.Context, audit *model.UnprocessableMessageAuditDocument) error
+	// SaveUnprocessableMessageWithAttempts moves the recorded failed attempts
+	// of the message into its attempt history
+	SaveUnprocessableMessageWithAttempts(
+		ctx context.Context, messageKey string, document *model.UnprocessableMessageDocument,
+	) error
+	RecordMessageProcessingAttempt(
+		ctx context.Context, messageKey string, attempt *model.MessageProcessingAttempt, expiresAt time.Time,
+	) error
+	PopMessageProcessingAttempts(ctx context.Context, messageKey string) ([]model.MessageProcessingAttempt, error)
+	TakeRateLimitToken(
+		ctx context.Context, key string, requestsPerSecond float64, burst int, now time.Time,
+	) (*model.RateLimitBucketDocument, error)
+	SaveApiKey(ctx context.Context, apiKey *model.ApiKeyDocument) error
+	FindApiKeyByHash(ctx context.Context, keyHash string) (*model.ApiKeyDocument, error)
+	RevokeApiKey(ctx context.Context, id string, revokedAt int64) error
+	// FindResponseCacheEntry returns a NotFoundError if the response is not
+	// cached or has expired
+	FindResponseCacheEntry(ctx context.Context, key string, now time.Time) (*model.ResponseCacheDocument, error)
+	SaveResponseCacheEntry(ctx context.Context, entry *model.ResponseCacheDocument) error
+	// DeleteResponseCacheGroup invalidates all the cached responses of the group
+	DeleteResponseCacheGroup(ctx context.Context, group string) error
+	// SaveIdempotencyKey returns a DuplicateKeyError if the key is in use
+	SaveIdempotencyKey(ctx context.Context, idempotencyKey *model.IdempotencyKeyDocument) error
+	// FindIdempotencyKey returns a NotFoundError if the key does not exist
+	FindIdempotencyKey(ctx context.Context, id string) (*model.IdempotencyKeyDocument, error)
+	// TakeOverIdempotencyKey returns false if the key is not abandoned
+	TakeOverIdempotencyKey(ctx context.Context, id, requestHash string, lockedUntil time.Time) (bool, error)
+	CompleteIdempotencyKey(
+		ctx context.Context, id string, lockedUntil time.Time, statusCode int, errorCode, errorMessage string,
+	) error
+	DeleteIdempotencyKey(ctx context.Context, id string, lockedUntil time.Time) error
 }
 
+// DelegationFilter narrows down the delegations to be queried.
+// Zero values and nil bounds are ignored.
 type DelegationFilter struct {
-	AfterTimestamp int64
-	States         []types.DelegationState
+	AfterTimestamp        int64
+	States                []types.DelegationState
+	FinalityProviderPkHex string
+	MinStakingValue       *uint64
+	MaxStakingValue       *uint64
+	MinStartHeight        *uint64
+	MaxStartHeight        *uint64
+}
+
+// UnprocessableMessageFilter narrows down the unprocessable messages to be
+// queried. Zero values are ignored.
+type UnprocessableMessageFilter struct {
+	QueueName    string
+	FailedAfter  int64
+	FailedBefore int64
+}
+
+// StateTransitionInfo describes when and why a delegation state transition
+// happened, it's recorded into the delegation state history.
+type StateTransitionInfo struct {
+	BtcHeight uint64
+	Timestamp int64
+	Source    types.DelegationStateSource
 }
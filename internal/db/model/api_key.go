@@ -0,0 +1,17 @@
+package model
+
+// ApiKeyDocument is an issued api key. Only the hash of the key is stored,
+// the key itself is returned once on issuance.
+type ApiKeyDocument struct {
+	Id      string `bson:"_id"`
+	KeyHash string `bson:"key_hash"`
+	Owner   string `bson:"owner"`
+	Tier    string `bson:"tier"`
+	// The paths the key is allowed to call, a trailing "*" matches any path
+	// with the prefix. The key is allowed to call any path if empty.
+	AllowedRoutes []string `bson:"allowed_routes"`
+	// Unix timestamps, zero if the key never expires or is not revoked
+	ExpiresAt int64 `bson:"expires_at"`
+	RevokedAt int64 `bson:"revoked_at"`
+	CreatedAt int64 `bson:"created_at"`
+}
@@ -1,7 +1,10 @@
 package model
 
 import (
+	"github.com/btcsuite/btcd/chaincfg"
+
 	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
 )
 
 type TimelockTransaction struct {
@@ -12,9 +15,40 @@ type TimelockTransaction struct {
 	TimeLock       uint64 `bson:"timelock"`
 }
 
-// The available addresses that can be derived from the given StakerPkHex
+// The available addresses that can be derived from the given StakerPkHex.
+// The segwit addresses are derived from both the even and odd compressed keys
+// as the StakerPkHex is in x-only format.
 type StakerBtcAddress struct {
-	TaprootAddress string `bson:"taproot_address"`
+	TaprootAddress          string `bson:"taproot_address"`
+	NativeSegwitEvenAddress string `bson:"native_segwit_even_address,omitempty"`
+	NativeSegwitOddAddress  string `bson:"native_segwit_odd_address,omitempty"`
+	NestedSegwitEvenAddress string `bson:"nested_segwit_even_address,omitempty"`
+	NestedSegwitOddAddress  string `bson:"nested_segwit_odd_address,omitempty"`
+}
+
+// NewStakerBtcAddress derives all the standard address forms of the staker
+func NewStakerBtcAddress(stakerPkHex string, netParams *chaincfg.Params) (*StakerBtcAddress, error) {
+	addresses, err := utils.DeriveBtcAddressesFromPk(stakerPkHex, netParams)
+	if err != nil {
+		return nil, err
+	}
+	return &StakerBtcAddress{
+		TaprootAddress:          addresses.Taproot,
+		NativeSegwitEvenAddress: addresses.NativeSegwitEven,
+		NativeSegwitOddAddress:  addresses.NativeSegwitOdd,
+		NestedSegwitEvenAddress: addresses.NestedSegwitEven,
+		NestedSegwitOddAddress:  addresses.NestedSegwitOdd,
+	}, nil
+}
+
+// StakerBtcAddressFields lists the delegation document fields of all the
+// address forms, a staker can be looked up by any of them.
+var StakerBtcAddressFields = []string{
+	"staker_btc_address.taproot_address",
+	"staker_btc_address.native_segwit_even_address",
+	"staker_btc_address.native_segwit_odd_address",
+	"staker_btc_address.nested_segwit_even_address",
+	"staker_btc_address.nested_segwit_odd_address",
 }
 
 type DelegationDocument struct {
@@ -29,19 +63,34 @@ type DelegationDocument struct {
 	StakerBtcAddress      *StakerBtcAddress     `bson:"staker_btc_address,omitempty"`
 }
 
+// DelegationStateTotal is the number of delegations in a state along with
+// their staking value
+type DelegationStateTotal struct {
+	State        types.DelegationState `bson:"_id"`
+	Delegations  int64                 `bson:"delegations"`
+	StakingValue uint64                `bson:"staking_value"`
+}
+
 type DelegationByStakerPagination struct {
 	StakingTxHashHex   string `json:"staking_tx_hash_hex"`
 	StakingStartHeight uint64 `json:"staking_start_height"`
+	// Hash of the query the token was issued for, i.e. the filters and sort order
+	QueryHash string `json:"query_hash"`
 }
 
-func BuildDelegationByStakerPaginationToken(d DelegationDocument) (string, error) {
-	page := &DelegationByStakerPagination{
-		StakingTxHashHex:   d.StakingTxHashHex,
-		StakingStartHeight: d.StakingTx.StartHeight,
-	}
-	token, err := GetPaginationToken(page)
-	if err != nil {
-		return "", err
+// BuildDelegationByStakerPaginationTokenBuilder returns the pagination token
+// builder of the delegations bound to the given query hash.
+func BuildDelegationByStakerPaginationTokenBuilder(queryHash string) func(DelegationDocument) (string, error) {
+	return func(d DelegationDocument) (string, error) {
+		page := &DelegationByStakerPagination{
+			StakingTxHashHex:   d.StakingTxHashHex,
+			StakingStartHeight: d.StakingTx.StartHeight,
+			QueryHash:          queryHash,
+		}
+		token, err := GetPaginationToken(page)
+		if err != nil {
+			return "", err
+		}
+		return token, nil
 	}
-	return token, nil
 }
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+// DelegationStateHistoryDocument records a single state transition of a delegation.
+// A delegation never goes back to a previous state, hence the _id is in the
+// format of {{stakingTxHashHex}}:{{newState}} which also prevents duplicated records.
+type DelegationStateHistoryDocument struct {
+	Id               string                      `bson:"_id"`
+	StakingTxHashHex string                      `bson:"staking_tx_hash_hex"`
+	PreviousState    types.DelegationState       `bson:"previous_state,omitempty"` // Empty for the initial active state
+	NewState         types.DelegationState       `bson:"new_state"`
+	BtcHeight        uint64                      `bson:"btc_height"`
+	Timestamp        int64                       `bson:"timestamp"`
+	Source           types.DelegationStateSource `bson:"source"`
+}
+
+func NewDelegationStateHistoryDocument(
+	stakingTxHashHex string, previousState, newState types.DelegationState,
+	btcHeight uint64, timestamp int64, source types.DelegationStateSource,
+) *DelegationStateHistoryDocument {
+	return &DelegationStateHistoryDocument{
+		Id:               stakingTxHashHex + ":" + newState.ToString(),
+		StakingTxHashHex: stakingTxHashHex,
+		PreviousState:    previousState,
+		NewState:         newState,
+		BtcHeight:        btcHeight,
+		Timestamp:        timestamp,
+		Source:           source,
+	}
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// IdempotencyKeyDocument records the outcome of a request submitted with an
+// idempotency key, the _id is in the format of {{scope}}:{{caller}}:{{key}}.
+// The outcome is only set once the request has been processed, until then the
+// request processing holds the key up to LockedUntil.
+type IdempotencyKeyDocument struct {
+	Id string `bson:"_id"`
+	// Hash of the request payload, a key can not be reused for another payload
+	RequestHash string `bson:"request_hash"`
+	Completed   bool   `bson:"completed"`
+	// Once passed, the processing is deemed abandoned and a retry can take over
+	LockedUntil time.Time `bson:"locked_until"`
+	// Only set if the request failed
+	StatusCode   int       `bson:"status_code,omitempty"`
+	ErrorCode    string    `bson:"error_code,omitempty"`
+	ErrorMessage string    `bson:"error_message,omitempty"`
+	ExpiresAt    time.Time `bson:"expires_at"`
+}
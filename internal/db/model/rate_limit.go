@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// RateLimitBucketDocument is the token bucket of a client within a route
+// group. The bucket expires once it would have been refilled, as a full
+// bucket is equivalent to a missing one.
+type RateLimitBucketDocument struct {
+	Key    string  `bson:"_id"`
+	Tokens float64 `bson:"tokens"`
+	// Unix timestamp in seconds, with a sub-second precision
+	UpdatedAt float64   `bson:"updated_at"`
+	Allowed   bool      `bson:"allowed"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
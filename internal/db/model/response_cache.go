@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// ResponseCacheDocument is a cached response of a read heavy endpoint. The
+// responses of a group are invalidated together when their data is written.
+type ResponseCacheDocument struct {
+	Key       string    `bson:"_id"`
+	Group     string    `bson:"group"`
+	Value     []byte    `bson:"value"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
@@ -14,20 +14,36 @@ import (
 )
 
 const (
-	StatsLockCollection             = "stats_lock"
-	OverallStatsCollection          = "overall_stats"
-	FinalityProviderStatsCollection = "finality_providers_stats"
-	StakerStatsCollection           = "staker_stats"
-	DelegationCollection            = "delegations"
-	TimeLockCollection              = "timelock_queue"
-	UnbondingCollection             = "unbonding_queue"
-	BtcInfoCollection               = "btc_info"
-	UnprocessableMsgCollection      = "unprocessable_messages"
+	StatsLockCollection                     = "stats_lock"
+	OverallStatsCollection                  = "overall_stats"
+	FinalityProviderStatsCollection         = "finality_providers_stats"
+	StakerStatsCollection                   = "staker_stats"
+	DelegationCollection                    = "delegations"
+	TimeLockCollection                      = "timelock_queue"
+	UnbondingCollection                     = "unbonding_queue"
+	BtcInfoCollection                       = "btc_info"
+	UnprocessableMsgCollection              = "unprocessable_messages"
+	OverallStatsSnapshotCollection          = "overall_stats_snapshots"
+	FinalityProviderStatsSnapshotCollection = "finality_providers_stats_snapshots"
+	StakerStatsSnapshotCollection           = "staker_stats_snapshots"
+	DelegationStateHistoryCollection        = "delegation_state_history"
+	FinalityProviderStakerStatsCollection   = "finality_provider_staker_stats"
+	WebhookSubscriptionCollection           = "webhook_subscriptions"
+	WebhookDeliveryCollection               = "webhook_deliveries"
+	UnprocessableMsgAuditCollection         = "unprocessable_messages_audit"
+	MessageProcessingAttemptsCollection     = "message_processing_attempts"
+	RateLimitBucketCollection               = "rate_limit_buckets"
+	ApiKeyCollection                        = "api_keys"
+	ResponseCacheCollection                 = "response_cache"
+	IdempotencyKeyCollection                = "idempotency_keys"
 )
 
 type index struct {
 	Indexes map[string]int
 	Unique  bool
+	// The documents expire this number of seconds after the date of the
+	// indexed field, if set
+	ExpireAfterSeconds *int32
 }
 
 var collections = map[string][]index{
@@ -37,14 +53,59 @@ var collections = map[string][]index{
 	StakerStatsCollection:           {{Indexes: map[string]int{"active_tvl": -1}, Unique: false}},
 	DelegationCollection: {
 		{Indexes: map[string]int{"staker_pk_hex": 1, "staking_tx.start_height": -1}, Unique: false},
+		{Indexes: map[string]int{"finality_provider_pk_hex": 1, "staking_tx.start_height": -1}, Unique: false},
 		{Indexes: map[string]int{"staker_btc_address.taproot_address": 1, "staking_tx.start_timestamp": -1}, Unique: false},
+		{Indexes: map[string]int{"staker_btc_address.native_segwit_even_address": 1, "staking_tx.start_timestamp": -1}, Unique: false},
+		{Indexes: map[string]int{"staker_btc_address.native_segwit_odd_address": 1, "staking_tx.start_timestamp": -1}, Unique: false},
+		{Indexes: map[string]int{"staker_btc_address.nested_segwit_even_address": 1, "staking_tx.start_timestamp": -1}, Unique: false},
+		{Indexes: map[string]int{"staker_btc_address.nested_segwit_odd_address": 1, "staking_tx.start_timestamp": -1}, Unique: false},
 	},
-	TimeLockCollection:         {{Indexes: map[string]int{"expire_height": 1}, Unique: false}},
-	UnbondingCollection:        {{Indexes: map[string]int{"unbonding_tx_hash_hex": 1}, Unique: true}},
-	UnprocessableMsgCollection: {{Indexes: map[string]int{}}},
+	TimeLockCollection: {{Indexes: map[string]int{"expire_height": 1}, Unique: false}},
+	UnbondingCollection: {
+		{Indexes: map[string]int{"unbonding_tx_hash_hex": 1}, Unique: true},
+		{Indexes: map[string]int{UnbondingStakingTxHashHexField: 1}, Unique: false},
+	},
+	UnprocessableMsgCollection: {{Indexes: map[string]int{"queue_name": 1}, Unique: false}},
 	BtcInfoCollection:          {{Indexes: map[string]int{}}},
+	OverallStatsSnapshotCollection: {
+		{Indexes: map[string]int{"timestamp": 1}, Unique: false},
+		{Indexes: map[string]int{"expires_at": 1}, Unique: false, ExpireAfterSeconds: &expireAtIndexedDate},
+	},
+	FinalityProviderStatsSnapshotCollection: {
+		{Indexes: map[string]int{"finality_provider_pk_hex": 1, "timestamp": 1}, Unique: false},
+		{Indexes: map[string]int{"expires_at": 1}, Unique: false, ExpireAfterSeconds: &expireAtIndexedDate},
+	},
+	StakerStatsSnapshotCollection: {
+		{Indexes: map[string]int{"staker_pk_hex": 1, "timestamp": 1}, Unique: false},
+		{Indexes: map[string]int{"expires_at": 1}, Unique: false, ExpireAfterSeconds: &expireAtIndexedDate},
+	},
+	DelegationStateHistoryCollection:      {{Indexes: map[string]int{"staking_tx_hash_hex": 1}, Unique: false}},
+	FinalityProviderStakerStatsCollection: {{Indexes: map[string]int{}}},
+	WebhookSubscriptionCollection:         {{Indexes: map[string]int{}}},
+	WebhookDeliveryCollection: {
+		{Indexes: map[string]int{"next_attempt_at": 1}, Unique: false},
+		{Indexes: map[string]int{"subscription_id": 1}, Unique: false},
+	},
+	UnprocessableMsgAuditCollection: {{Indexes: map[string]int{"message_id": 1}, Unique: false}},
+	MessageProcessingAttemptsCollection: {
+		{Indexes: map[string]int{"expires_at": 1}, Unique: false, ExpireAfterSeconds: &expireAtIndexedDate},
+	},
+	RateLimitBucketCollection: {
+		{Indexes: map[string]int{"expires_at": 1}, Unique: false, ExpireAfterSeconds: &expireAtIndexedDate},
+	},
+	ApiKeyCollection: {{Indexes: map[string]int{"key_hash": 1}, Unique: true}},
+	ResponseCacheCollection: {
+		{Indexes: map[string]int{"group": 1}, Unique: false},
+		{Indexes: map[string]int{"expires_at": 1}, Unique: false, ExpireAfterSeconds: &expireAtIndexedDate},
+	},
+	IdempotencyKeyCollection: {
+		{Indexes: map[string]int{"expires_at": 1}, Unique: false, ExpireAfterSeconds: &expireAtIndexedDate},
+	},
 }
 
+// expireAtIndexedDate expires the documents at the date of the indexed field
+var expireAtIndexedDate int32 = 0
+
 func Setup(ctx context.Context, cfg *config.Config) error {
 	credential := options.Credential{
 		Username: cfg.Db.Username,
@@ -104,9 +165,13 @@ func createIndex(ctx context.Context, database *mongo.Database, collectionName s
 		indexKeys = append(indexKeys, bson.E{Key: k, Value: v})
 	}
 
+	indexOptions := options.Index().SetUnique(idx.Unique)
+	if idx.ExpireAfterSeconds != nil {
+		indexOptions.SetExpireAfterSeconds(*idx.ExpireAfterSeconds)
+	}
 	index := mongo.IndexModel{
 		Keys:    indexKeys,
-		Options: options.Index().SetUnique(idx.Unique),
+		Options: indexOptions,
 	}
 
 	if _, err := database.Collection(collectionName).Indexes().CreateOne(ctx, index); err != nil {
@@ -37,6 +37,17 @@ type FinalityProviderStatsDocument struct {
 	TotalTvl              int64  `bson:"total_tvl"`
 	ActiveDelegations     int64  `bson:"active_delegations"`
 	TotalDelegations      int64  `bson:"total_delegations"`
+	ActiveStakers         int64  `bson:"active_stakers"`
+	TotalStakers          int64  `bson:"total_stakers"`
+}
+
+// FinalityProviderStakerStatsDocument tracks the delegations of a staker to a
+// finality provider, it's used to determine the unique stakers of the provider.
+// The _id is in the format of {{fpPkHex}}:{{stakerPkHex}}
+type FinalityProviderStakerStatsDocument struct {
+	Id                string `bson:"_id"`
+	ActiveDelegations int64  `bson:"active_delegations"`
+	TotalDelegations  int64  `bson:"total_delegations"`
 }
 
 type FinalityProviderStatsPagination struct {
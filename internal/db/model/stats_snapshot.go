@@ -0,0 +1,49 @@
+package model
+
+import "time"
+
+// OverallStatsSnapshotDocument is a point-in-time copy of the summed overall
+// stats shards together with the btc info at the time the snapshot was taken.
+// The _id is in the format of {{btcHeight}}:{{timestamp}}
+type OverallStatsSnapshotDocument struct {
+	Id                string `bson:"_id"`
+	BtcHeight         uint64 `bson:"btc_height"`
+	Timestamp         int64  `bson:"timestamp"`
+	ActiveTvl         int64  `bson:"active_tvl"`
+	TotalTvl          int64  `bson:"total_tvl"`
+	ActiveDelegations int64  `bson:"active_delegations"`
+	TotalDelegations  int64  `bson:"total_delegations"`
+	TotalStakers      uint64 `bson:"total_stakers"`
+	ConfirmedTvl      uint64 `bson:"confirmed_tvl"`
+	UnconfirmedTvl    uint64 `bson:"unconfirmed_tvl"`
+	// The snapshot is removed by the ttl index once expired
+	ExpiresAt time.Time `bson:"expires_at,omitempty"`
+}
+
+// FinalityProviderStatsSnapshotDocument is a point-in-time copy of a finality
+// provider stats document. The _id is in the format of {{fpPkHex}}:{{btcHeight}}:{{timestamp}}
+type FinalityProviderStatsSnapshotDocument struct {
+	Id                    string    `bson:"_id"`
+	FinalityProviderPkHex string    `bson:"finality_provider_pk_hex"`
+	BtcHeight             uint64    `bson:"btc_height"`
+	Timestamp             int64     `bson:"timestamp"`
+	ActiveTvl             int64     `bson:"active_tvl"`
+	TotalTvl              int64     `bson:"total_tvl"`
+	ActiveDelegations     int64     `bson:"active_delegations"`
+	TotalDelegations      int64     `bson:"total_delegations"`
+	ExpiresAt             time.Time `bson:"expires_at,omitempty"`
+}
+
+// StakerStatsSnapshotDocument is a point-in-time copy of a staker stats
+// document. The _id is in the format of {{stakerPkHex}}:{{btcHeight}}:{{timestamp}}
+type StakerStatsSnapshotDocument struct {
+	Id                string    `bson:"_id"`
+	StakerPkHex       string    `bson:"staker_pk_hex"`
+	BtcHeight         uint64    `bson:"btc_height"`
+	Timestamp         int64     `bson:"timestamp"`
+	ActiveTvl         int64     `bson:"active_tvl"`
+	TotalTvl          int64     `bson:"total_tvl"`
+	ActiveDelegations int64     `bson:"active_delegations"`
+	TotalDelegations  int64     `bson:"total_delegations"`
+	ExpiresAt         time.Time `bson:"expires_at,omitempty"`
+}
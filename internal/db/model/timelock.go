@@ -13,3 +13,11 @@ func NewTimeLockDocument(stakingTxHashHex string, expireHeight uint64, txType st
 		TxType:           txType,
 	}
 }
+
+// TimeLockExpirationBucket sums up the delegations unlocked by the timelock
+// expiries within a bucket of btc heights, identified by its index
+type TimeLockExpirationBucket struct {
+	Index        uint64 `bson:"_id"`
+	Delegations  uint64 `bson:"delegations"`
+	StakingValue uint64 `bson:"staking_value"`
+}
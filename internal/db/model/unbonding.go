@@ -2,8 +2,39 @@ package model
 
 const (
 	UnbondingInitialState = "INSERTED"
+	// The states reported back by the unbonding pipeline processing the requests
+	UnbondingPickedUpState                    = "PICKED_UP"
+	UnbondingCovenantSignaturesCollectedState = "COVENANT_SIGNATURES_COLLECTED"
+	UnbondingBroadcastState                   = "BROADCAST"
+	UnbondingConfirmedState                   = "CONFIRMED"
+	UnbondingFailedState                      = "FAILED"
 )
 
+// UnbondingStateOrder returns the position of the state within the unbonding
+// request lifecycle, the failed state can be reached from any position before
+// the confirmed one. It returns -1 for an unknown state.
+func UnbondingStateOrder(state string) int {
+	switch state {
+	case UnbondingInitialState:
+		return 0
+	case UnbondingPickedUpState:
+		return 1
+	case UnbondingCovenantSignaturesCollectedState:
+		return 2
+	case UnbondingBroadcastState:
+		return 3
+	case UnbondingConfirmedState, UnbondingFailedState:
+		return 4
+	default:
+		return -1
+	}
+}
+
+// UnbondingStakingTxHashHexField is the bson field of the staking tx hash. The
+// field has no bson tag, hence the default lowercased field name is kept for
+// the compatibility with the existing documents.
+const UnbondingStakingTxHashHexField = "stakingtxhashhex"
+
 type UnbondingDocument struct {
 	StakerPkHex        string `bson:"staker_pk_hex"`
 	FinalityPkHex      string `bson:"finality_pk_hex"`
@@ -16,4 +47,14 @@ type UnbondingDocument struct {
 	StakingTimelock    uint64 `bson:"staking_timelock"`
 	StakingAmount      uint64 `bson:"staking_amount"`
 	StakingTxHashHex   string `json:"staking_tx_hash_hex"`
+	// Only set once the unbonding pipeline reported the request as failed
+	FailureReason string `bson:"failure_reason,omitempty"`
+	// Empty for the requests inserted before the lifecycle was recorded
+	StateHistory []UnbondingStateTransition `bson:"state_history,omitempty"`
+}
+
+type UnbondingStateTransition struct {
+	State     string `bson:"state"`
+	Timestamp int64  `bson:"timestamp"`
+	Reason    string `bson:"reason,omitempty"`
 }
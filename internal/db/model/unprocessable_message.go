@@ -1,13 +1,91 @@
 package model
 
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
 type UnprocessableMessageDocument struct {
-	MessageBody string `bson:"message_body"`
-	Receipt     string `bson:"receipt"`
+	Id          primitive.ObjectID `bson:"_id,omitempty"`
+	MessageBody string             `bson:"message_body"`
+	Receipt     string             `bson:"receipt"`
+	// The messages dead-lettered before the failure metadata was recorded
+	// only have the body and receipt
+	QueueName string `bson:"queue_name,omitempty"`
+	// The error of the final attempt
+	Error      string `bson:"error,omitempty"`
+	ErrorCode  string `bson:"error_code,omitempty"`
+	StatusCode int    `bson:"status_code,omitempty"`
+	TraceId    string `bson:"trace_id,omitempty"`
+	Attempts   int32  `bson:"attempts,omitempty"`
+	// The failed processing attempts, the final one last
+	AttemptHistory []MessageProcessingAttempt `bson:"attempt_history,omitempty"`
+	FailedAt       int64                      `bson:"failed_at,omitempty"`
 }
 
-func NewUnprocessableMessageDocument(messageBody, receipt string) *UnprocessableMessageDocument {
+func NewUnprocessableMessageDocument(
+	messageBody, receipt, queueName, errMsg string, attempts int32, failedAt int64,
+) *UnprocessableMessageDocument {
 	return &UnprocessableMessageDocument{
 		MessageBody: messageBody,
 		Receipt:     receipt,
+		QueueName:   queueName,
+		Error:       errMsg,
+		Attempts:    attempts,
+		FailedAt:    failedAt,
 	}
 }
+
+type MessageProcessingAttempt struct {
+	Timestamp  int64  `bson:"timestamp"`
+	TraceId    string `bson:"trace_id,omitempty"`
+	Error      string `bson:"error"`
+	ErrorCode  string `bson:"error_code"`
+	StatusCode int    `bson:"status_code"`
+}
+
+// MessageProcessingAttemptsDocument tracks the failed processing attempts of
+// a message while it's retried. The message is identified by its queue and
+// body, as the receipt changes once it's requeued, hence messages with an
+// identical body on the same queue share a single attempt history.
+// The document is removed once the message is processed or dead-lettered,
+// otherwise it expires after the last attempt.
+type MessageProcessingAttemptsDocument struct {
+	MessageKey string                     `bson:"_id"`
+	Attempts   []MessageProcessingAttempt `bson:"attempts"`
+	ExpiresAt  time.Time                  `bson:"expires_at"`
+}
+
+type UnprocessableMessagePagination struct {
+	Id string `json:"id"`
+}
+
+func BuildUnprocessableMessagePaginationToken(d UnprocessableMessageDocument) (string, error) {
+	page := &UnprocessableMessagePagination{
+		Id: d.Id.Hex(),
+	}
+	token, err := GetPaginationToken(page)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// UnprocessableMessageAuditDocument records an admin action on the
+// unprocessable messages
+type UnprocessableMessageAuditDocument struct {
+	Id     primitive.ObjectID               `bson:"_id,omitempty"`
+	Action types.UnprocessableMessageAction `bson:"action"`
+	// The message acted upon, empty for the listing
+	MessageId primitive.ObjectID `bson:"message_id,omitempty"`
+	// The filter of the listing or the bulk replay
+	QueueName string `bson:"queue_name,omitempty"`
+	Reason    string `bson:"reason,omitempty"`
+	// The snapshot of the replayed or discarded message, which is no longer
+	// stored in the unprocessable messages
+	Message   *UnprocessableMessageDocument `bson:"message,omitempty"`
+	Timestamp int64                         `bson:"timestamp"`
+}
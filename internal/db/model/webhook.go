@@ -0,0 +1,67 @@
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+// WebhookSubscriptionDocument is a registered webhook. The empty filters match
+// any staker, finality provider or event type.
+type WebhookSubscriptionDocument struct {
+	Id                    string                   `bson:"_id"`
+	Url                   string                   `bson:"url"`
+	Secret                string                   `bson:"secret"` // HMAC key of the payload signatures
+	StakerPkHex           string                   `bson:"staker_pk_hex"`
+	FinalityProviderPkHex string                   `bson:"finality_provider_pk_hex"`
+	EventTypes            []types.WebhookEventType `bson:"event_types"`
+	CreatedAt             int64                    `bson:"created_at"`
+}
+
+// WebhookEvent is the delegation state transition delivered to the subscribers
+type WebhookEvent struct {
+	EventType             types.WebhookEventType      `bson:"event_type"`
+	StakingTxHashHex      string                      `bson:"staking_tx_hash_hex"`
+	StakerPkHex           string                      `bson:"staker_pk_hex"`
+	FinalityProviderPkHex string                      `bson:"finality_provider_pk_hex"`
+	StakingValue          uint64                      `bson:"staking_value"`
+	PreviousState         types.DelegationState       `bson:"previous_state,omitempty"`
+	NewState              types.DelegationState       `bson:"new_state"`
+	BtcHeight             uint64                      `bson:"btc_height"`
+	Timestamp             int64                       `bson:"timestamp"`
+	Source                types.DelegationStateSource `bson:"source"`
+}
+
+type WebhookDeliveryAttempt struct {
+	Timestamp int64 `bson:"timestamp"`
+	// Zero if no response is received
+	StatusCode int    `bson:"status_code"`
+	Error      string `bson:"error,omitempty"`
+}
+
+// WebhookDeliveryDocument is an entry of the persistent delivery queue. Pending
+// deliveries are picked up once their next_attempt_at is reached.
+type WebhookDeliveryDocument struct {
+	Id             primitive.ObjectID         `bson:"_id,omitempty"`
+	SubscriptionId string                     `bson:"subscription_id"`
+	Event          WebhookEvent               `bson:"event"`
+	State          types.WebhookDeliveryState `bson:"state"`
+	Attempts       []WebhookDeliveryAttempt   `bson:"attempts"`
+	NextAttemptAt  int64                      `bson:"next_attempt_at"`
+	CreatedAt      int64                      `bson:"created_at"`
+}
+
+type WebhookDeliveryPagination struct {
+	Id string `json:"id"`
+}
+
+func BuildWebhookDeliveryPaginationToken(d WebhookDeliveryDocument) (string, error) {
+	page := &WebhookDeliveryPagination{
+		Id: d.Id.Hex(),
+	}
+	token, err := GetPaginationToken(page)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
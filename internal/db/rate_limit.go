@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+)
+
+// TakeRateLimitToken refills the bucket of the key for the time elapsed since
+// its last update and takes a token from it, if any. The bucket is updated
+// atomically, hence it can be shared across service replicas. The returned
+// bucket tells whether the token was taken.
+func (db *Database) TakeRateLimitToken(
+	ctx context.Context, key string, requestsPerSecond float64, burst int, now time.Time,
+) (*model.RateLimitBucketDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.RateLimitBucketCollection)
+	nowSeconds := float64(now.UnixNano()) / float64(time.Second)
+	refillDuration := time.Duration(float64(burst) / requestsPerSecond * float64(time.Second))
+
+	// A missing bucket is a full one
+	refilledTokens := bson.M{"$min": bson.A{
+		float64(burst),
+		bson.M{"$add": bson.A{
+			bson.M{"$ifNull": bson.A{"$tokens", float64(burst)}},
+			bson.M{"$multiply": bson.A{
+				bson.M{"$max": bson.A{0, bson.M{"$subtract": bson.A{
+					nowSeconds, bson.M{"$ifNull": bson.A{"$updated_at", nowSeconds}},
+				}}}},
+				requestsPerSecond,
+			}},
+		}},
+	}}
+	pipeline := bson.A{
+		bson.M{"$set": bson.M{"tokens": refilledTokens}},
+		bson.M{"$set": bson.M{"allowed": bson.M{"$gte": bson.A{"$tokens", 1}}}},
+		bson.M{"$set": bson.M{
+			"tokens": bson.M{"$cond": bson.A{
+				"$allowed", bson.M{"$subtract": bson.A{"$tokens", 1}}, "$tokens",
+			}},
+			"updated_at": nowSeconds,
+			"expires_at": now.Add(refillDuration),
+		}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var bucket model.RateLimitBucketDocument
+	err := client.FindOneAndUpdate(ctx, bson.M{"_id": key}, pipeline, opts).Decode(&bucket)
+	if err != nil {
+		return nil, err
+	}
+	return &bucket, nil
+}
@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+)
+
+// FindResponseCacheEntry returns a NotFoundError if the response is not
+// cached or has expired. The expired entries are only deleted periodically
+// by the TTL index, hence they are filtered out here.
+func (db *Database) FindResponseCacheEntry(
+	ctx context.Context, key string, now time.Time,
+) (*model.ResponseCacheDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.ResponseCacheCollection)
+	filter := bson.M{"_id": key, "expires_at": bson.M{"$gt": now}}
+	var entry model.ResponseCacheDocument
+	err := client.FindOne(ctx, filter).Decode(&entry)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &NotFoundError{
+				Key:     key,
+				Message: "response cache entry not found",
+			}
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (db *Database) SaveResponseCacheEntry(ctx context.Context, entry *model.ResponseCacheDocument) error {
+	client := db.Client.Database(db.DbName).Collection(model.ResponseCacheCollection)
+	_, err := client.ReplaceOne(
+		ctx, bson.M{"_id": entry.Key}, entry, options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+// DeleteResponseCacheGroup invalidates all the cached responses of the group
+func (db *Database) DeleteResponseCacheGroup(ctx context.Context, group string) error {
+	client := db.Client.Database(db.DbName).Collection(model.ResponseCacheCollection)
+	_, err := client.DeleteMany(ctx, bson.M{"group": group})
+	return err
+}
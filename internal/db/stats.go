@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 
@@ -217,7 +218,7 @@ func constructStatsLockId(stakingTxHashHex, state string) string {
 // This method is idempotent, only the first call will be processed. Otherwise it will return a notFoundError for duplicates
 // Refer to the README.md in this directory for more information on the sharding logic
 func (db *Database) IncrementFinalityProviderStats(
-	ctx context.Context, stakingTxHashHex, fpPkHex string, amount uint64,
+	ctx context.Context, stakingTxHashHex, fpPkHex, stakerPkHex string, amount uint64,
 ) error {
 	upsertUpdate := bson.M{
 		"$inc": bson.M{
@@ -227,14 +228,14 @@ func (db *Database) IncrementFinalityProviderStats(
 			"total_delegations":  1,
 		},
 	}
-	return db.updateFinalityProviderStats(ctx, types.Active.ToString(), stakingTxHashHex, fpPkHex, upsertUpdate)
+	return db.updateFinalityProviderStats(ctx, types.Active.ToString(), stakingTxHashHex, fpPkHex, stakerPkHex, upsertUpdate)
 }
 
 // SubtractFinalityProviderStats decrements the finality provider stats for the given provider pk hex
 // This method is idempotent, only the first call will be processed. Otherwise it will return a notFoundError for duplicates
 // Refer to the README.md in this directory for more information on the sharding logic
 func (db *Database) SubtractFinalityProviderStats(
-	ctx context.Context, stakingTxHashHex, fpPkHex string, amount uint64,
+	ctx context.Context, stakingTxHashHex, fpPkHex, stakerPkHex string, amount uint64,
 ) error {
 	upsertUpdate := bson.M{
 		"$inc": bson.M{
@@ -242,7 +243,7 @@ func (db *Database) SubtractFinalityProviderStats(
 			"active_delegations": -1,
 		},
 	}
-	return db.updateFinalityProviderStats(ctx, types.Unbonded.ToString(), stakingTxHashHex, fpPkHex, upsertUpdate)
+	return db.updateFinalityProviderStats(ctx, types.Unbonded.ToString(), stakingTxHashHex, fpPkHex, stakerPkHex, upsertUpdate)
 }
 
 // FindFinalityProviderStats fetches the finality provider stats from the database
@@ -292,8 +293,14 @@ func (db *Database) FindFinalityProviderStatsByFinalityProviderPkHex(
 	return finalityProviders, nil
 }
 
-func (db *Database) updateFinalityProviderStats(ctx context.Context, state, stakingTxHashHex, fpPkHex string, upsertUpdate primitive.M) error {
+// updateFinalityProviderStats applies the update to the finality provider stats
+// and maintains its active and total unique stakers within the same transaction
+// as the stats lock, hence the staker counts are idempotent as well.
+func (db *Database) updateFinalityProviderStats(
+	ctx context.Context, state, stakingTxHashHex, fpPkHex, stakerPkHex string, upsertUpdate primitive.M,
+) error {
 	client := db.Client.Database(db.DbName).Collection(model.FinalityProviderStatsCollection)
+	fpStakerStatsClient := db.Client.Database(db.DbName).Collection(model.FinalityProviderStakerStatsCollection)
 
 	// Start a session
 	session, sessionErr := db.Client.StartSession()
@@ -308,6 +315,41 @@ func (db *Database) updateFinalityProviderStats(ctx context.Context, state, stak
 			return nil, err
 		}
 
+		// The staker is counted as a new unique staker of the finality provider on
+		// its first delegation, and as an active staker while it has any active delegation.
+		isActive := state == types.Active.ToString()
+		fpStakerStatsFilter := bson.M{"_id": constructFinalityProviderStakerStatsId(fpPkHex, stakerPkHex)}
+		fpStakerStatsUpdate := bson.M{"$inc": bson.M{"active_delegations": 1, "total_delegations": 1}}
+		fpStakerStatsOptions := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+		if !isActive {
+			// The delegation may have been activated before its counter existed,
+			// e.g. not yet backfilled, a missing counter shall not go negative.
+			fpStakerStatsFilter["active_delegations"] = bson.M{"$gt": 0}
+			fpStakerStatsUpdate = bson.M{"$inc": bson.M{"active_delegations": -1}}
+			fpStakerStatsOptions.SetUpsert(false)
+		}
+		var fpStakerStats model.FinalityProviderStakerStatsDocument
+		err = fpStakerStatsClient.FindOneAndUpdate(
+			sessCtx, fpStakerStatsFilter, fpStakerStatsUpdate, fpStakerStatsOptions,
+		).Decode(&fpStakerStats)
+		isCounted := true
+		if err != nil {
+			if isActive || !errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, err
+			}
+			isCounted = false
+		}
+		if isActive {
+			if fpStakerStats.TotalDelegations == 1 {
+				upsertUpdate["$inc"].(bson.M)["total_stakers"] = 1
+			}
+			if fpStakerStats.ActiveDelegations == 1 {
+				upsertUpdate["$inc"].(bson.M)["active_stakers"] = 1
+			}
+		} else if isCounted && fpStakerStats.ActiveDelegations == 0 {
+			upsertUpdate["$inc"].(bson.M)["active_stakers"] = -1
+		}
+
 		upsertFilter := bson.M{"_id": fpPkHex}
 
 		_, err = client.UpdateOne(sessCtx, upsertFilter, upsertUpdate, options.Update().SetUpsert(true))
@@ -326,6 +368,73 @@ func (db *Database) updateFinalityProviderStats(ctx context.Context, state, stak
 	return nil
 }
 
+// BackfillFinalityProviderStakerStats rebuilds the per staker counters of the
+// finality providers and their active and unique stakers from the delegations.
+// The delegations not yet unbonded nor withdrawn are counted as active.
+// It shall be run while the queues are not consumed, and can be safely re-run.
+func (db *Database) BackfillFinalityProviderStakerStats(ctx context.Context) error {
+	client := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
+	groupByFpStaker := bson.D{{Key: "$group", Value: bson.M{
+		"_id": bson.M{
+			"finality_provider_pk_hex": "$finality_provider_pk_hex",
+			"staker_pk_hex":            "$staker_pk_hex",
+		},
+		"active_delegations": bson.M{"$sum": bson.M{"$cond": bson.A{
+			bson.M{"$in": bson.A{"$state", bson.A{types.Unbonded.ToString(), types.Withdrawn.ToString()}}}, 0, 1,
+		}}},
+		"total_delegations": bson.M{"$sum": 1},
+	}}}
+
+	fpStakerStatsPipeline := mongo.Pipeline{
+		groupByFpStaker,
+		{{Key: "$project", Value: bson.M{
+			"_id": bson.M{"$concat": bson.A{
+				"$_id.finality_provider_pk_hex", ":", "$_id.staker_pk_hex",
+			}},
+			"active_delegations": 1,
+			"total_delegations":  1,
+		}}},
+		{{Key: "$merge", Value: bson.M{
+			"into":           model.FinalityProviderStakerStatsCollection,
+			"whenMatched":    "replace",
+			"whenNotMatched": "insert",
+		}}},
+	}
+	cursor, err := client.Aggregate(ctx, fpStakerStatsPipeline)
+	if err != nil {
+		return err
+	}
+	if err := cursor.Close(ctx); err != nil {
+		return err
+	}
+
+	// Only the staker counts are merged into the existing finality provider stats
+	fpStatsPipeline := mongo.Pipeline{
+		groupByFpStaker,
+		{{Key: "$group", Value: bson.M{
+			"_id":           "$_id.finality_provider_pk_hex",
+			"total_stakers": bson.M{"$sum": 1},
+			"active_stakers": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{"$active_delegations", 0}}, 1, 0,
+			}}},
+		}}},
+		{{Key: "$merge", Value: bson.M{
+			"into":           model.FinalityProviderStatsCollection,
+			"whenMatched":    "merge",
+			"whenNotMatched": "discard",
+		}}},
+	}
+	cursor, err = client.Aggregate(ctx, fpStatsPipeline)
+	if err != nil {
+		return err
+	}
+	return cursor.Close(ctx)
+}
+
+func constructFinalityProviderStakerStatsId(fpPkHex, stakerPkHex string) string {
+	return fpPkHex + ":" + stakerPkHex
+}
+
 // IncrementStakerStats increments the staker stats for the given staking tx hash
 // This method is idempotent, only the first call will be processed. Otherwise it will return a notFoundError for duplicates
 func (db *Database) IncrementStakerStats(
@@ -412,3 +521,49 @@ func (db *Database) FindTopStakersByTvl(ctx context.Context, paginationToken str
 		model.BuildStakerStatsByStakerPaginationToken,
 	)
 }
+
+// FindStakerStatsByStakerPks returns the stats of the stakers, the stakers
+// without any delegation yet are omitted.
+func (db *Database) FindStakerStatsByStakerPks(
+	ctx context.Context, stakerPks []string,
+) ([]model.StakerStatsDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.StakerStatsCollection)
+	cursor, err := client.Find(ctx, bson.M{"_id": bson.M{"$in": stakerPks}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	stats := []model.StakerStatsDocument{}
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// FindStakerStatsByStakerPk returns a NotFoundError if the staker has no
+// delegation yet
+func (db *Database) FindStakerStatsByStakerPk(
+	ctx context.Context, stakerPk string,
+) (*model.StakerStatsDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.StakerStatsCollection)
+	var stats model.StakerStatsDocument
+	err := client.FindOne(ctx, bson.M{"_id": stakerPk}).Decode(&stats)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &NotFoundError{
+				Key:     stakerPk,
+				Message: "staker stats not found",
+			}
+		}
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// CountStakersWithHigherActiveTvl counts the stakers ranked above the active
+// tvl, the count is served by the active tvl index.
+func (db *Database) CountStakersWithHigherActiveTvl(ctx context.Context, activeTvl int64) (int64, error) {
+	client := db.Client.Database(db.DbName).Collection(model.StakerStatsCollection)
+	return client.CountDocuments(ctx, bson.M{"active_tvl": bson.M{"$gt": activeTvl}})
+}
@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SaveStatsSnapshot persists the given overall stats snapshot and copies the
+// current finality provider and staker stats into their snapshot collections
+// under the same btc height, timestamp and expiry.
+// This method tolerates duplicated calls, the snapshot documents will be overwritten.
+func (db *Database) SaveStatsSnapshot(
+	ctx context.Context, snapshot *model.OverallStatsSnapshotDocument,
+) error {
+	snapshot.Id = constructStatsSnapshotId(snapshot.BtcHeight, snapshot.Timestamp)
+	client := db.Client.Database(db.DbName).Collection(model.OverallStatsSnapshotCollection)
+	_, err := client.ReplaceOne(
+		ctx, bson.M{"_id": snapshot.Id}, snapshot, options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		return err
+	}
+
+	// The per finality provider and per staker stats can be large, hence the
+	// copy is done within the db server instead of loading them into memory.
+	err = db.copyStatsIntoSnapshot(
+		ctx, model.FinalityProviderStatsCollection, model.FinalityProviderStatsSnapshotCollection,
+		"finality_provider_pk_hex", snapshot.BtcHeight, snapshot.Timestamp, snapshot.ExpiresAt,
+	)
+	if err != nil {
+		return err
+	}
+	return db.copyStatsIntoSnapshot(
+		ctx, model.StakerStatsCollection, model.StakerStatsSnapshotCollection,
+		"staker_pk_hex", snapshot.BtcHeight, snapshot.Timestamp, snapshot.ExpiresAt,
+	)
+}
+
+// FindOverallStatsSnapshots returns the latest overall stats snapshot of each
+// interval bucket between the from and to timestamps, sorted by timestamp in ascending order
+func (db *Database) FindOverallStatsSnapshots(
+	ctx context.Context, from, to, interval int64,
+) ([]model.OverallStatsSnapshotDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.OverallStatsSnapshotCollection)
+	return findStatsSnapshotBuckets[model.OverallStatsSnapshotDocument](
+		ctx, client, bson.M{}, from, to, interval,
+	)
+}
+
+// FindFinalityProviderStatsSnapshots returns the latest stats snapshot of the
+// given finality provider in each interval bucket between the from and to timestamps
+func (db *Database) FindFinalityProviderStatsSnapshots(
+	ctx context.Context, fpPkHex string, from, to, interval int64,
+) ([]model.FinalityProviderStatsSnapshotDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.FinalityProviderStatsSnapshotCollection)
+	return findStatsSnapshotBuckets[model.FinalityProviderStatsSnapshotDocument](
+		ctx, client, bson.M{"finality_provider_pk_hex": fpPkHex}, from, to, interval,
+	)
+}
+
+// FindStakerStatsSnapshots returns the latest stats snapshot of the given
+// staker in each interval bucket between the from and to timestamps
+func (db *Database) FindStakerStatsSnapshots(
+	ctx context.Context, stakerPkHex string, from, to, interval int64,
+) ([]model.StakerStatsSnapshotDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.StakerStatsSnapshotCollection)
+	return findStatsSnapshotBuckets[model.StakerStatsSnapshotDocument](
+		ctx, client, bson.M{"staker_pk_hex": stakerPkHex}, from, to, interval,
+	)
+}
+
+// copyStatsIntoSnapshot copies all the documents from the stats collection into
+// the snapshot collection. The _id of the stats document is kept in the pkField.
+func (db *Database) copyStatsIntoSnapshot(
+	ctx context.Context, statsCollection, snapshotCollection, pkField string,
+	btcHeight uint64, timestamp int64, expiresAt time.Time,
+) error {
+	client := db.Client.Database(db.DbName).Collection(statsCollection)
+	idSuffix := constructStatsSnapshotId(btcHeight, timestamp)
+	pipeline := mongo.Pipeline{
+		{{Key: "$project", Value: bson.M{
+			"_id":                bson.M{"$concat": bson.A{"$_id", ":" + idSuffix}},
+			pkField:              "$_id",
+			"btc_height":         bson.M{"$literal": int64(btcHeight)},
+			"timestamp":          bson.M{"$literal": timestamp},
+			"active_tvl":         1,
+			"total_tvl":          1,
+			"active_delegations": 1,
+			"total_delegations":  1,
+			"expires_at":         bson.M{"$literal": expiresAt},
+		}}},
+		{{Key: "$merge", Value: bson.M{
+			"into":           snapshotCollection,
+			"whenMatched":    "replace",
+			"whenNotMatched": "insert",
+		}}},
+	}
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	return cursor.Close(ctx)
+}
+
+// findStatsSnapshotBuckets groups the snapshots between the from and to timestamps
+// into buckets of the given interval (in seconds) and returns the latest snapshot of each bucket
+func findStatsSnapshotBuckets[T any](
+	ctx context.Context, client *mongo.Collection, filter bson.M, from, to, interval int64,
+) ([]T, error) {
+	filter["timestamp"] = bson.M{"$gte": from, "$lte": to}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$sort", Value: bson.D{{Key: "timestamp", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{"$subtract": bson.A{
+				"$timestamp", bson.M{"$mod": bson.A{"$timestamp", interval}},
+			}},
+			"snapshot": bson.M{"$last": "$$ROOT"},
+		}}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$snapshot"}}},
+		{{Key: "$sort", Value: bson.D{{Key: "timestamp", Value: 1}}}},
+	}
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var snapshots []T
+	if err = cursor.All(ctx, &snapshots); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+func constructStatsSnapshotId(btcHeight uint64, timestamp int64) string {
+	return fmt.Sprintf("%d:%d", btcHeight, timestamp)
+}
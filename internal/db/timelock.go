@@ -5,6 +5,9 @@ import (
 
 	"github.com/babylonchain/staking-api-service/internal/db/model"
 	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func (db *Database) SaveTimeLockExpireCheck(
@@ -22,6 +25,65 @@ func (db *Database) SaveTimeLockExpireCheck(
 
 func (db *Database) TransitionToUnbondedState(
 	ctx context.Context, stakingTxHashHex string, eligiblePreviousState []types.DelegationState,
+	transition *StateTransitionInfo,
 ) error {
-	return db.transitionState(ctx, stakingTxHashHex, types.Unbonded.ToString(), eligiblePreviousState, nil)
+	return db.transitionState(ctx, stakingTxHashHex, types.Unbonded, eligiblePreviousState, nil, transition)
+}
+
+// FindTimeLockExpirationBuckets sums up the delegations unlocked by the
+// timelock expiries with an expire height above fromHeight and up to toHeight,
+// in buckets of bucketBlocks heights starting right after fromHeight. An expiry
+// is only counted if the delegation is still in a state that transitions to
+// unbonded once it happens. The buckets without expiries are omitted.
+func (db *Database) FindTimeLockExpirationBuckets(
+	ctx context.Context, fromHeight, toHeight, bucketBlocks uint64,
+) ([]model.TimeLockExpirationBucket, error) {
+	client := db.Client.Database(db.DbName).Collection(model.TimeLockCollection)
+
+	qualifiedExpiries := bson.A{}
+	for _, txType := range []types.StakingTxType{types.ActiveTxType, types.UnbondingTxType} {
+		qualifiedExpiries = append(qualifiedExpiries, bson.M{
+			"_id.tx_type":      txType.ToString(),
+			"delegation.state": bson.M{"$in": utils.QualifiedStatesToUnbonded(txType)},
+		})
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"expire_height": bson.M{"$gt": int64(fromHeight), "$lte": int64(toHeight)},
+		}}},
+		// The expire checks tolerate duplicates, only count each of them once
+		{{Key: "$group", Value: bson.M{"_id": bson.M{
+			"staking_tx_hash_hex": "$staking_tx_hash_hex",
+			"expire_height":       "$expire_height",
+			"tx_type":             "$tx_type",
+		}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         model.DelegationCollection,
+			"localField":   "_id.staking_tx_hash_hex",
+			"foreignField": "_id",
+			"as":           "delegation",
+		}}},
+		{{Key: "$unwind", Value: "$delegation"}},
+		{{Key: "$match", Value: bson.M{"$or": qualifiedExpiries}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{"$toLong": bson.M{"$floor": bson.M{"$divide": bson.A{
+				bson.M{"$subtract": bson.A{"$_id.expire_height", int64(fromHeight) + 1}},
+				int64(bucketBlocks),
+			}}}},
+			"delegations":   bson.M{"$sum": 1},
+			"staking_value": bson.M{"$sum": "$delegation.staking_value"},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+	cursor, err := client.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	buckets := []model.TimeLockExpirationBucket{}
+	if err := cursor.All(ctx, &buckets); err != nil {
+		return nil, err
+	}
+	return buckets, nil
 }
@@ -13,6 +13,7 @@ import (
 
 func (db *Database) SaveUnbondingTx(
 	ctx context.Context, stakingTxHashHex, txHashHex, txHex, signatureHex string,
+	transition *StateTransitionInfo,
 ) error {
 	delegationClient := db.Client.Database(db.DbName).Collection(model.DelegationCollection)
 	unbondingClient := db.Client.Database(db.DbName).Collection(model.UnbondingCollection)
@@ -69,6 +70,9 @@ func (db *Database) SaveUnbondingTx(
 			StakingTimelock:    delegationDocument.StakingTx.TimeLock,
 			StakingTxHashHex:   stakingTxHashHex,
 			StakingAmount:      delegationDocument.StakingValue,
+			StateHistory: []model.UnbondingStateTransition{
+				{State: model.UnbondingInitialState, Timestamp: transition.Timestamp},
+			},
 		}
 		_, err = unbondingClient.InsertOne(sessCtx, unbondingDocument)
 		if err != nil {
@@ -86,6 +90,14 @@ func (db *Database) SaveUnbondingTx(
 			return nil, err
 		}
 
+		err = db.saveDelegationStateHistory(sessCtx, &delegationDocument, model.NewDelegationStateHistoryDocument(
+			stakingTxHashHex, types.Active, types.UnbondingRequested,
+			transition.BtcHeight, transition.Timestamp, transition.Source,
+		))
+		if err != nil {
+			return nil, err
+		}
+
 		return nil, nil
 	}
 
@@ -98,6 +110,80 @@ func (db *Database) SaveUnbondingTx(
 	return nil
 }
 
+// FindUnbondingTxByHashHex returns the unbonding request of the unbonding tx
+// hash, or a NotFoundError if it has not been requested through the service
+func (db *Database) FindUnbondingTxByHashHex(
+	ctx context.Context, unbondingTxHashHex string,
+) (*model.UnbondingDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.UnbondingCollection)
+	var unbonding model.UnbondingDocument
+	err := client.FindOne(ctx, bson.M{"unbonding_tx_hash_hex": unbondingTxHashHex}).Decode(&unbonding)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &NotFoundError{
+				Key:     unbondingTxHashHex,
+				Message: "unbonding transaction not found",
+			}
+		}
+		return nil, err
+	}
+	return &unbonding, nil
+}
+
+// FindUnbondingTxByStakingTxHashHex returns the unbonding request of the
+// staking tx, or a NotFoundError if it has not been requested
+func (db *Database) FindUnbondingTxByStakingTxHashHex(
+	ctx context.Context, stakingTxHashHex string,
+) (*model.UnbondingDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.UnbondingCollection)
+	filter := bson.M{model.UnbondingStakingTxHashHexField: stakingTxHashHex}
+	var unbonding model.UnbondingDocument
+	err := client.FindOne(ctx, filter).Decode(&unbonding)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &NotFoundError{
+				Key:     stakingTxHashHex,
+				Message: "unbonding request not found",
+			}
+		}
+		return nil, err
+	}
+	return &unbonding, nil
+}
+
+// TransitionUnbondingState moves the unbonding request of the staking tx to
+// the state of the transition and records it in the state history. It returns
+// a NotFoundError if the request is not in any of the eligible previous states.
+func (db *Database) TransitionUnbondingState(
+	ctx context.Context, stakingTxHashHex string, eligiblePreviousStates []string,
+	transition *model.UnbondingStateTransition,
+) error {
+	client := db.Client.Database(db.DbName).Collection(model.UnbondingCollection)
+	filter := bson.M{
+		model.UnbondingStakingTxHashHexField: stakingTxHashHex,
+		"state":                              bson.M{"$in": eligiblePreviousStates},
+	}
+	set := bson.M{"state": transition.State}
+	if transition.State == model.UnbondingFailedState {
+		set["failure_reason"] = transition.Reason
+	}
+	update := bson.M{
+		"$set":  set,
+		"$push": bson.M{"state_history": transition},
+	}
+	result, err := client.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return &NotFoundError{
+			Key:     stakingTxHashHex,
+			Message: "unbonding request not found or not eligible for the state transition",
+		}
+	}
+	return nil
+}
+
 // Change the state to `unbonding` and save the unbondingTx data
 // Return not found error if the stakingTxHashHex is not found or the existing state is not eligible for unbonding
 func (db *Database) TransitionToUnbondingState(
@@ -113,8 +199,13 @@ func (db *Database) TransitionToUnbondingState(
 	}
 
 	err := db.transitionState(
-		ctx, txHashHex, types.Unbonding.ToString(),
+		ctx, txHashHex, types.Unbonding,
 		utils.QualifiedStatesToUnbonding(), unbondingTxMap,
+		&StateTransitionInfo{
+			BtcHeight: startHeight,
+			Timestamp: startTimestamp,
+			Source:    types.UnbondingStakingEventSource,
+		},
 	)
 	if err != nil {
 		return err
@@ -2,16 +2,22 @@ package db
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/babylonchain/staking-api-service/internal/db/model"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func (db *Database) SaveUnprocessableMessage(ctx context.Context, messageBody, receipt string) error {
+func (db *Database) SaveUnprocessableMessage(
+	ctx context.Context, document *model.UnprocessableMessageDocument,
+) error {
 	unprocessableMsgClient := db.Client.Database(db.DbName).Collection(model.UnprocessableMsgCollection)
 
-	_, err := unprocessableMsgClient.InsertOne(ctx, model.NewUnprocessableMessageDocument(messageBody, receipt))
+	_, err := unprocessableMsgClient.InsertOne(ctx, document)
 	if err != nil {
 		return err
 	}
@@ -19,12 +25,87 @@ func (db *Database) SaveUnprocessableMessage(ctx context.Context, messageBody, r
 	return nil
 }
 
+// SaveUnprocessableMessageWithAttempts saves the message along with its
+// recorded failed attempts prepended to its attempt history, and removes
+// them within the same transaction, so that they are kept if the message
+// can't be saved.
+func (db *Database) SaveUnprocessableMessageWithAttempts(
+	ctx context.Context, messageKey string, document *model.UnprocessableMessageDocument,
+) error {
+	messageClient := db.Client.Database(db.DbName).Collection(model.UnprocessableMsgCollection)
+	attemptsClient := db.Client.Database(db.DbName).Collection(model.MessageProcessingAttemptsCollection)
+
+	// Start a session
+	session, sessionErr := db.Client.StartSession()
+	if sessionErr != nil {
+		return sessionErr
+	}
+	defer session.EndSession(ctx)
+
+	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		var attempts model.MessageProcessingAttemptsDocument
+		err := attemptsClient.FindOneAndDelete(sessCtx, bson.M{"_id": messageKey}).Decode(&attempts)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+		// The transaction may be retried, hence the document is left as is
+		message := *document
+		message.AttemptHistory = append(attempts.Attempts, document.AttemptHistory...)
+		if _, err := messageClient.InsertOne(sessCtx, &message); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	// Execute the transaction
+	_, txErr := session.WithTransaction(ctx, transactionWork)
+	return txErr
+}
+
+// RecordMessageProcessingAttempt appends the failed attempt to the ones of
+// the message and extends their expiry
+func (db *Database) RecordMessageProcessingAttempt(
+	ctx context.Context, messageKey string, attempt *model.MessageProcessingAttempt, expiresAt time.Time,
+) error {
+	client := db.Client.Database(db.DbName).Collection(model.MessageProcessingAttemptsCollection)
+	update := bson.M{
+		"$push": bson.M{"attempts": attempt},
+		"$set":  bson.M{"expires_at": expiresAt},
+	}
+	_, err := client.UpdateOne(ctx, bson.M{"_id": messageKey}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// PopMessageProcessingAttempts removes and returns the failed attempts of
+// the message, it returns an empty slice if none has been recorded
+func (db *Database) PopMessageProcessingAttempts(
+	ctx context.Context, messageKey string,
+) ([]model.MessageProcessingAttempt, error) {
+	client := db.Client.Database(db.DbName).Collection(model.MessageProcessingAttemptsCollection)
+	var document model.MessageProcessingAttemptsDocument
+	err := client.FindOneAndDelete(ctx, bson.M{"_id": messageKey}).Decode(&document)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return []model.MessageProcessingAttempt{}, nil
+		}
+		return nil, err
+	}
+	return document.Attempts, nil
+}
+
 func (db *Database) FindUnprocessableMessages(ctx context.Context) ([]model.UnprocessableMessageDocument, error) {
+	return db.FindUnprocessableMessagesByFilter(ctx, nil)
+}
+
+// FindUnprocessableMessagesByFilter returns all the unprocessable messages
+// matching the filter, the oldest first
+func (db *Database) FindUnprocessableMessagesByFilter(
+	ctx context.Context, filter *UnprocessableMessageFilter,
+) ([]model.UnprocessableMessageDocument, error) {
 	client := db.Client.Database(db.DbName).Collection(model.UnprocessableMsgCollection)
-	filter := bson.M{}
-	options := options.FindOptions{}
+	options := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
 
-	cursor, err := client.Find(ctx, filter, &options)
+	cursor, err := client.Find(ctx, buildUnprocessableMessageFilter(filter), options)
 	if err != nil {
 		return nil, err
 	}
@@ -38,9 +119,127 @@ func (db *Database) FindUnprocessableMessages(ctx context.Context) ([]model.Unpr
 	return unprocessableMessages, nil
 }
 
+// FindUnprocessableMessagesWithPagination returns the unprocessable messages
+// matching the filter, the latest first
+func (db *Database) FindUnprocessableMessagesWithPagination(
+	ctx context.Context, filter *UnprocessableMessageFilter, paginationToken string,
+) (*DbResultMap[model.UnprocessableMessageDocument], error) {
+	client := db.Client.Database(db.DbName).Collection(model.UnprocessableMsgCollection)
+	mongoFilter := buildUnprocessableMessageFilter(filter)
+	options := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}})
+
+	if paginationToken != "" {
+		decodedToken, err := model.DecodePaginationToken[model.UnprocessableMessagePagination](paginationToken)
+		if err != nil {
+			return nil, &InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		lastId, err := primitive.ObjectIDFromHex(decodedToken.Id)
+		if err != nil {
+			return nil, &InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		mongoFilter["_id"] = bson.M{"$lt": lastId}
+	}
+
+	return findWithPagination(
+		ctx, client, mongoFilter, options, db.cfg.MaxPaginationLimit,
+		model.BuildUnprocessableMessagePaginationToken,
+	)
+}
+
+// FindUnprocessableMessageById returns a NotFoundError if the message does
+// not exist
+func (db *Database) FindUnprocessableMessageById(
+	ctx context.Context, id primitive.ObjectID,
+) (*model.UnprocessableMessageDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.UnprocessableMsgCollection)
+	var message model.UnprocessableMessageDocument
+	err := client.FindOne(ctx, bson.M{"_id": id}).Decode(&message)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &NotFoundError{
+				Key:     id.Hex(),
+				Message: "unprocessable message not found",
+			}
+		}
+		return nil, err
+	}
+	return &message, nil
+}
+
 func (db *Database) DeleteUnprocessableMessage(ctx context.Context, Receipt interface{}) error {
 	unprocessableMsgClient := db.Client.Database(db.DbName).Collection(model.UnprocessableMsgCollection)
 	filter := bson.M{"receipt": Receipt}
 	_, err := unprocessableMsgClient.DeleteOne(ctx, filter)
 	return err
 }
+
+// DeleteUnprocessableMessageWithAudit deletes the message and records the
+// audit within the same transaction. It returns a NotFoundError if the
+// message does not exist, e.g. it has been replayed or discarded concurrently.
+func (db *Database) DeleteUnprocessableMessageWithAudit(
+	ctx context.Context, id primitive.ObjectID, audit *model.UnprocessableMessageAuditDocument,
+) error {
+	messageClient := db.Client.Database(db.DbName).Collection(model.UnprocessableMsgCollection)
+	auditClient := db.Client.Database(db.DbName).Collection(model.UnprocessableMsgAuditCollection)
+
+	// Start a session
+	session, sessionErr := db.Client.StartSession()
+	if sessionErr != nil {
+		return sessionErr
+	}
+	defer session.EndSession(ctx)
+
+	transactionWork := func(sessCtx mongo.SessionContext) (interface{}, error) {
+		result, err := messageClient.DeleteOne(sessCtx, bson.M{"_id": id})
+		if err != nil {
+			return nil, err
+		}
+		if result.DeletedCount == 0 {
+			return nil, &NotFoundError{
+				Key:     id.Hex(),
+				Message: "unprocessable message not found",
+			}
+		}
+		if _, err := auditClient.InsertOne(sessCtx, audit); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	// Execute the transaction
+	_, txErr := session.WithTransaction(ctx, transactionWork)
+	return txErr
+}
+
+func (db *Database) SaveUnprocessableMessageAudit(
+	ctx context.Context, audit *model.UnprocessableMessageAuditDocument,
+) error {
+	client := db.Client.Database(db.DbName).Collection(model.UnprocessableMsgAuditCollection)
+	_, err := client.InsertOne(ctx, audit)
+	return err
+}
+
+func buildUnprocessableMessageFilter(filter *UnprocessableMessageFilter) primitive.M {
+	mongoFilter := bson.M{}
+	if filter == nil {
+		return mongoFilter
+	}
+	if filter.QueueName != "" {
+		mongoFilter["queue_name"] = filter.QueueName
+	}
+	if filter.FailedAfter != 0 || filter.FailedBefore != 0 {
+		failedAt := bson.M{}
+		if filter.FailedAfter != 0 {
+			failedAt["$gte"] = filter.FailedAfter
+		}
+		if filter.FailedBefore != 0 {
+			failedAt["$lte"] = filter.FailedBefore
+		}
+		mongoFilter["failed_at"] = failedAt
+	}
+	return mongoFilter
+}
@@ -0,0 +1,233 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+func (db *Database) SaveWebhookSubscription(
+	ctx context.Context, subscription *model.WebhookSubscriptionDocument,
+) error {
+	client := db.Client.Database(db.DbName).Collection(model.WebhookSubscriptionCollection)
+	_, err := client.InsertOne(ctx, subscription)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return &DuplicateKeyError{
+				Key:     subscription.Id,
+				Message: "webhook subscription already exists",
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// FindWebhookSubscriptions returns all the webhook subscriptions sorted by
+// creation time
+func (db *Database) FindWebhookSubscriptions(ctx context.Context) ([]model.WebhookSubscriptionDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.WebhookSubscriptionCollection)
+	options := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := client.Find(ctx, bson.M{}, options)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	subscriptions := make([]model.WebhookSubscriptionDocument, 0)
+	if err = cursor.All(ctx, &subscriptions); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// FindWebhookSubscriptionById returns a NotFoundError if the subscription
+// does not exist
+func (db *Database) FindWebhookSubscriptionById(
+	ctx context.Context, id string,
+) (*model.WebhookSubscriptionDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.WebhookSubscriptionCollection)
+	var subscription model.WebhookSubscriptionDocument
+	err := client.FindOne(ctx, bson.M{"_id": id}).Decode(&subscription)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &NotFoundError{
+				Key:     id,
+				Message: "webhook subscription not found",
+			}
+		}
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// DeleteWebhookSubscription returns a NotFoundError if the subscription
+// does not exist. The pending deliveries of the subscription are failed
+// once they are picked up.
+func (db *Database) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	client := db.Client.Database(db.DbName).Collection(model.WebhookSubscriptionCollection)
+	result, err := client.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return &NotFoundError{
+			Key:     id,
+			Message: "webhook subscription not found",
+		}
+	}
+	return nil
+}
+
+// FindWebhookDeliveriesBySubscriptionId returns the deliveries of the
+// subscription, the latest first
+func (db *Database) FindWebhookDeliveriesBySubscriptionId(
+	ctx context.Context, subscriptionId, paginationToken string,
+) (*DbResultMap[model.WebhookDeliveryDocument], error) {
+	client := db.Client.Database(db.DbName).Collection(model.WebhookDeliveryCollection)
+	filter := bson.M{"subscription_id": subscriptionId}
+	options := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}})
+
+	if paginationToken != "" {
+		decodedToken, err := model.DecodePaginationToken[model.WebhookDeliveryPagination](paginationToken)
+		if err != nil {
+			return nil, &InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		lastId, err := primitive.ObjectIDFromHex(decodedToken.Id)
+		if err != nil {
+			return nil, &InvalidPaginationTokenError{
+				Message: "Invalid pagination token",
+			}
+		}
+		filter["_id"] = bson.M{"$lt": lastId}
+	}
+
+	return findWithPagination(
+		ctx, client, filter, options, db.cfg.MaxPaginationLimit,
+		model.BuildWebhookDeliveryPaginationToken,
+	)
+}
+
+// ClaimDueWebhookDelivery picks the pending delivery with the earliest due
+// attempt and postpones its next attempt to leaseUntil, so that no other
+// service replica picks it up while it's being delivered.
+// It returns a NotFoundError if no delivery is due.
+func (db *Database) ClaimDueWebhookDelivery(
+	ctx context.Context, now, leaseUntil int64,
+) (*model.WebhookDeliveryDocument, error) {
+	client := db.Client.Database(db.DbName).Collection(model.WebhookDeliveryCollection)
+	filter := bson.M{
+		"state":           types.WebhookDeliveryPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"next_attempt_at": leaseUntil}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var delivery model.WebhookDeliveryDocument
+	err := client.FindOneAndUpdate(ctx, filter, update, opts).Decode(&delivery)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, &NotFoundError{
+				Message: "no webhook delivery is due",
+			}
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// RecordWebhookDeliveryAttempt appends the attempt to the delivery and updates
+// its state. The next attempt time is only relevant for pending deliveries.
+func (db *Database) RecordWebhookDeliveryAttempt(
+	ctx context.Context, id primitive.ObjectID, attempt *model.WebhookDeliveryAttempt,
+	state types.WebhookDeliveryState, nextAttemptAt int64,
+) error {
+	client := db.Client.Database(db.DbName).Collection(model.WebhookDeliveryCollection)
+	update := bson.M{
+		"$set":  bson.M{"state": state, "next_attempt_at": nextAttemptAt},
+		"$push": bson.M{"attempts": attempt},
+	}
+	result, err := client.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return &NotFoundError{
+			Key:     id.Hex(),
+			Message: "webhook delivery not found",
+		}
+	}
+	return nil
+}
+
+// enqueueWebhookDeliveries queues a delivery of the state transition for each
+// matching subscription. It shall be called within the same transaction as the
+// state change, so that no transition is missed nor delivered twice.
+func (db *Database) enqueueWebhookDeliveries(
+	ctx context.Context, delegation *model.DelegationDocument,
+	history *model.DelegationStateHistoryDocument,
+) error {
+	eventType := types.WebhookEventTypeFromDelegationState(history.NewState)
+	subscriptionClient := db.Client.Database(db.DbName).Collection(model.WebhookSubscriptionCollection)
+	filter := bson.M{"$and": bson.A{
+		bson.M{"staker_pk_hex": bson.M{"$in": bson.A{"", delegation.StakerPkHex}}},
+		bson.M{"finality_provider_pk_hex": bson.M{"$in": bson.A{"", delegation.FinalityProviderPkHex}}},
+		bson.M{"$or": bson.A{
+			bson.M{"event_types": bson.M{"$size": 0}},
+			bson.M{"event_types": eventType},
+		}},
+	}}
+	cursor, err := subscriptionClient.Find(ctx, filter)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var subscriptions []model.WebhookSubscriptionDocument
+	if err = cursor.All(ctx, &subscriptions); err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	event := model.WebhookEvent{
+		EventType:             eventType,
+		StakingTxHashHex:      delegation.StakingTxHashHex,
+		StakerPkHex:           delegation.StakerPkHex,
+		FinalityProviderPkHex: delegation.FinalityProviderPkHex,
+		StakingValue:          delegation.StakingValue,
+		PreviousState:         history.PreviousState,
+		NewState:              history.NewState,
+		BtcHeight:             history.BtcHeight,
+		Timestamp:             history.Timestamp,
+		Source:                history.Source,
+	}
+	now := time.Now().Unix()
+	deliveries := make([]interface{}, len(subscriptions))
+	for i, subscription := range subscriptions {
+		deliveries[i] = model.WebhookDeliveryDocument{
+			SubscriptionId: subscription.Id,
+			Event:          event,
+			State:          types.WebhookDeliveryPending,
+			Attempts:       []model.WebhookDeliveryAttempt{},
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		}
+	}
+	deliveryClient := db.Client.Database(db.DbName).Collection(model.WebhookDeliveryCollection)
+	_, err = deliveryClient.InsertMany(ctx, deliveries)
+	return err
+}
@@ -7,10 +7,12 @@ import (
 	"github.com/babylonchain/staking-api-service/internal/utils"
 )
 
-func (db *Database) TransitionToWithdrawnState(ctx context.Context, txHashHex string) error {
+func (db *Database) TransitionToWithdrawnState(
+	ctx context.Context, txHashHex string, transition *StateTransitionInfo,
+) error {
 	err := db.transitionState(
-		ctx, txHashHex, types.Withdrawn.ToString(),
-		utils.QualifiedStatesToWithdraw(), nil,
+		ctx, txHashHex, types.Withdrawn,
+		utils.QualifiedStatesToWithdraw(), nil, transition,
 	)
 	if err != nil {
 		return err
@@ -29,10 +29,12 @@ var (
 	httpRequestDurationHistogram     *prometheus.HistogramVec
 	eventProcessingDurationHistogram *prometheus.HistogramVec
 	unprocessableEntityCounter       *prometheus.CounterVec
+	unprocessableEntityErrorCounter  *prometheus.CounterVec
 	queueOperationFailureCounter     *prometheus.CounterVec
 	httpResponseWriteFailureCounter  *prometheus.CounterVec
 	clientRequestDurationHistogram   *prometheus.HistogramVec
 	serviceCrashCounter              *prometheus.CounterVec
+	rateLimitedRequestCounter        *prometheus.CounterVec
 )
 
 // Init initializes the metrics package.
@@ -89,6 +91,14 @@ func registerMetrics() {
 		[]string{"entity"},
 	)
 
+	unprocessableEntityErrorCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "unprocessable_entity_error_total",
+			Help: "Total number of unprocessable entities from the event processing per queue name and error code.",
+		},
+		[]string{"queuename", "errorcode"},
+	)
+
 	queueOperationFailureCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "queue_operation_failure_total",
@@ -122,14 +132,24 @@ func registerMetrics() {
 		[]string{"type"},
 	)
 
+	rateLimitedRequestCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rate_limited_request_total",
+			Help: "Total number of requests rejected by the rate limit per route group and client type.",
+		},
+		[]string{"group", "client"},
+	)
+
 	prometheus.MustRegister(
 		httpRequestDurationHistogram,
 		eventProcessingDurationHistogram,
 		unprocessableEntityCounter,
+		unprocessableEntityErrorCounter,
 		queueOperationFailureCounter,
 		httpResponseWriteFailureCounter,
 		clientRequestDurationHistogram,
 		serviceCrashCounter,
+		rateLimitedRequestCounter,
 	)
 }
 
@@ -163,6 +183,12 @@ func RecordUnprocessableEntity(entity string) {
 	unprocessableEntityCounter.WithLabelValues(entity).Inc()
 }
 
+// RecordUnprocessableEntityError increments the unprocessable entity counter
+// broken down by the error code of the final processing attempt
+func RecordUnprocessableEntityError(queuename, errorCode string) {
+	unprocessableEntityErrorCounter.WithLabelValues(queuename, errorCode).Inc()
+}
+
 // RecordQueueOperationFailure increments the queue operation failure counter.
 func RecordQueueOperationFailure(operation, queuename string) {
 	queueOperationFailureCounter.WithLabelValues(operation, queuename).Inc()
@@ -191,3 +217,9 @@ func StartClientRequestDurationTimer(baseUrl, method, path string) func(statusCo
 func RecordServiceCrash(service string) {
 	serviceCrashCounter.WithLabelValues(service).Inc()
 }
+
+// RecordRateLimitedRequest increments the rate limited request counter, the
+// client is either limited by its ip or by its key
+func RecordRateLimitedRequest(group, client string) {
+	rateLimitedRequestCounter.WithLabelValues(group, client).Inc()
+}
@@ -39,7 +39,15 @@ func (h *QueueHandler) ExpiredStakingHandler(ctx context.Context, messageBody st
 		return types.NewError(http.StatusBadRequest, types.BadRequest, err)
 	}
 
-	transitionErr := h.Services.TransitionToUnbondedState(ctx, txType, expiredStakingEvent.StakingTxHashHex)
+	// The delegation became unbonded once the timelock of the given tx type expired
+	expireHeight := del.StakingTx.StartHeight + del.StakingTx.TimeLock
+	if txType == types.UnbondingTxType && del.UnbondingTx != nil {
+		expireHeight = del.UnbondingTx.StartHeight + del.UnbondingTx.TimeLock
+	}
+
+	transitionErr := h.Services.TransitionToUnbondedState(
+		ctx, txType, expiredStakingEvent.StakingTxHashHex, expireHeight,
+	)
 	if transitionErr != nil {
 		return transitionErr
 	}
@@ -17,7 +17,14 @@ type QueueHandler struct {
 }
 
 type MessageHandler func(ctx context.Context, messageBody string) *types.Error
-type UnprocessableMessageHandler func(ctx context.Context, messageBody, receipt string) *types.Error
+
+// MessageFailureHandler keeps track of the failed processing attempts of the
+// messages, the messages exceeding the retry attempts are dumped into the db
+type MessageFailureHandler interface {
+	HandleFailedMessage(ctx context.Context, failure *services.MessageProcessingFailure) *types.Error
+	HandleUnprocessedMessage(ctx context.Context, failure *services.MessageProcessingFailure) *types.Error
+	HandleRetriedMessageProcessed(ctx context.Context, queueName, messageBody string) *types.Error
+}
 
 func NewQueueHandler(
 	services *services.Services,
@@ -29,8 +36,22 @@ func NewQueueHandler(
 	}
 }
 
-func (qh *QueueHandler) HandleUnprocessedMessage(ctx context.Context, messageBody, receipt string) *types.Error {
-	return qh.Services.SaveUnprocessableMessages(ctx, messageBody, receipt)
+func (qh *QueueHandler) HandleFailedMessage(
+	ctx context.Context, failure *services.MessageProcessingFailure,
+) *types.Error {
+	return qh.Services.RecordMessageProcessingFailure(ctx, failure)
+}
+
+func (qh *QueueHandler) HandleUnprocessedMessage(
+	ctx context.Context, failure *services.MessageProcessingFailure,
+) *types.Error {
+	return qh.Services.SaveUnprocessableMessages(ctx, failure)
+}
+
+func (qh *QueueHandler) HandleRetriedMessageProcessed(
+	ctx context.Context, queueName, messageBody string,
+) *types.Error {
+	return qh.Services.ClearMessageProcessingFailures(ctx, queueName, messageBody)
 }
 
 func (qh *QueueHandler) EmitStatsEvent(ctx context.Context, statsEvent client.StatsEvent) *types.Error {
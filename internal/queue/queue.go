@@ -91,32 +91,32 @@ func (q *Queues) StartReceivingMessages() {
 	// start processing messages from the active staking queue
 	startQueueMessageProcessing(
 		q.ActiveStakingQueueClient,
-		q.Handlers.ActiveStakingHandler, q.Handlers.HandleUnprocessedMessage,
+		q.Handlers.ActiveStakingHandler, q.Handlers,
 		q.maxRetryAttempts, q.processingTimeout,
 	)
 	startQueueMessageProcessing(
 		q.ExpiredStakingQueueClient,
-		q.Handlers.ExpiredStakingHandler, q.Handlers.HandleUnprocessedMessage,
+		q.Handlers.ExpiredStakingHandler, q.Handlers,
 		q.maxRetryAttempts, q.processingTimeout,
 	)
 	startQueueMessageProcessing(
 		q.UnbondingStakingQueueClient,
-		q.Handlers.UnbondingStakingHandler, q.Handlers.HandleUnprocessedMessage,
+		q.Handlers.UnbondingStakingHandler, q.Handlers,
 		q.maxRetryAttempts, q.processingTimeout,
 	)
 	startQueueMessageProcessing(
 		q.WithdrawStakingQueueClient,
-		q.Handlers.WithdrawStakingHandler, q.Handlers.HandleUnprocessedMessage,
+		q.Handlers.WithdrawStakingHandler, q.Handlers,
 		q.maxRetryAttempts, q.processingTimeout,
 	)
 	startQueueMessageProcessing(
 		q.StatsQueueClient,
-		q.Handlers.StatsHandler, q.Handlers.HandleUnprocessedMessage,
+		q.Handlers.StatsHandler, q.Handlers,
 		q.maxRetryAttempts, q.processingTimeout,
 	)
 	startQueueMessageProcessing(
 		q.BtcInfoQueueClient,
-		q.Handlers.BtcInfoHandler, q.Handlers.HandleUnprocessedMessage,
+		q.Handlers.BtcInfoHandler, q.Handlers,
 		q.maxRetryAttempts, q.processingTimeout,
 	)
 	// ...add more queues here
@@ -163,9 +163,26 @@ func (q *Queues) StopReceivingMessages() {
 	// ...add more queues here
 }
 
+// SendMessage sends the message to the queue with the given name
+func (q *Queues) SendMessage(ctx context.Context, queueName, messageBody string) error {
+	for _, queueClient := range []client.QueueClient{
+		q.ActiveStakingQueueClient,
+		q.ExpiredStakingQueueClient,
+		q.UnbondingStakingQueueClient,
+		q.WithdrawStakingQueueClient,
+		q.StatsQueueClient,
+		q.BtcInfoQueueClient,
+	} {
+		if queueClient.GetQueueName() == queueName {
+			return queueClient.SendMessage(ctx, messageBody)
+		}
+	}
+	return fmt.Errorf("unknown queue: %s", queueName)
+}
+
 func startQueueMessageProcessing(
 	queueClient client.QueueClient,
-	handler handlers.MessageHandler, unprocessableHandler handlers.UnprocessableMessageHandler,
+	handler handlers.MessageHandler, failureHandler handlers.MessageFailureHandler,
 	maxRetryAttempts int32, processingTimeout time.Duration,
 ) {
 	messagesChan, err := queueClient.ReceiveMessages()
@@ -194,13 +211,23 @@ func startQueueMessageProcessing(
 			})
 			if err != nil {
 				recordErrorLog(err)
+				traceId, _ := ctx.Value(tracing.TraceIdKey).(string)
+				failure := &services.MessageProcessingFailure{
+					QueueName:   queueClient.GetQueueName(),
+					MessageBody: message.Body,
+					Receipt:     message.Receipt,
+					Attempts:    attempts,
+					TraceId:     traceId,
+					Err:         err,
+				}
 				// We will retry the message if it has not exceeded the max retry attempts
 				// otherwise, we will dump the message into db for manual inspection and remove from the queue
 				if attempts > maxRetryAttempts {
 					log.Ctx(ctx).Error().Err(err).
 						Msg("exceeded retry attempts, message will be dumped into db for manual inspection")
 					metrics.RecordUnprocessableEntity(queueClient.GetQueueName())
-					saveUnprocessableMsgErr := unprocessableHandler(ctx, message.Body, message.Receipt)
+					metrics.RecordUnprocessableEntityError(queueClient.GetQueueName(), err.ErrorCode.String())
+					saveUnprocessableMsgErr := failureHandler.HandleUnprocessedMessage(ctx, failure)
 					if saveUnprocessableMsgErr != nil {
 						log.Ctx(ctx).Error().Err(saveUnprocessableMsgErr).
 							Msg("error while saving unprocessable message")
@@ -211,6 +238,13 @@ func startQueueMessageProcessing(
 				} else {
 					log.Ctx(ctx).Error().Err(err).
 						Msg("error while processing message from queue, will be requeued")
+					// The message is requeued even if its failed attempt can't be recorded
+					recordFailureErr := failureHandler.HandleFailedMessage(ctx, failure)
+					if recordFailureErr != nil {
+						log.Ctx(ctx).Error().Err(recordFailureErr).
+							Msg("error while recording failed message attempt")
+						metrics.RecordQueueOperationFailure("failedMessageHandler", queueClient.GetQueueName())
+					}
 					reQueueErr := queueClient.ReQueueMessage(ctx, message)
 					if reQueueErr != nil {
 						log.Ctx(ctx).Error().Err(reQueueErr).
@@ -222,6 +256,16 @@ func startQueueMessageProcessing(
 				}
 			}
 
+			// The failed attempts of a message which eventually succeeded are not needed anymore
+			if err == nil && attempts > 0 {
+				clearErr := failureHandler.HandleRetriedMessageProcessed(ctx, queueClient.GetQueueName(), message.Body)
+				if clearErr != nil {
+					log.Ctx(ctx).Error().Err(clearErr).
+						Msg("error while clearing failed message attempts")
+					metrics.RecordQueueOperationFailure("retriedMessageProcessedHandler", queueClient.GetQueueName())
+				}
+			}
+
 			delErr := queueClient.DeleteMessage(message.Receipt)
 			if delErr != nil {
 				log.Ctx(ctx).Error().Err(delErr).
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
+)
+
+const (
+	apiKeyIdLength     = 8
+	apiKeySecretLength = 32
+)
+
+type ApiKeyPublic struct {
+	Id string `json:"id"`
+	// Only returned on issuance
+	Key           string   `json:"key,omitempty"`
+	Owner         string   `json:"owner"`
+	Tier          string   `json:"tier"`
+	AllowedRoutes []string `json:"allowed_routes"`
+	ExpiresAt     string   `json:"expires_at,omitempty"`
+	CreatedAt     string   `json:"created_at"`
+}
+
+func hashApiKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// IssueApiKey generates a new api key, only its hash is stored hence the key
+// can't be retrieved afterwards. A zero expiry never expires.
+func (s *Services) IssueApiKey(
+	ctx context.Context, owner, tier string, allowedRoutes []string, expiresAt int64,
+) (*ApiKeyPublic, *types.Error) {
+	id, err := generateRandomHex(apiKeyIdLength)
+	if err != nil {
+		return nil, types.NewInternalServiceError(err)
+	}
+	key, err := generateRandomHex(apiKeySecretLength)
+	if err != nil {
+		return nil, types.NewInternalServiceError(err)
+	}
+	if allowedRoutes == nil {
+		allowedRoutes = []string{}
+	}
+
+	apiKey := &model.ApiKeyDocument{
+		Id:            id,
+		KeyHash:       hashApiKey(key),
+		Owner:         owner,
+		Tier:          tier,
+		AllowedRoutes: allowedRoutes,
+		ExpiresAt:     expiresAt,
+		CreatedAt:     time.Now().Unix(),
+	}
+	if err := s.DbClient.SaveApiKey(ctx, apiKey); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while saving api key")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	apiKeyPublic := &ApiKeyPublic{
+		Id:            apiKey.Id,
+		Key:           key,
+		Owner:         apiKey.Owner,
+		Tier:          apiKey.Tier,
+		AllowedRoutes: apiKey.AllowedRoutes,
+		CreatedAt:     utils.ParseTimestampToIsoFormat(apiKey.CreatedAt),
+	}
+	if apiKey.ExpiresAt != 0 {
+		apiKeyPublic.ExpiresAt = utils.ParseTimestampToIsoFormat(apiKey.ExpiresAt)
+	}
+	return apiKeyPublic, nil
+}
+
+func (s *Services) RevokeApiKey(ctx context.Context, id string) *types.Error {
+	if err := s.DbClient.RevokeApiKey(ctx, id, time.Now().Unix()); err != nil {
+		if db.IsNotFoundError(err) {
+			return types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "api key not found or already revoked")
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while revoking api key")
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
+
+// AuthenticateApiKey returns the caller of the api key if the key is valid and
+// allowed to call the path
+func (s *Services) AuthenticateApiKey(ctx context.Context, key, path string) (*types.ApiCaller, *types.Error) {
+	apiKey, err := s.DbClient.FindApiKeyByHash(ctx, hashApiKey(key))
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return nil, types.NewErrorWithMsg(http.StatusUnauthorized, types.Unauthorized, "invalid api key")
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching api key")
+		return nil, types.NewInternalServiceError(err)
+	}
+	if apiKey.RevokedAt != 0 {
+		return nil, types.NewErrorWithMsg(http.StatusUnauthorized, types.Unauthorized, "api key has been revoked")
+	}
+	if apiKey.ExpiresAt != 0 && apiKey.ExpiresAt <= time.Now().Unix() {
+		return nil, types.NewErrorWithMsg(http.StatusUnauthorized, types.Unauthorized, "api key has expired")
+	}
+	if !isRouteAllowed(apiKey.AllowedRoutes, path) {
+		return nil, types.NewErrorWithMsg(http.StatusForbidden, types.Forbidden, "api key is not allowed to call this route")
+	}
+	return &types.ApiCaller{
+		KeyId: apiKey.Id,
+		Owner: apiKey.Owner,
+		Tier:  apiKey.Tier,
+	}, nil
+}
+
+func isRouteAllowed(allowedRoutes []string, path string) bool {
+	if len(allowedRoutes) == 0 {
+		return true
+	}
+	for _, route := range allowedRoutes {
+		if prefix, ok := strings.CutSuffix(route, "*"); ok {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+		} else if route == path {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/babylonchain/staking-api-service/internal/config"
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+// CacheGroup is a set of cached responses invalidated together whenever
+// their data is written
+type CacheGroup string
+
+const (
+	OverallStatsCacheGroup      CacheGroup = "overall_stats"
+	FinalityProvidersCacheGroup CacheGroup = "finality_providers"
+	GlobalParamsCacheGroup      CacheGroup = "global_params"
+)
+
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// memoryResponseCache holds the cached responses of a single service replica
+type memoryResponseCache struct {
+	mu     sync.Mutex
+	groups map[CacheGroup]map[string]*memoryCacheEntry
+}
+
+func newMemoryResponseCache() *memoryResponseCache {
+	return &memoryResponseCache{
+		groups: make(map[CacheGroup]map[string]*memoryCacheEntry),
+	}
+}
+
+func (m *memoryResponseCache) get(group CacheGroup, key string, now time.Time) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.groups[group][key]
+	if !ok {
+		return nil, false
+	}
+	if !now.Before(entry.expiresAt) {
+		delete(m.groups[group], key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (m *memoryResponseCache) set(group CacheGroup, key string, value []byte, expiresAt time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.groups[group] == nil {
+		m.groups[group] = make(map[string]*memoryCacheEntry)
+	}
+	m.groups[group][key] = &memoryCacheEntry{value: value, expiresAt: expiresAt}
+}
+
+func (m *memoryResponseCache) invalidate(group CacheGroup) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.groups, group)
+}
+
+func responseCacheKey(group CacheGroup, key string) string {
+	return string(group) + ":" + key
+}
+
+// getCachedResponse looks up the cached value of the key within the group.
+// The cache is best effort, its errors are logged and treated as a miss.
+func (s *Services) getCachedResponse(ctx context.Context, group CacheGroup, key string, value interface{}) bool {
+	var data []byte
+	if s.cfg.Cache.Backend == config.CacheMongoBackend {
+		entry, err := s.DbClient.FindResponseCacheEntry(ctx, responseCacheKey(group, key), time.Now())
+		if err != nil {
+			if !db.IsNotFoundError(err) {
+				log.Ctx(ctx).Warn().Err(err).Str("cacheGroup", string(group)).Msg("error while fetching cached response")
+			}
+			return false
+		}
+		data = entry.Value
+	} else {
+		var ok bool
+		data, ok = s.responseCache.get(group, key, time.Now())
+		if !ok {
+			return false
+		}
+	}
+
+	if err := json.Unmarshal(data, value); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("cacheGroup", string(group)).Msg("error while decoding cached response")
+		return false
+	}
+	return true
+}
+
+func (s *Services) setCachedResponse(ctx context.Context, group CacheGroup, key string, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("cacheGroup", string(group)).Msg("error while encoding response to cache")
+		return
+	}
+
+	expiresAt := time.Now().Add(s.cfg.Cache.Ttl)
+	if s.cfg.Cache.Backend == config.CacheMongoBackend {
+		err := s.DbClient.SaveResponseCacheEntry(ctx, &model.ResponseCacheDocument{
+			Key:       responseCacheKey(group, key),
+			Group:     string(group),
+			Value:     data,
+			ExpiresAt: expiresAt,
+		})
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("cacheGroup", string(group)).Msg("error while caching response")
+		}
+		return
+	}
+	s.responseCache.set(group, key, data, expiresAt)
+}
+
+// invalidateCache drops the cached responses of the groups, it shall be
+// called whenever their data is written. A failed invalidation is logged,
+// the responses are then served stale until they expire.
+func (s *Services) invalidateCache(ctx context.Context, groups ...CacheGroup) {
+	if s.cfg.Cache == nil {
+		return
+	}
+	for _, group := range groups {
+		if s.cfg.Cache.Backend == config.CacheMongoBackend {
+			if err := s.DbClient.DeleteResponseCacheGroup(ctx, string(group)); err != nil {
+				log.Ctx(ctx).Error().Err(err).Str("cacheGroup", string(group)).Msg("error while invalidating cache")
+			}
+			continue
+		}
+		s.responseCache.invalidate(group)
+	}
+}
+
+// withCache serves the value of the key within the group from the cache if
+// enabled, or loads and caches it. The value is only cached if the loader
+// deems it cacheable, e.g. not a fallback served while the db is unavailable.
+func withCache[T any](
+	ctx context.Context, s *Services, group CacheGroup, key string,
+	load func() (T, bool, *types.Error),
+) (T, *types.Error) {
+	if s.cfg.Cache == nil {
+		value, _, err := load()
+		return value, err
+	}
+
+	var cached T
+	if s.getCachedResponse(ctx, group, key, &cached) {
+		return cached, nil
+	}
+	value, cacheable, err := load()
+	if err != nil {
+		return value, err
+	}
+	if cacheable {
+		s.setCachedResponse(ctx, group, key, value)
+	}
+	return value, nil
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+
+	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
+)
+
+// ConfigFileInfoPublic describes the currently loaded version of a config file
+type ConfigFileInfoPublic struct {
+	// Hex encoded sha256 of the file content
+	Hash     string `json:"hash"`
+	LoadedAt string `json:"loaded_at"`
+}
+
+type loadedGlobalParams struct {
+	params *types.GlobalParams
+	// nil if the params are not loaded from a file
+	fileInfo *ConfigFileInfoPublic
+}
+
+type loadedFinalityProviders struct {
+	finalityProviders []types.FinalityProviderDetails
+	// nil if the finality providers are not loaded from a file
+	fileInfo *ConfigFileInfoPublic
+}
+
+func (s *Services) getGlobalParams() *types.GlobalParams {
+	return s.params.Load().params
+}
+
+func (s *Services) getFinalityProviders() []types.FinalityProviderDetails {
+	return s.finalityProviders.Load().finalityProviders
+}
+
+// GetGlobalParamsFileInfo returns the loaded global params file version, nil
+// if the params are not loaded from a file.
+func (s *Services) GetGlobalParamsFileInfo() *ConfigFileInfoPublic {
+	return s.params.Load().fileInfo
+}
+
+// GetFinalityProvidersFileInfo returns the loaded finality providers file
+// version, nil if the finality providers are not loaded from a file.
+func (s *Services) GetFinalityProvidersFileInfo() *ConfigFileInfoPublic {
+	return s.finalityProviders.Load().fileInfo
+}
+
+// ReloadGlobalParams parses the global params file and swaps it in if the
+// content has changed. The current params are kept if the new content is invalid.
+func (s *Services) ReloadGlobalParams(filePath string) (bool, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return false, err
+	}
+	fileInfo := newConfigFileInfo(data)
+	if current := s.GetGlobalParamsFileInfo(); current != nil && current.Hash == fileInfo.Hash {
+		return false, nil
+	}
+	params, err := types.NewGlobalParamsFromBytes(data)
+	if err != nil {
+		return false, err
+	}
+	s.params.Store(&loadedGlobalParams{params: params, fileInfo: fileInfo})
+	s.invalidateCache(context.Background(), GlobalParamsCacheGroup)
+	return true, nil
+}
+
+// ReloadFinalityProviders parses the finality providers file and swaps it in if
+// the content has changed. The current finality providers are kept if the new
+// content is invalid.
+func (s *Services) ReloadFinalityProviders(filePath string) (bool, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return false, err
+	}
+	fileInfo := newConfigFileInfo(data)
+	if current := s.GetFinalityProvidersFileInfo(); current != nil && current.Hash == fileInfo.Hash {
+		return false, nil
+	}
+	finalityProviders, err := types.NewFinalityProvidersFromBytes(data)
+	if err != nil {
+		return false, err
+	}
+	// An empty list is most likely a truncated or malformed file
+	if len(finalityProviders) == 0 {
+		return false, errors.New("no finality providers found in the file")
+	}
+	s.finalityProviders.Store(&loadedFinalityProviders{
+		finalityProviders: finalityProviders, fileInfo: fileInfo,
+	})
+	s.invalidateCache(context.Background(), FinalityProvidersCacheGroup)
+	return true, nil
+}
+
+// StartConfigWatcher loads the global params and finality providers files and
+// reloads them whenever the files change or a SIGHUP is received, until the
+// context is cancelled.
+func (s *Services) StartConfigWatcher(ctx context.Context, globalParamsPath, finalityProvidersPath string) error {
+	if _, err := s.ReloadGlobalParams(globalParamsPath); err != nil {
+		return err
+	}
+	if _, err := s.ReloadFinalityProviders(finalityProvidersPath); err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	// Watch the directories instead of the files, as the files are usually
+	// replaced rather than written in place (e.g. by editors or k8s configmaps)
+	watchedDirs := make(map[string]bool)
+	for _, path := range []string{globalParamsPath, finalityProvidersPath} {
+		dir := filepath.Dir(path)
+		if watchedDirs[dir] {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return err
+		}
+		watchedDirs[dir] = true
+	}
+
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	log.Info().Msg("Initiated Config Watcher")
+
+	go func() {
+		defer watcher.Close()
+		defer signal.Stop(sighup)
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info().Msg("Stopping Config Watcher")
+				return
+			case <-sighup:
+				log.Info().Msg("SIGHUP received, reloading config files")
+				s.reloadConfigFiles(globalParamsPath, finalityProvidersPath)
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) || event.Has(fsnotify.Rename) {
+					// Unchanged files are skipped by comparing the content hash
+					s.reloadConfigFiles(globalParamsPath, finalityProvidersPath)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Error().Err(err).Msg("error while watching config files")
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (s *Services) reloadConfigFiles(globalParamsPath, finalityProvidersPath string) {
+	reloaded, err := s.ReloadGlobalParams(globalParamsPath)
+	if err != nil {
+		log.Error().Err(err).Str("path", globalParamsPath).
+			Msg("failed to reload global params, keeping the current version")
+	} else if reloaded {
+		log.Info().Str("hash", s.GetGlobalParamsFileInfo().Hash).Msg("global params reloaded")
+	}
+
+	reloaded, err = s.ReloadFinalityProviders(finalityProvidersPath)
+	if err != nil {
+		log.Error().Err(err).Str("path", finalityProvidersPath).
+			Msg("failed to reload finality providers, keeping the current version")
+	} else if reloaded {
+		log.Info().Str("hash", s.GetFinalityProvidersFileInfo().Hash).Msg("finality providers reloaded")
+	}
+}
+
+func newConfigFileInfo(data []byte) *ConfigFileInfoPublic {
+	hash := sha256.Sum256(data)
+	return &ConfigFileInfoPublic{
+		Hash:     hex.EncodeToString(hash[:]),
+		LoadedAt: utils.ParseTimestampToIsoFormat(time.Now().Unix()),
+	}
+}
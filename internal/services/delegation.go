@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/babylonchain/staking-api-service/internal/db"
 	"github.com/babylonchain/staking-api-service/internal/db/model"
@@ -28,6 +29,22 @@ type DelegationPublic struct {
 	StakingTx             *TransactionPublic `json:"staking_tx"`
 	UnbondingTx           *TransactionPublic `json:"unbonding_tx,omitempty"`
 	IsOverflow            bool               `json:"is_overflow"`
+	// The btc height at which the timelock of the unbonding tx, or of the
+	// staking tx if not unbonded early, expires
+	ExpireHeight uint64 `json:"expire_height"`
+	// Only populated once the latest btc height is known
+	BlocksRemaining     *uint64 `json:"blocks_remaining,omitempty"`
+	EstimatedUnlockTime string  `json:"estimated_unlock_time,omitempty"`
+	// Only populated if the timeline is requested
+	Timeline []DelegationStateHistoryPublic `json:"timeline,omitempty"`
+}
+
+type DelegationStateHistoryPublic struct {
+	PreviousState string `json:"previous_state"`
+	NewState      string `json:"new_state"`
+	BtcHeight     uint64 `json:"btc_height"`
+	Timestamp     string `json:"timestamp"`
+	Source        string `json:"source"`
 }
 
 func FromDelegationDocument(d *model.DelegationDocument) DelegationPublic {
@@ -44,7 +61,8 @@ func FromDelegationDocument(d *model.DelegationDocument) DelegationPublic {
 			StartHeight:    d.StakingTx.StartHeight,
 			TimeLock:       d.StakingTx.TimeLock,
 		},
-		IsOverflow: d.IsOverflow,
+		IsOverflow:   d.IsOverflow,
+		ExpireHeight: d.StakingTx.StartHeight + d.StakingTx.TimeLock,
 	}
 
 	// Add unbonding transaction if it exists
@@ -56,12 +74,43 @@ func FromDelegationDocument(d *model.DelegationDocument) DelegationPublic {
 			StartHeight:    d.UnbondingTx.StartHeight,
 			TimeLock:       d.UnbondingTx.TimeLock,
 		}
+		delPublic.ExpireHeight = d.UnbondingTx.StartHeight + d.UnbondingTx.TimeLock
 	}
 	return delPublic
 }
 
-func (s *Services) DelegationsByStakerPk(ctx context.Context, stakerPk string, pageToken string) ([]DelegationPublic, string, *types.Error) {
-	resultMap, err := s.DbClient.FindDelegationsByStakerPk(ctx, stakerPk, pageToken)
+// SetDelegationsExpiry fills the blocks remaining until the timelock of the
+// delegations expires, and the estimated unlock time, from the latest btc
+// height. They are left empty if the latest btc height is not available yet.
+func (s *Services) SetDelegationsExpiry(ctx context.Context, delegations []DelegationPublic) {
+	if len(delegations) == 0 {
+		return
+	}
+	btcInfo, err := s.DbClient.GetLatestBtcInfo(ctx)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("latest btc info not found")
+		} else {
+			log.Ctx(ctx).Error().Err(err).Msg("error while fetching latest btc info")
+		}
+		return
+	}
+	now := time.Now()
+	for i := range delegations {
+		blocksRemaining := uint64(0)
+		if delegations[i].ExpireHeight > btcInfo.BtcHeight {
+			blocksRemaining = delegations[i].ExpireHeight - btcInfo.BtcHeight
+			delegations[i].EstimatedUnlockTime = estimateBtcHeightTime(now, blocksRemaining)
+		}
+		delegations[i].BlocksRemaining = &blocksRemaining
+	}
+}
+
+func (s *Services) DelegationsByStakerPk(
+	ctx context.Context, stakerPk string, filter *db.DelegationFilter,
+	sortOrder types.SortOrder, pageToken string,
+) ([]DelegationPublic, string, *types.Error) {
+	resultMap, err := s.DbClient.FindDelegationsByStakerPk(ctx, stakerPk, filter, sortOrder, pageToken)
 	if err != nil {
 		if db.IsInvalidPaginationTokenError(err) {
 			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching delegations by staker pk")
@@ -74,6 +123,7 @@ func (s *Services) DelegationsByStakerPk(ctx context.Context, stakerPk string, p
 	for _, d := range resultMap.Data {
 		delegations = append(delegations, FromDelegationDocument(&d))
 	}
+	s.SetDelegationsExpiry(ctx, delegations)
 	return delegations, resultMap.PaginationToken, nil
 }
 
@@ -83,16 +133,16 @@ func (s *Services) SaveActiveStakingDelegation(
 	value, startHeight uint64, stakingTimestamp int64, timeLock, stakingOutputIndex uint64,
 	stakingTxHex string, isOverflow bool,
 ) *types.Error {
-	taprootAddress, err := utils.GetTaprootAddressFromPk(stakerPkHex, s.cfg.Server.BTCNetParam)
+	stakerBtcAddress, err := model.NewStakerBtcAddress(stakerPkHex, s.cfg.Server.BTCNetParam)
 	if err != nil {
-		log.Ctx(ctx).Error().Err(err).Msg("Failed to get taproot address from staker pk")
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to derive btc addresses from staker pk")
 		return types.NewErrorWithMsg(
-			http.StatusBadRequest, types.BadRequest, "failed to get taproot address from staker pk",
+			http.StatusBadRequest, types.BadRequest, "failed to derive btc addresses from staker pk",
 		)
 	}
 	err = s.DbClient.SaveActiveStakingDelegation(
 		ctx, txHashHex, stakerPkHex, finalityProviderPkHex, stakingTxHex,
-		value, startHeight, timeLock, stakingOutputIndex, stakingTimestamp, isOverflow, taprootAddress,
+		value, startHeight, timeLock, stakingOutputIndex, stakingTimestamp, isOverflow, stakerBtcAddress,
 	)
 	if err != nil {
 		if ok := db.IsDuplicateKeyError(err); ok {
@@ -134,6 +184,51 @@ func (s *Services) GetDelegation(ctx context.Context, txHashHex string) (*model.
 	return delegation, nil
 }
 
+// DelegationsLookupPublic is the result of a bulk delegation lookup, the
+// unknown staking tx hashes are listed as not found
+type DelegationsLookupPublic struct {
+	Found    []DelegationPublic `json:"found"`
+	NotFound []string           `json:"not_found"`
+}
+
+// LookupDelegations resolves the delegations of the staking tx hashes in a
+// single query. The results follow the order of the tx hashes, the duplicates
+// are only reported once.
+func (s *Services) LookupDelegations(ctx context.Context, txHashHexes []string) (*DelegationsLookupPublic, *types.Error) {
+	uniqueTxHashHexes := make([]string, 0, len(txHashHexes))
+	seen := make(map[string]bool, len(txHashHexes))
+	for _, txHashHex := range txHashHexes {
+		if !seen[txHashHex] {
+			seen[txHashHex] = true
+			uniqueTxHashHexes = append(uniqueTxHashHexes, txHashHex)
+		}
+	}
+
+	delegations, err := s.DbClient.FindDelegationsByTxHashHexes(ctx, uniqueTxHashHexes)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegations by tx hash hexes")
+		return nil, types.NewInternalServiceError(err)
+	}
+	delegationsByTxHash := make(map[string]*model.DelegationDocument, len(delegations))
+	for i := range delegations {
+		delegationsByTxHash[delegations[i].StakingTxHashHex] = &delegations[i]
+	}
+
+	result := &DelegationsLookupPublic{
+		Found:    []DelegationPublic{},
+		NotFound: []string{},
+	}
+	for _, txHashHex := range uniqueTxHashHexes {
+		if delegation, ok := delegationsByTxHash[txHashHex]; ok {
+			result.Found = append(result.Found, FromDelegationDocument(delegation))
+		} else {
+			result.NotFound = append(result.NotFound, txHashHex)
+		}
+	}
+	s.SetDelegationsExpiry(ctx, result.Found)
+	return result, nil
+}
+
 func (s *Services) CheckStakerHasActiveDelegationByAddress(
 	ctx context.Context, btcAddress string, afterTimestamp int64,
 ) (bool, *types.Error) {
@@ -141,7 +236,7 @@ func (s *Services) CheckStakerHasActiveDelegationByAddress(
 		States:         []types.DelegationState{types.Active},
 		AfterTimestamp: afterTimestamp,
 	}
-	hasDelegation, err := s.DbClient.CheckDelegationExistByStakerTaprootAddress(
+	hasDelegation, err := s.DbClient.CheckDelegationExistByStakerAddress(
 		ctx, btcAddress, filter,
 	)
 	if err != nil {
@@ -150,3 +245,42 @@ func (s *Services) CheckStakerHasActiveDelegationByAddress(
 	}
 	return hasDelegation, nil
 }
+
+// GetStakerPkByAddress resolves the staker public key from any of the BTC
+// address forms derived from it. An empty string is returned if the address
+// has never been used for staking.
+func (s *Services) GetStakerPkByAddress(ctx context.Context, btcAddress string) (string, *types.Error) {
+	stakerPkHex, err := s.DbClient.FindStakerPkByAddress(ctx, btcAddress)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return "", nil
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find staker pk by address")
+		return "", types.NewInternalServiceError(err)
+	}
+	return stakerPkHex, nil
+}
+
+// GetDelegationStateHistory returns the recorded state transitions of the
+// delegation in the order they happened.
+func (s *Services) GetDelegationStateHistory(
+	ctx context.Context, stakingTxHashHex string,
+) ([]DelegationStateHistoryPublic, *types.Error) {
+	history, err := s.DbClient.FindDelegationStateHistory(ctx, stakingTxHashHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("stakingTxHashHex", stakingTxHashHex).
+			Msg("Failed to find delegation state history")
+		return nil, types.NewInternalServiceError(err)
+	}
+	timeline := make([]DelegationStateHistoryPublic, 0, len(history))
+	for _, h := range history {
+		timeline = append(timeline, DelegationStateHistoryPublic{
+			PreviousState: h.PreviousState.ToString(),
+			NewState:      h.NewState.ToString(),
+			BtcHeight:     h.BtcHeight,
+			Timestamp:     utils.ParseTimestampToIsoFormat(h.Timestamp),
+			Source:        h.Source.ToString(),
+		})
+	}
+	return timeline, nil
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+)
+
+const (
+	// delegationStreamRetryInterval is the wait before the change stream is
+	// reopened after a failure
+	delegationStreamRetryInterval = 5 * time.Second
+	// delegationSubscriberBufferSize is the number of pending events kept for
+	// a subscriber, further events are dropped until the subscriber catches up
+	delegationSubscriberBufferSize = 32
+)
+
+// delegationSubscribers fans out the delegation changes to the subscribers of
+// the staker of the delegation
+type delegationSubscribers struct {
+	mu         sync.RWMutex
+	byStakerPk map[string]map[chan DelegationPublic]struct{}
+	// Closed once the first subscriber arrives
+	firstSubscribed     chan struct{}
+	firstSubscribedOnce sync.Once
+}
+
+func newDelegationSubscribers() *delegationSubscribers {
+	return &delegationSubscribers{
+		byStakerPk:      make(map[string]map[chan DelegationPublic]struct{}),
+		firstSubscribed: make(chan struct{}),
+	}
+}
+
+func (d *delegationSubscribers) subscribe(stakerPkHex string) chan DelegationPublic {
+	d.firstSubscribedOnce.Do(func() {
+		close(d.firstSubscribed)
+	})
+	ch := make(chan DelegationPublic, delegationSubscriberBufferSize)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.byStakerPk[stakerPkHex] == nil {
+		d.byStakerPk[stakerPkHex] = make(map[chan DelegationPublic]struct{})
+	}
+	d.byStakerPk[stakerPkHex][ch] = struct{}{}
+	return ch
+}
+
+func (d *delegationSubscribers) unsubscribe(stakerPkHex string, ch chan DelegationPublic) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.byStakerPk[stakerPkHex], ch)
+	if len(d.byStakerPk[stakerPkHex]) == 0 {
+		delete(d.byStakerPk, stakerPkHex)
+	}
+}
+
+func (d *delegationSubscribers) publish(delegation *model.DelegationDocument) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	subscribers := d.byStakerPk[delegation.StakerPkHex]
+	if len(subscribers) == 0 {
+		return
+	}
+	event := FromDelegationDocument(delegation)
+	for ch := range subscribers {
+		select {
+		case ch <- event:
+		default:
+			log.Warn().Str("stakerPkHex", delegation.StakerPkHex).
+				Str("stakingTxHashHex", delegation.StakingTxHashHex).
+				Msg("delegation stream subscriber is lagging behind, dropping event")
+		}
+	}
+}
+
+// SubscribeStakerDelegations returns a channel receiving the latest version of
+// the staker's delegations whenever they are changed, and a function to
+// unsubscribe once the subscriber is gone.
+func (s *Services) SubscribeStakerDelegations(stakerPkHex string) (<-chan DelegationPublic, func()) {
+	ch := s.delegationSubscribers.subscribe(stakerPkHex)
+	return ch, func() {
+		s.delegationSubscribers.unsubscribe(stakerPkHex, ch)
+	}
+}
+
+// StartDelegationStream watches the delegation changes in the database and
+// publishes them to the subscribers. The change stream is only opened once the
+// first subscriber arrives, and is resumed after the last observed change if it
+// fails, until the context is cancelled.
+func (s *Services) StartDelegationStream(ctx context.Context) {
+	go func() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.delegationSubscribers.firstSubscribed:
+		}
+
+		var resumeToken bson.Raw
+		for {
+			var err error
+			resumeToken, err = s.DbClient.WatchDelegations(ctx, resumeToken, s.delegationSubscribers.publish)
+			if ctx.Err() != nil {
+				return
+			}
+			log.Ctx(ctx).Error().Err(err).Msg("delegation change stream stopped, retrying")
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delegationStreamRetryInterval):
+			}
+		}
+	}()
+}
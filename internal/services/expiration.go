@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// btcBlockTime is the average time between two btc blocks, used to estimate
+// when an upcoming btc height will be reached
+const btcBlockTime = 10 * time.Minute
+
+// estimateBtcHeightTime returns the estimated time in ISO format at which the
+// btc height the given number of blocks away will be reached
+func estimateBtcHeightTime(now time.Time, blocks uint64) string {
+	return utils.ParseTimestampToIsoFormat(now.Add(time.Duration(blocks) * btcBlockTime).Unix())
+}
+
+type ExpirationBucketPublic struct {
+	StartHeight uint64 `json:"start_height"`
+	EndHeight   uint64 `json:"end_height"`
+	// Estimated time at which the end height is reached
+	EstimatedUnlockTime string `json:"estimated_unlock_time"`
+	Delegations         uint64 `json:"delegations"`
+	StakingValue        uint64 `json:"staking_value"`
+}
+
+// ExpirationsForecastPublic is the staking value unlocked by the timelock
+// expiries within the upcoming btc blocks, bucketed by btc height
+type ExpirationsForecastPublic struct {
+	BtcHeight         uint64                   `json:"btc_height"`
+	WithinBlocks      uint64                   `json:"within_blocks"`
+	BucketBlocks      uint64                   `json:"bucket_blocks"`
+	TotalDelegations  uint64                   `json:"total_delegations"`
+	TotalStakingValue uint64                   `json:"total_staking_value"`
+	Buckets           []ExpirationBucketPublic `json:"buckets"`
+}
+
+// GetExpirationsForecast returns the delegations and staking value to be
+// unlocked by the timelock expiries within the blocks following the latest
+// btc height. An expiry is only counted if the delegation is still in a state
+// that transitions to unbonded once it happens.
+func (s *Services) GetExpirationsForecast(
+	ctx context.Context, withinBlocks, bucketBlocks uint64,
+) (*ExpirationsForecastPublic, *types.Error) {
+	btcInfo, err := s.DbClient.GetLatestBtcInfo(ctx)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("latest btc info not found")
+			return nil, types.NewErrorWithMsg(
+				http.StatusNotFound, types.NotFound, "latest btc height is not available yet",
+			)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching latest btc info")
+		return nil, types.NewInternalServiceError(err)
+	}
+	height := btcInfo.BtcHeight
+
+	expirationBuckets, err := s.DbClient.FindTimeLockExpirationBuckets(
+		ctx, height, height+withinBlocks, bucketBlocks,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find timelock expiration buckets")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	now := time.Now()
+	forecast := &ExpirationsForecastPublic{
+		BtcHeight:    height,
+		WithinBlocks: withinBlocks,
+		BucketBlocks: bucketBlocks,
+		Buckets:      []ExpirationBucketPublic{},
+	}
+	for start := height + 1; start <= height+withinBlocks; start += bucketBlocks {
+		end := min(start+bucketBlocks-1, height+withinBlocks)
+		forecast.Buckets = append(forecast.Buckets, ExpirationBucketPublic{
+			StartHeight:         start,
+			EndHeight:           end,
+			EstimatedUnlockTime: estimateBtcHeightTime(now, end-height),
+		})
+	}
+
+	for _, expirationBucket := range expirationBuckets {
+		bucket := &forecast.Buckets[expirationBucket.Index]
+		bucket.Delegations = expirationBucket.Delegations
+		bucket.StakingValue = expirationBucket.StakingValue
+		forecast.TotalDelegations += expirationBucket.Delegations
+		forecast.TotalStakingValue += expirationBucket.StakingValue
+	}
+	return forecast, nil
+}
@@ -34,6 +34,15 @@ type FpDetailsPublic struct {
 	TotalTvl          int64                `json:"total_tvl"`
 	ActiveDelegations int64                `json:"active_delegations"`
 	TotalDelegations  int64                `json:"total_delegations"`
+	ActiveStakers     int64                `json:"active_stakers"`
+	TotalStakers      int64                `json:"total_stakers"`
+}
+
+// FpDetailsWithDelegationsPublic is the detailed view of a single finality
+// provider together with a page of its delegations.
+type FpDetailsWithDelegationsPublic struct {
+	*FpDetailsPublic
+	Delegations []DelegationPublic `json:"delegations"`
 }
 
 type FpParamsPublic struct {
@@ -46,7 +55,7 @@ type FpParamsPublic struct {
 // Those FP are treated as "active" finality providers.
 func (s *Services) GetFinalityProvidersFromGlobalParams() []*FpParamsPublic {
 	var fpDetails []*FpParamsPublic
-	for _, finalityProvider := range s.finalityProviders {
+	for _, finalityProvider := range s.getFinalityProviders() {
 		description := &FpDescriptionPublic{
 			Moniker:         finalityProvider.Description.Moniker,
 			Identity:        finalityProvider.Description.Identity,
@@ -63,11 +72,37 @@ func (s *Services) GetFinalityProvidersFromGlobalParams() []*FpParamsPublic {
 	return fpDetails
 }
 
+// finalityProvidersPage is a page of finality providers as cached
+type finalityProvidersPage struct {
+	FinalityProviders []*FpDetailsPublic `json:"finality_providers"`
+	PaginationToken   string             `json:"pagination_token"`
+}
+
+// GetFinalityProviders returns a page of finality providers sorted by their
+// active tvl, served from the cache if enabled
 func (s *Services) GetFinalityProviders(ctx context.Context, page string) ([]*FpDetailsPublic, string, *types.Error) {
+	result, err := withCache(
+		ctx, s, FinalityProvidersCacheGroup, page,
+		func() (*finalityProvidersPage, bool, *types.Error) {
+			fps, paginationToken, cacheable, err := s.loadFinalityProviders(ctx, page)
+			return &finalityProvidersPage{FinalityProviders: fps, PaginationToken: paginationToken}, cacheable, err
+		},
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	return result.FinalityProviders, result.PaginationToken, nil
+}
+
+// loadFinalityProviders returns a page of finality providers and whether it
+// can be cached, the fallback served while the db is unavailable can't.
+func (s *Services) loadFinalityProviders(
+	ctx context.Context, page string,
+) ([]*FpDetailsPublic, string, bool, *types.Error) {
 	fpParams := s.GetFinalityProvidersFromGlobalParams()
 	if len(fpParams) == 0 {
 		log.Ctx(ctx).Error().Msg("No finality providers found from global params")
-		return nil, "", types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "No finality providers found from global params")
+		return nil, "", false, types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "No finality providers found from global params")
 	}
 	// Convert the fpParams slice to a map with the BtcPk as the key
 	fpParamsMap := make(map[string]*FpParamsPublic)
@@ -79,14 +114,14 @@ func (s *Services) GetFinalityProviders(ctx context.Context, page string) ([]*Fp
 	if err != nil {
 		if db.IsInvalidPaginationTokenError(err) {
 			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching finality providers")
-			return nil, "", types.NewError(http.StatusBadRequest, types.BadRequest, err)
+			return nil, "", false, types.NewError(http.StatusBadRequest, types.BadRequest, err)
 		}
 		// We don't want to return an error here in case of DB error.
 		// we will continue the process with the data we have from global params as a fallback.
 		// TODO: Add metric for this error and alerting
 		log.Ctx(ctx).Error().Err(err).Msg("Error while fetching finality providers from DB")
 		// Return the finality providers from global params as a fallback
-		return buildFallbackFpDetailsPublic(fpParams), "", nil
+		return buildFallbackFpDetailsPublic(fpParams), "", false, nil
 	}
 
 	/*
@@ -96,7 +131,7 @@ func (s *Services) GetFinalityProviders(ctx context.Context, page string) ([]*Fp
 		launching the service for the first time and no finality providers are found in the database.
 	*/
 	if (len(resultMap.Data) == 0) && (page == "") {
-		return buildFallbackFpDetailsPublic(fpParams), "", nil
+		return buildFallbackFpDetailsPublic(fpParams), "", true, nil
 	}
 
 	var finalityProviderDetailsPublic []*FpDetailsPublic
@@ -120,6 +155,8 @@ func (s *Services) GetFinalityProviders(ctx context.Context, page string) ([]*Fp
 			TotalTvl:          fp.TotalTvl,
 			ActiveDelegations: fp.ActiveDelegations,
 			TotalDelegations:  fp.TotalDelegations,
+			ActiveStakers:     fp.ActiveStakers,
+			TotalStakers:      fp.TotalStakers,
 		}
 		finalityProviderDetailsPublic = append(finalityProviderDetailsPublic, detail)
 	}
@@ -128,13 +165,83 @@ func (s *Services) GetFinalityProviders(ctx context.Context, page string) ([]*Fp
 		fpsNotInUse, err := s.findRegisteredFinalityProvidersNotInUse(ctx, fpParams)
 		if err != nil {
 			log.Ctx(ctx).Error().Err(err).Msg("Error while fetching finality providers not in use")
-			return nil, "", types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
+			return nil, "", false, types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
 		}
 
 		finalityProviderDetailsPublic = append(finalityProviderDetailsPublic, fpsNotInUse...)
 	}
 
-	return finalityProviderDetailsPublic, resultMap.PaginationToken, nil
+	return finalityProviderDetailsPublic, resultMap.PaginationToken, true, nil
+}
+
+// GetFinalityProvider returns the details of the finality provider along with
+// a page of its delegations in the given states, sorted by the staking start
+// height in descending order. The finality provider shall either be registered
+// in the global params or have received at least one delegation.
+func (s *Services) GetFinalityProvider(
+	ctx context.Context, fpPkHex string, states []types.DelegationState, page string,
+) (*FpDetailsWithDelegationsPublic, string, *types.Error) {
+	var paramsPublic *FpParamsPublic
+	for _, fp := range s.GetFinalityProvidersFromGlobalParams() {
+		if fp.BtcPk == fpPkHex {
+			paramsPublic = fp
+			break
+		}
+	}
+
+	fpStats, err := s.DbClient.FindFinalityProviderStatsByFinalityProviderPkHex(ctx, []string{fpPkHex})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("fpPkHex", fpPkHex).Msg("Error while fetching finality provider stats")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+	if paramsPublic == nil {
+		if len(fpStats) == 0 {
+			return nil, "", types.NewErrorWithMsg(
+				http.StatusNotFound, types.NotFound, "finality provider not found",
+			)
+		}
+		paramsPublic = &FpParamsPublic{
+			Description: emptyFpDescriptionPublic,
+			Commission:  "",
+			BtcPk:       fpPkHex,
+		}
+	}
+
+	detail := &FpDetailsPublic{
+		Description: paramsPublic.Description,
+		Commission:  paramsPublic.Commission,
+		BtcPk:       fpPkHex,
+	}
+	if len(fpStats) > 0 {
+		detail.ActiveTvl = fpStats[0].ActiveTvl
+		detail.TotalTvl = fpStats[0].TotalTvl
+		detail.ActiveDelegations = fpStats[0].ActiveDelegations
+		detail.TotalDelegations = fpStats[0].TotalDelegations
+		detail.ActiveStakers = fpStats[0].ActiveStakers
+		detail.TotalStakers = fpStats[0].TotalStakers
+	}
+
+	resultMap, err := s.DbClient.FindDelegationsByFinalityProviderPk(
+		ctx, fpPkHex, &db.DelegationFilter{States: states}, page,
+	)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching delegations by finality provider pk")
+			return nil, "", types.NewError(http.StatusBadRequest, types.BadRequest, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Str("fpPkHex", fpPkHex).Msg("Failed to find delegations by finality provider pk")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+	delegations := make([]DelegationPublic, 0, len(resultMap.Data))
+	for _, d := range resultMap.Data {
+		delegations = append(delegations, FromDelegationDocument(&d))
+	}
+	s.SetDelegationsExpiry(ctx, delegations)
+
+	return &FpDetailsWithDelegationsPublic{
+		FpDetailsPublic: detail,
+		Delegations:     delegations,
+	}, resultMap.PaginationToken, nil
 }
 
 func (s *Services) findRegisteredFinalityProvidersNotInUse(
@@ -165,6 +272,8 @@ func (s *Services) findRegisteredFinalityProvidersNotInUse(
 				TotalTvl:          0,
 				ActiveDelegations: 0,
 				TotalDelegations:  0,
+				ActiveStakers:     0,
+				TotalStakers:      0,
 			}
 			fps = append(fps, detail)
 		}
@@ -183,6 +292,8 @@ func buildFallbackFpDetailsPublic(fpParams []*FpParamsPublic) []*FpDetailsPublic
 			TotalTvl:          0,
 			ActiveDelegations: 0,
 			TotalDelegations:  0,
+			ActiveStakers:     0,
+			TotalStakers:      0,
 		}
 		finalityProviderDetailsPublic = append(finalityProviderDetailsPublic, detail)
 	}
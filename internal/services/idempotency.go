@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+// idempotencyKeyTtl is how long the outcome of a request is replayed to the
+// requests retried with the same idempotency key
+const idempotencyKeyTtl = 24 * time.Hour
+
+// idempotencyKeyLease is how long a request holds its idempotency key while
+// being processed, the retries can take the key over once it has passed
+const idempotencyKeyLease = time.Minute
+
+func hashIdempotentRequest(request interface{}) (string, error) {
+	requestBytes, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(requestBytes)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// withIdempotencyKey processes the request once per idempotency key of the
+// caller within the scope, the requests retried with the same key get the
+// original outcome instead. The key is released if the processing fails with
+// an internal error so that it can be retried, and it can be taken over once
+// its lease expires if the processing has been abandoned. Reusing the key for
+// another request is rejected.
+func (s *Services) withIdempotencyKey(
+	ctx context.Context, scope, callerId, key string, request interface{},
+	process func(ctx context.Context) *types.Error,
+) *types.Error {
+	requestHash, err := hashIdempotentRequest(request)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to hash the idempotent request")
+		return types.NewInternalServiceError(err)
+	}
+	id := scope + ":" + callerId + ":" + key
+	lockedUntil := time.Now().Add(idempotencyKeyLease)
+	err = s.DbClient.SaveIdempotencyKey(ctx, &model.IdempotencyKeyDocument{
+		Id:          id,
+		RequestHash: requestHash,
+		LockedUntil: lockedUntil,
+		ExpiresAt:   time.Now().Add(idempotencyKeyTtl),
+	})
+	if err != nil {
+		if !db.IsDuplicateKeyError(err) {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to save the idempotency key")
+			return types.NewInternalServiceError(err)
+		}
+		takenOver, err := s.DbClient.TakeOverIdempotencyKey(ctx, id, requestHash, lockedUntil)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Failed to take over the idempotency key")
+			return types.NewInternalServiceError(err)
+		}
+		if !takenOver {
+			return s.replayIdempotentRequest(ctx, id, requestHash)
+		}
+	}
+
+	// Once the key is held, the request is processed and its outcome recorded
+	// even if the caller goes away, otherwise its retries would be rejected
+	// until the key expires or be processed again while already applied.
+	ctx = context.WithoutCancel(ctx)
+	processErr := process(ctx)
+	if processErr != nil && processErr.StatusCode >= http.StatusInternalServerError {
+		if err := s.DbClient.DeleteIdempotencyKey(ctx, id, lockedUntil); err != nil {
+			log.Ctx(ctx).Error().Err(err).Str("id", id).Msg("Failed to release the idempotency key")
+		}
+		return processErr
+	}
+
+	statusCode, errorCode, errorMessage := 0, "", ""
+	if processErr != nil {
+		statusCode = processErr.StatusCode
+		errorCode = processErr.ErrorCode.String()
+		errorMessage = processErr.Err.Error()
+	}
+	err = s.DbClient.CompleteIdempotencyKey(ctx, id, lockedUntil, statusCode, errorCode, errorMessage)
+	if err != nil {
+		// The request has been processed, hence its outcome is still returned.
+		// The retries are rejected as in progress until the lease expires.
+		log.Ctx(ctx).Error().Err(err).Str("id", id).Msg("Failed to record the outcome of the idempotent request")
+	}
+	return processErr
+}
+
+// replayIdempotentRequest returns the recorded outcome of the request
+// submitted with the idempotency key
+func (s *Services) replayIdempotentRequest(ctx context.Context, id, requestHash string) *types.Error {
+	idempotencyKey, err := s.DbClient.FindIdempotencyKey(ctx, id)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			// Released by a failed request in the meantime
+			return types.NewErrorWithMsg(
+				http.StatusConflict, types.Conflict,
+				"a request with the same idempotency key has just failed, please retry",
+			)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find the idempotency key")
+		return types.NewInternalServiceError(err)
+	}
+	if idempotencyKey.RequestHash != requestHash {
+		return types.NewErrorWithMsg(
+			http.StatusUnprocessableEntity, types.UnprocessableEntity,
+			"the idempotency key has already been used for another request",
+		)
+	}
+	if !idempotencyKey.Completed {
+		return types.NewErrorWithMsg(
+			http.StatusConflict, types.Conflict,
+			"a request with the same idempotency key is being processed",
+		)
+	}
+	if idempotencyKey.StatusCode == 0 {
+		return nil
+	}
+	return types.NewErrorWithMsg(
+		idempotencyKey.StatusCode, types.ErrorCode(idempotencyKey.ErrorCode), idempotencyKey.ErrorMessage,
+	)
+}
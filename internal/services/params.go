@@ -1,6 +1,12 @@
 package services
 
 import (
+	"context"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/babylonchain/staking-api-service/internal/db"
 	"github.com/babylonchain/staking-api-service/internal/types"
 )
 
@@ -25,42 +31,107 @@ type GlobalParamsPublic struct {
 	Versions []VersionedGlobalParamsPublic `json:"versions"`
 }
 
+// GlobalParamsAtHeightPublic describes the global params version applied at
+// the btc height and the next scheduled version, if any.
+type GlobalParamsAtHeightPublic struct {
+	BtcHeight uint64                       `json:"btc_height"`
+	Current   *VersionedGlobalParamsPublic `json:"current"`
+	Next      *VersionedGlobalParamsPublic `json:"next,omitempty"`
+	// Number of blocks until the next version activates, omitted if there is no next version
+	BlocksUntilNext uint64 `json:"blocks_until_next,omitempty"`
+}
+
 func (s *Services) GetGlobalParamsPublic() *GlobalParamsPublic {
 	var versionedParams []VersionedGlobalParamsPublic
-	for _, version := range s.params.Versions {
-		versionedParams = append(versionedParams, VersionedGlobalParamsPublic{
-			Version:           version.Version,
-			ActivationHeight:  version.ActivationHeight,
-			StakingCap:        version.StakingCap,
-			CapHeight:         version.CapHeight,
-			Tag:               version.Tag,
-			CovenantPks:       version.CovenantPks,
-			CovenantQuorum:    version.CovenantQuorum,
-			UnbondingTime:     version.UnbondingTime,
-			UnbondingFee:      version.UnbondingFee,
-			MaxStakingAmount:  version.MaxStakingAmount,
-			MinStakingAmount:  version.MinStakingAmount,
-			MaxStakingTime:    version.MaxStakingTime,
-			MinStakingTime:    version.MinStakingTime,
-			ConfirmationDepth: version.ConfirmationDepth,
-		})
+	for _, version := range s.getGlobalParams().Versions {
+		versionedParams = append(versionedParams, *toVersionedGlobalParamsPublic(version))
 	}
 	return &GlobalParamsPublic{
 		Versions: versionedParams,
 	}
 }
 
+// GetCachedGlobalParamsPublic returns the global params, served from the
+// cache if enabled
+func (s *Services) GetCachedGlobalParamsPublic(ctx context.Context) (*GlobalParamsPublic, *types.Error) {
+	return withCache(ctx, s, GlobalParamsCacheGroup, "", func() (*GlobalParamsPublic, bool, *types.Error) {
+		return s.GetGlobalParamsPublic(), true, nil
+	})
+}
+
+// GetGlobalParamsAtHeight returns the global params version applied at the
+// given btc height along with the next scheduled version.
+func (s *Services) GetGlobalParamsAtHeight(height uint64) *GlobalParamsAtHeightPublic {
+	current, next := findVersionedGlobalParams(s.getGlobalParams(), height)
+	result := &GlobalParamsAtHeightPublic{BtcHeight: height}
+	if current != nil {
+		result.Current = toVersionedGlobalParamsPublic(current)
+	}
+	if next != nil {
+		result.Next = toVersionedGlobalParamsPublic(next)
+		result.BlocksUntilNext = next.ActivationHeight - height
+	}
+	return result
+}
+
+// GetCurrentGlobalParams returns the global params version applied at the
+// latest btc height along with the next scheduled version.
+func (s *Services) GetCurrentGlobalParams(ctx context.Context) (*GlobalParamsAtHeightPublic, *types.Error) {
+	btcInfo, err := s.DbClient.GetLatestBtcInfo(ctx)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("latest btc info not found")
+			return nil, types.NewErrorWithMsg(
+				http.StatusNotFound, types.NotFound, "latest btc height is not available yet",
+			)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching latest btc info")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return s.GetGlobalParamsAtHeight(btcInfo.BtcHeight), nil
+}
+
 // GetVersionedGlobalParamsByHeight returns the versioned global params
 // for a particular bitcoin height
 func (s *Services) GetVersionedGlobalParamsByHeight(height uint64) *types.VersionedGlobalParams {
+	current, _ := findVersionedGlobalParams(s.getGlobalParams(), height)
+	return current
+}
+
+// findVersionedGlobalParams returns the params version applied at the btc
+// height and the next version to be activated after it. Either can be nil.
+func findVersionedGlobalParams(
+	params *types.GlobalParams, height uint64,
+) (*types.VersionedGlobalParams, *types.VersionedGlobalParams) {
 	// Iterate the list in reverse (i.e. decreasing ActivationHeight)
 	// and identify the first element that has an activation height below
 	// the specified BTC height.
-	for i := len(s.params.Versions) - 1; i >= 0; i-- {
-		paramsVersion := s.params.Versions[i]
+	var next *types.VersionedGlobalParams
+	for i := len(params.Versions) - 1; i >= 0; i-- {
+		paramsVersion := params.Versions[i]
 		if paramsVersion.ActivationHeight <= height {
-			return paramsVersion
+			return paramsVersion, next
 		}
+		next = paramsVersion
+	}
+	return nil, next
+}
+
+func toVersionedGlobalParamsPublic(version *types.VersionedGlobalParams) *VersionedGlobalParamsPublic {
+	return &VersionedGlobalParamsPublic{
+		Version:           version.Version,
+		ActivationHeight:  version.ActivationHeight,
+		StakingCap:        version.StakingCap,
+		CapHeight:         version.CapHeight,
+		Tag:               version.Tag,
+		CovenantPks:       version.CovenantPks,
+		CovenantQuorum:    version.CovenantQuorum,
+		UnbondingTime:     version.UnbondingTime,
+		UnbondingFee:      version.UnbondingFee,
+		MaxStakingAmount:  version.MaxStakingAmount,
+		MinStakingAmount:  version.MinStakingAmount,
+		MaxStakingTime:    version.MaxStakingTime,
+		MinStakingTime:    version.MinStakingTime,
+		ConfirmationDepth: version.ConfirmationDepth,
 	}
-	return nil
 }
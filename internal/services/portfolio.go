@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+// reportedDelegationStates are the states reported in the delegation totals,
+// even if there is no delegation in them
+var reportedDelegationStates = []types.DelegationState{
+	types.Active, types.UnbondingRequested, types.Unbonding, types.Unbonded, types.Withdrawn,
+}
+
+type PortfolioStatsPublic struct {
+	ActiveTvl         int64 `json:"active_tvl"`
+	TotalTvl          int64 `json:"total_tvl"`
+	ActiveDelegations int64 `json:"active_delegations"`
+	TotalDelegations  int64 `json:"total_delegations"`
+}
+
+type DelegationStateTotalPublic struct {
+	Delegations  int64  `json:"delegations"`
+	StakingValue uint64 `json:"staking_value"`
+}
+
+// toDelegationStateTotalsPublic maps the delegation totals by state, the
+// states without delegations are reported with zero totals
+func toDelegationStateTotalsPublic(totals []model.DelegationStateTotal) map[string]*DelegationStateTotalPublic {
+	totalsPublic := make(map[string]*DelegationStateTotalPublic, len(reportedDelegationStates))
+	for _, state := range reportedDelegationStates {
+		totalsPublic[state.ToString()] = &DelegationStateTotalPublic{}
+	}
+	for _, total := range totals {
+		totalsPublic[total.State.ToString()] = &DelegationStateTotalPublic{
+			Delegations:  total.Delegations,
+			StakingValue: total.StakingValue,
+		}
+	}
+	return totalsPublic
+}
+
+// StakerPortfolioPublic aggregates the delegations of several stakers. The
+// stats and totals cover all the delegations of the stakers, whereas the
+// delegations are a page of the merged delegations matching the filter.
+type StakerPortfolioPublic struct {
+	Stakers     []StakerStatsPublic                    `json:"stakers"`
+	Combined    *PortfolioStatsPublic                  `json:"combined"`
+	StateTotals map[string]*DelegationStateTotalPublic `json:"state_totals"`
+	Delegations []DelegationPublic                     `json:"delegations"`
+}
+
+// GetStakerPortfolio returns the stats of each staker and combined, the
+// totals of their delegations by state and a page of their delegations merged
+// and sorted by the staking start height.
+func (s *Services) GetStakerPortfolio(
+	ctx context.Context, stakerPks []string, filter *db.DelegationFilter,
+	sortOrder types.SortOrder, pageToken string,
+) (*StakerPortfolioPublic, string, *types.Error) {
+	portfolio := &StakerPortfolioPublic{
+		Stakers:     make([]StakerStatsPublic, 0, len(stakerPks)),
+		Combined:    &PortfolioStatsPublic{},
+		StateTotals: toDelegationStateTotalsPublic(nil),
+		Delegations: []DelegationPublic{},
+	}
+	if len(stakerPks) == 0 {
+		return portfolio, "", nil
+	}
+
+	stakerStats, err := s.DbClient.FindStakerStatsByStakerPks(ctx, stakerPks)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find staker stats by staker pks")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+	stakerStatsByPk := make(map[string]model.StakerStatsDocument, len(stakerStats))
+	for _, stats := range stakerStats {
+		stakerStatsByPk[stats.StakerPkHex] = stats
+	}
+	for _, stakerPk := range stakerPks {
+		// The stakers without any delegation yet have no stats
+		stats := stakerStatsByPk[stakerPk]
+		portfolio.Stakers = append(portfolio.Stakers, StakerStatsPublic{
+			StakerPkHex:       stakerPk,
+			ActiveTvl:         stats.ActiveTvl,
+			TotalTvl:          stats.TotalTvl,
+			ActiveDelegations: stats.ActiveDelegations,
+			TotalDelegations:  stats.TotalDelegations,
+		})
+		portfolio.Combined.ActiveTvl += stats.ActiveTvl
+		portfolio.Combined.TotalTvl += stats.TotalTvl
+		portfolio.Combined.ActiveDelegations += stats.ActiveDelegations
+		portfolio.Combined.TotalDelegations += stats.TotalDelegations
+	}
+
+	stateTotals, err := s.DbClient.FindDelegationStateTotalsByStakerPks(ctx, stakerPks)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegation state totals by staker pks")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+	portfolio.StateTotals = toDelegationStateTotalsPublic(stateTotals)
+
+	resultMap, err := s.DbClient.FindDelegationsByStakerPks(ctx, stakerPks, filter, sortOrder, pageToken)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching delegations by staker pks")
+			return nil, "", types.NewError(http.StatusBadRequest, types.BadRequest, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("Failed to find delegations by staker pks")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+	for _, d := range resultMap.Data {
+		portfolio.Delegations = append(portfolio.Delegations, FromDelegationDocument(&d))
+	}
+	s.SetDelegationsExpiry(ctx, portfolio.Delegations)
+	return portfolio, resultMap.PaginationToken, nil
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/babylonchain/staking-api-service/internal/config"
+)
+
+// memoryBucketsSweepInterval is how often the refilled buckets are dropped
+const memoryBucketsSweepInterval = time.Minute
+
+type rateLimitBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	// When the bucket is refilled up to the burst if left unused
+	refilledAt time.Time
+}
+
+// memoryRateLimitBuckets holds the token buckets of a single service replica
+type memoryRateLimitBuckets struct {
+	mu        sync.Mutex
+	buckets   map[string]*rateLimitBucket
+	lastSweep time.Time
+}
+
+func newMemoryRateLimitBuckets() *memoryRateLimitBuckets {
+	return &memoryRateLimitBuckets{
+		buckets:   make(map[string]*rateLimitBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// take refills the bucket for the time elapsed since its last update and
+// takes a token from it. It returns whether the token was taken along with
+// the remaining tokens.
+func (m *memoryRateLimitBuckets) take(key string, rule *config.RateLimitRule, now time.Time) (bool, float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if now.Sub(m.lastSweep) >= memoryBucketsSweepInterval {
+		m.sweep(now)
+	}
+
+	bucket, ok := m.buckets[key]
+	if !ok {
+		bucket = &rateLimitBucket{tokens: float64(rule.Burst), updatedAt: now}
+		m.buckets[key] = bucket
+	}
+	elapsed := math.Max(0, now.Sub(bucket.updatedAt).Seconds())
+	bucket.tokens = math.Min(float64(rule.Burst), bucket.tokens+elapsed*rule.RequestsPerSecond)
+	bucket.updatedAt = now
+	if bucket.tokens < 1 {
+		return false, bucket.tokens
+	}
+	bucket.tokens--
+	refillSeconds := (float64(rule.Burst) - bucket.tokens) / rule.RequestsPerSecond
+	bucket.refilledAt = now.Add(time.Duration(refillSeconds * float64(time.Second)))
+	return true, bucket.tokens
+}
+
+// sweep drops the buckets which are refilled by now, as they would be
+// recreated full anyway.
+func (m *memoryRateLimitBuckets) sweep(now time.Time) {
+	for key, bucket := range m.buckets {
+		if !now.Before(bucket.refilledAt) {
+			delete(m.buckets, key)
+		}
+	}
+	m.lastSweep = now
+}
+
+// TakeRateLimitToken takes a token from the bucket of the key under the rule.
+// If the bucket is empty, it returns false along with the time to wait for
+// the next token.
+func (s *Services) TakeRateLimitToken(
+	ctx context.Context, key string, rule *config.RateLimitRule,
+) (bool, time.Duration, error) {
+	now := time.Now()
+	var allowed bool
+	var tokens float64
+	if s.cfg.RateLimit.Backend == config.RateLimitMongoBackend {
+		bucket, err := s.DbClient.TakeRateLimitToken(ctx, key, rule.RequestsPerSecond, rule.Burst, now)
+		if err != nil {
+			return false, 0, err
+		}
+		allowed, tokens = bucket.Allowed, bucket.Tokens
+	} else {
+		allowed, tokens = s.rateLimitBuckets.take(key, rule, now)
+	}
+	if allowed {
+		return true, 0, nil
+	}
+	retryAfter := time.Duration((1 - tokens) / rule.RequestsPerSecond * float64(time.Second))
+	return false, retryAfter, nil
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
+	"github.com/rs/zerolog/log"
+)
+
+type SearchResultType string
+
+const (
+	SearchResultStakingTx        SearchResultType = "staking_tx"
+	SearchResultUnbondingTx      SearchResultType = "unbonding_tx"
+	SearchResultStaker           SearchResultType = "staker"
+	SearchResultFinalityProvider SearchResultType = "finality_provider"
+)
+
+// SearchResultPublic is an entity matching the search query. The link points
+// to the endpoint serving the full details of the entity.
+type SearchResultPublic struct {
+	Type                  SearchResultType `json:"type"`
+	Id                    string           `json:"id"`
+	StakingTxHashHex      string           `json:"staking_tx_hash_hex,omitempty"`
+	StakerPkHex           string           `json:"staker_pk_hex,omitempty"`
+	FinalityProviderPkHex string           `json:"finality_provider_pk_hex,omitempty"`
+	Link                  string           `json:"link"`
+}
+
+func delegationLink(stakingTxHashHex string) string {
+	return "/v1/delegation?staking_tx_hash_hex=" + url.QueryEscape(stakingTxHashHex)
+}
+
+func stakerDelegationsLink(stakerPkHex string) string {
+	return "/v1/staker/delegations?staker_btc_pk=" + url.QueryEscape(stakerPkHex)
+}
+
+func finalityProviderLink(fpPkHex string) string {
+	return "/v1/finality-providers/" + url.PathEscape(fpPkHex)
+}
+
+// Search classifies the query as a tx hash, a public key or a BTC address and
+// returns the entities it matches. A 32 bytes hex string can be either a tx
+// hash or a public key, so all the matching lookups are run for it.
+func (s *Services) Search(ctx context.Context, query string) ([]SearchResultPublic, *types.Error) {
+	query = strings.TrimSpace(query)
+	isTxHash := len(query) == 64 && utils.IsValidTxHash(query)
+	_, pkErr := utils.GetSchnorrPkFromHex(query)
+	isPk := pkErr == nil
+	isAddress := !isTxHash && !isPk && utils.IsValidBtcAddress(query, s.cfg.Server.BTCNetParam) == nil
+	if !isTxHash && !isPk && !isAddress {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest,
+			"q must be a transaction hash, a public key or a BTC address",
+		)
+	}
+
+	results := []SearchResultPublic{}
+	if isTxHash {
+		txResults, err := s.searchTxHash(ctx, strings.ToLower(query))
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, txResults...)
+	}
+	if isPk {
+		pkResults, err := s.searchPk(ctx, strings.ToLower(query))
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, pkResults...)
+	}
+	if isAddress {
+		stakerPkHex, err := s.GetStakerPkByAddress(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		if stakerPkHex != "" {
+			results = append(results, SearchResultPublic{
+				Type:        SearchResultStaker,
+				Id:          stakerPkHex,
+				StakerPkHex: stakerPkHex,
+				Link:        stakerDelegationsLink(stakerPkHex),
+			})
+		}
+	}
+	return results, nil
+}
+
+// searchTxHash looks the hash up as a staking tx and as an unbonding tx
+// requested through the service
+func (s *Services) searchTxHash(ctx context.Context, txHashHex string) ([]SearchResultPublic, *types.Error) {
+	var results []SearchResultPublic
+	delegation, err := s.DbClient.FindDelegationByTxHashHex(ctx, txHashHex)
+	if err != nil && !db.IsNotFoundError(err) {
+		log.Ctx(ctx).Error().Err(err).Str("txHashHex", txHashHex).Msg("Failed to find delegation by tx hash hex")
+		return nil, types.NewInternalServiceError(err)
+	}
+	if delegation != nil {
+		results = append(results, SearchResultPublic{
+			Type:                  SearchResultStakingTx,
+			Id:                    delegation.StakingTxHashHex,
+			StakingTxHashHex:      delegation.StakingTxHashHex,
+			StakerPkHex:           delegation.StakerPkHex,
+			FinalityProviderPkHex: delegation.FinalityProviderPkHex,
+			Link:                  delegationLink(delegation.StakingTxHashHex),
+		})
+	}
+
+	unbonding, err := s.DbClient.FindUnbondingTxByHashHex(ctx, txHashHex)
+	if err != nil && !db.IsNotFoundError(err) {
+		log.Ctx(ctx).Error().Err(err).Str("txHashHex", txHashHex).Msg("Failed to find unbonding tx by hash hex")
+		return nil, types.NewInternalServiceError(err)
+	}
+	if unbonding != nil {
+		results = append(results, SearchResultPublic{
+			Type:                  SearchResultUnbondingTx,
+			Id:                    unbonding.UnbondingTxHashHex,
+			StakingTxHashHex:      unbonding.StakingTxHashHex,
+			StakerPkHex:           unbonding.StakerPkHex,
+			FinalityProviderPkHex: unbonding.FinalityPkHex,
+			Link:                  delegationLink(unbonding.StakingTxHashHex),
+		})
+	}
+	return results, nil
+}
+
+// searchPk looks the public key up as a staker and as a finality provider
+// either registered in the global params or known from its delegations
+func (s *Services) searchPk(ctx context.Context, pkHex string) ([]SearchResultPublic, *types.Error) {
+	var results []SearchResultPublic
+	isStaker, err := s.DbClient.CheckDelegationExistByStakerPk(ctx, pkHex)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("pkHex", pkHex).Msg("Failed to check if staker has delegation")
+		return nil, types.NewInternalServiceError(err)
+	}
+	if isStaker {
+		results = append(results, SearchResultPublic{
+			Type:        SearchResultStaker,
+			Id:          pkHex,
+			StakerPkHex: pkHex,
+			Link:        stakerDelegationsLink(pkHex),
+		})
+	}
+
+	isFp := false
+	for _, fp := range s.GetFinalityProvidersFromGlobalParams() {
+		if fp.BtcPk == pkHex {
+			isFp = true
+			break
+		}
+	}
+	if !isFp {
+		fpStats, err := s.DbClient.FindFinalityProviderStatsByFinalityProviderPkHex(ctx, []string{pkHex})
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Str("pkHex", pkHex).Msg("Error while fetching finality provider stats")
+			return nil, types.NewInternalServiceError(err)
+		}
+		isFp = len(fpStats) > 0
+	}
+	if isFp {
+		results = append(results, SearchResultPublic{
+			Type:                  SearchResultFinalityProvider,
+			Id:                    pkHex,
+			FinalityProviderPkHex: pkHex,
+			Link:                  finalityProviderLink(pkHex),
+		})
+	}
+	return results, nil
+}
@@ -3,23 +3,38 @@ package services
 import (
 	"context"
 	"net/http"
+	"sync"
+	"sync/atomic"
 
 	"github.com/rs/zerolog/log"
 
 	"github.com/babylonchain/staking-api-service/internal/clients"
 	"github.com/babylonchain/staking-api-service/internal/config"
 	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
 	"github.com/babylonchain/staking-api-service/internal/types"
 )
 
 // Service layer contains the business logic and is used to interact with
 // the database and other external clients (if any).
 type Services struct {
-	DbClient          db.DBClient
-	Clients           *clients.Clients
-	cfg               *config.Config
-	params            *types.GlobalParams
-	finalityProviders []types.FinalityProviderDetails
+	DbClient db.DBClient
+	Clients  *clients.Clients
+	cfg      *config.Config
+	// The global params and finality providers can be hot-reloaded, hence they
+	// are swapped atomically and shall be accessed through their getters.
+	params            atomic.Pointer[loadedGlobalParams]
+	finalityProviders atomic.Pointer[loadedFinalityProviders]
+	// Subscribers of the delegation changes streamed from the database
+	delegationSubscribers *delegationSubscribers
+	// Replays the unprocessable messages, set once the queues are set up
+	queueMessageSender QueueMessageSender
+	// Token buckets of the in-memory rate limit backend
+	rateLimitBuckets *memoryRateLimitBuckets
+	// Cached responses of the in-memory cache backend
+	responseCache *memoryResponseCache
+	// Serializes the stats snapshots taken by the cron and on btc height change
+	statsSnapshotMutex sync.Mutex
 }
 
 func New(
@@ -34,13 +49,17 @@ func New(
 		log.Ctx(ctx).Fatal().Err(err).Msg("error while creating db client")
 		return nil, err
 	}
-	return &Services{
-		DbClient:          dbClient,
-		Clients:           clients,
-		cfg:               cfg,
-		params:            globalParams,
-		finalityProviders: finalityProviders,
-	}, nil
+	s := &Services{
+		DbClient:              dbClient,
+		Clients:               clients,
+		cfg:                   cfg,
+		delegationSubscribers: newDelegationSubscribers(),
+		rateLimitBuckets:      newMemoryRateLimitBuckets(),
+		responseCache:         newMemoryResponseCache(),
+	}
+	s.params.Store(&loadedGlobalParams{params: globalParams})
+	s.finalityProviders.Store(&loadedFinalityProviders{finalityProviders: finalityProviders})
+	return s, nil
 }
 
 // DoHealthCheck checks the health of the services by ping the database.
@@ -48,8 +67,22 @@ func (s *Services) DoHealthCheck(ctx context.Context) error {
 	return s.DbClient.Ping(ctx)
 }
 
-func (s *Services) SaveUnprocessableMessages(ctx context.Context, messageBody, receipt string) *types.Error {
-	err := s.DbClient.SaveUnprocessableMessage(ctx, messageBody, receipt)
+// SaveUnprocessableMessages dumps the message exceeding the retry attempts
+// into the db, along with its previously failed attempts
+func (s *Services) SaveUnprocessableMessages(ctx context.Context, failure *MessageProcessingFailure) *types.Error {
+	finalAttempt := failure.toAttempt()
+	err := s.DbClient.SaveUnprocessableMessageWithAttempts(ctx, failure.messageKey(), &model.UnprocessableMessageDocument{
+		MessageBody:    failure.MessageBody,
+		Receipt:        failure.Receipt,
+		QueueName:      failure.QueueName,
+		Error:          finalAttempt.Error,
+		ErrorCode:      finalAttempt.ErrorCode,
+		StatusCode:     finalAttempt.StatusCode,
+		TraceId:        finalAttempt.TraceId,
+		Attempts:       failure.Attempts,
+		AttemptHistory: []model.MessageProcessingAttempt{*finalAttempt},
+		FailedAt:       finalAttempt.Timestamp,
+	})
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("error while saving unprocessable message")
 		return types.NewErrorWithMsg(http.StatusInternalServerError, types.InternalServiceError, "error while saving unprocessable message")
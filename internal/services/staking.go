@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/rs/zerolog/log"
+
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
+)
+
+// The staking tx validation rules, evaluated in order
+const (
+	StakingRuleParamsVersion    = "params_version"
+	StakingRuleTxStructure      = "tx_structure"
+	StakingRuleStakingAmount    = "staking_amount"
+	StakingRuleStakingTime      = "staking_time"
+	StakingRuleStakingCap       = "staking_cap"
+	StakingRuleFinalityProvider = "finality_provider"
+)
+
+type StakingTxValidationRulePublic struct {
+	Rule    string `json:"rule"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+type StakingTxValidationPublic struct {
+	Valid bool `json:"valid"`
+	// The btc height used to select the params version
+	BtcHeight        uint64                          `json:"btc_height"`
+	ParamsVersion    *uint64                         `json:"params_version,omitempty"`
+	StakingTxHashHex string                          `json:"staking_tx_hash_hex,omitempty"`
+	StakingValue     uint64                          `json:"staking_value,omitempty"`
+	Rules            []StakingTxValidationRulePublic `json:"rules"`
+}
+
+func (v *StakingTxValidationPublic) addRule(rule string, err error) {
+	result := StakingTxValidationRulePublic{Rule: rule, Passed: err == nil}
+	if err != nil {
+		result.Message = err.Error()
+		v.Valid = false
+	}
+	v.Rules = append(v.Rules, result)
+}
+
+// ValidateStakingTx checks the staking tx against the global params version
+// applied at the btc height and the finality provider registry. If the btc
+// height is not provided, the height of the next btc block is used.
+// Rules depending on a failed rule are not evaluated.
+func (s *Services) ValidateStakingTx(
+	ctx context.Context, stakingTxHex string, btcHeight uint64,
+) (*StakingTxValidationPublic, *types.Error) {
+	btcInfo, err := s.DbClient.GetLatestBtcInfo(ctx)
+	if err != nil && !db.IsNotFoundError(err) {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching latest btc info")
+		return nil, types.NewInternalServiceError(err)
+	}
+	if btcHeight == 0 {
+		if btcInfo == nil {
+			return nil, types.NewErrorWithMsg(
+				http.StatusBadRequest, types.BadRequest,
+				"btc_height is required as the latest btc height is not available yet",
+			)
+		}
+		btcHeight = btcInfo.BtcHeight + 1
+	}
+
+	validation := &StakingTxValidationPublic{Valid: true, BtcHeight: btcHeight}
+
+	params := s.GetVersionedGlobalParamsByHeight(btcHeight)
+	if params == nil {
+		validation.addRule(StakingRuleParamsVersion, fmt.Errorf(
+			"no global params version is activated at btc height %d", btcHeight,
+		))
+		return validation, nil
+	}
+	validation.ParamsVersion = &params.Version
+	validation.addRule(StakingRuleParamsVersion, nil)
+
+	// The tag and covenant committee are verified while parsing the staking tx
+	stakingTx, parsedStakingTx, err := utils.ParseStakingTxHex(
+		stakingTxHex, params, s.cfg.Server.BTCNetParam,
+	)
+	validation.addRule(StakingRuleTxStructure, err)
+	if err != nil {
+		return validation, nil
+	}
+	stakingValue := uint64(parsedStakingTx.StakingOutput.Value)
+	validation.StakingTxHashHex = stakingTx.TxHash().String()
+	validation.StakingValue = stakingValue
+
+	var amountErr error
+	if stakingValue < params.MinStakingAmount || stakingValue > params.MaxStakingAmount {
+		amountErr = fmt.Errorf(
+			"staking amount %d is out of the range [%d, %d]",
+			stakingValue, params.MinStakingAmount, params.MaxStakingAmount,
+		)
+	}
+	validation.addRule(StakingRuleStakingAmount, amountErr)
+
+	var timeErr error
+	stakingTime := uint64(parsedStakingTx.OpReturnData.StakingTime)
+	if stakingTime < params.MinStakingTime || stakingTime > params.MaxStakingTime {
+		timeErr = fmt.Errorf(
+			"staking time %d is out of the range [%d, %d]",
+			stakingTime, params.MinStakingTime, params.MaxStakingTime,
+		)
+	}
+	validation.addRule(StakingRuleStakingTime, timeErr)
+
+	validation.addRule(StakingRuleStakingCap, checkStakingCap(params, btcInfo, btcHeight, stakingValue))
+
+	var fpErr error
+	fpPkHex := hex.EncodeToString(schnorr.SerializePubKey(
+		parsedStakingTx.OpReturnData.FinalityProviderPublicKey.PubKey,
+	))
+	if !s.isRegisteredFinalityProvider(fpPkHex) {
+		fpErr = fmt.Errorf("finality provider %s is not registered", fpPkHex)
+	}
+	validation.addRule(StakingRuleFinalityProvider, fpErr)
+
+	return validation, nil
+}
+
+// checkStakingCap checks the staking tx would not overflow. A params version
+// is either capped by the staking cap, which is compared against the latest
+// unconfirmed tvl, or by the cap height.
+func checkStakingCap(
+	params *types.VersionedGlobalParams, btcInfo *model.BtcInfo, btcHeight, stakingValue uint64,
+) error {
+	if params.CapHeight > 0 && btcHeight > params.CapHeight {
+		return fmt.Errorf("btc height %d is above the cap height %d", btcHeight, params.CapHeight)
+	}
+	if params.StakingCap > 0 {
+		var unconfirmedTvl uint64
+		if btcInfo != nil {
+			unconfirmedTvl = btcInfo.UnconfirmedTvl
+		}
+		if unconfirmedTvl+stakingValue > params.StakingCap {
+			return fmt.Errorf(
+				"staking amount %d exceeds the remaining staking cap %d",
+				stakingValue, remainingStakingCap(params.StakingCap, unconfirmedTvl),
+			)
+		}
+	}
+	return nil
+}
+
+func remainingStakingCap(stakingCap, tvl uint64) uint64 {
+	if tvl >= stakingCap {
+		return 0
+	}
+	return stakingCap - tvl
+}
+
+func (s *Services) isRegisteredFinalityProvider(fpPkHex string) bool {
+	for _, fp := range s.getFinalityProviders() {
+		if fp.BtcPk == fpPkHex {
+			return true
+		}
+	}
+	return false
+}
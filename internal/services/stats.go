@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
 	"github.com/babylonchain/staking-api-service/internal/types"
 	"github.com/rs/zerolog/log"
 )
@@ -28,6 +29,14 @@ type StakerStatsPublic struct {
 	TotalDelegations  int64  `json:"total_delegations"`
 }
 
+// StakerStatsDetailsPublic is the stats of a single staker along with its
+// rank by active tvl, the stakers with the same active tvl share the rank
+type StakerStatsDetailsPublic struct {
+	StakerStatsPublic
+	Rank        int64                                  `json:"rank"`
+	StateTotals map[string]*DelegationStateTotalPublic `json:"state_totals"`
+}
+
 // ProcessStakingStatsCalculation calculates the staking stats and updates the database.
 // This method tolerates duplicated calls, only the first call will be processed.
 func (s *Services) ProcessStakingStatsCalculation(
@@ -40,11 +49,13 @@ func (s *Services) ProcessStakingStatsCalculation(
 		log.Ctx(ctx).Error().Err(err).Str("stakingTxHashHex", stakingTxHashHex).Msg("error while fetching stats lock document")
 		return types.NewInternalServiceError(err)
 	}
+	// Any of the stats may be written below, even if a later one fails
+	defer s.invalidateCache(ctx, OverallStatsCacheGroup, FinalityProvidersCacheGroup)
 	switch state {
 	case types.Active:
 		// Add to the finality stats
 		if !statsLockDocument.FinalityProviderStats {
-			err = s.DbClient.IncrementFinalityProviderStats(ctx, stakingTxHashHex, fpPkHex, amount)
+			err = s.DbClient.IncrementFinalityProviderStats(ctx, stakingTxHashHex, fpPkHex, stakerPkHex, amount)
 			if err != nil {
 				if db.IsNotFoundError(err) {
 					return nil
@@ -82,7 +93,7 @@ func (s *Services) ProcessStakingStatsCalculation(
 	case types.Unbonded:
 		// Subtract from the finality stats
 		if !statsLockDocument.FinalityProviderStats {
-			err = s.DbClient.SubtractFinalityProviderStats(ctx, stakingTxHashHex, fpPkHex, amount)
+			err = s.DbClient.SubtractFinalityProviderStats(ctx, stakingTxHashHex, fpPkHex, stakerPkHex, amount)
 			if err != nil {
 				if db.IsNotFoundError(err) {
 					return nil
@@ -126,7 +137,15 @@ func (s *Services) ProcessStakingStatsCalculation(
 	return nil
 }
 
+// GetOverallStats returns the overall stats, served from the cache if enabled
 func (s *Services) GetOverallStats(ctx context.Context) (*OverallStatsPublic, *types.Error) {
+	return withCache(ctx, s, OverallStatsCacheGroup, "", func() (*OverallStatsPublic, bool, *types.Error) {
+		stats, err := s.loadOverallStats(ctx)
+		return stats, true, err
+	})
+}
+
+func (s *Services) loadOverallStats(ctx context.Context) (*OverallStatsPublic, *types.Error) {
 	stats, err := s.DbClient.GetOverallStats(ctx)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("error while fetching overall stats")
@@ -189,13 +208,90 @@ func (s *Services) GetTopStakersByActiveTvl(ctx context.Context, pageToken strin
 	return topStakersStats, resultMap.PaginationToken, nil
 }
 
+// GetStakerStats returns the stats of the staker, its delegations by state and
+// its rank on the leaderboard by active tvl
+func (s *Services) GetStakerStats(ctx context.Context, stakerPkHex string) (*StakerStatsDetailsPublic, *types.Error) {
+	stats, err := s.DbClient.FindStakerStatsByStakerPk(ctx, stakerPkHex)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "staker stats not found")
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching staker stats")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	higherRanked, err := s.DbClient.CountStakersWithHigherActiveTvl(ctx, stats.ActiveTvl)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while counting stakers with higher active tvl")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	stateTotals, err := s.DbClient.FindDelegationStateTotalsByStakerPks(ctx, []string{stakerPkHex})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching delegation state totals of staker")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	return &StakerStatsDetailsPublic{
+		StakerStatsPublic: StakerStatsPublic{
+			StakerPkHex:       stats.StakerPkHex,
+			ActiveTvl:         stats.ActiveTvl,
+			TotalTvl:          stats.TotalTvl,
+			ActiveDelegations: stats.ActiveDelegations,
+			TotalDelegations:  stats.TotalDelegations,
+		},
+		Rank:        higherRanked + 1,
+		StateTotals: toDelegationStateTotalsPublic(stateTotals),
+	}, nil
+}
+
+// ProcessBtcInfoStats updates the latest btc info and, if the stats snapshots
+// are enabled, takes a stats snapshot in the background whenever the btc height moves forward.
 func (s *Services) ProcessBtcInfoStats(
 	ctx context.Context, btcHeight uint64, confirmedTvl uint64, unconfirmedTvl uint64,
 ) *types.Error {
-	err := s.DbClient.UpsertLatestBtcInfo(ctx, btcHeight, confirmedTvl, unconfirmedTvl)
+	previousBtcInfo, err := s.DbClient.GetLatestBtcInfo(ctx)
+	if err != nil && !db.IsNotFoundError(err) {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching latest btc info")
+		return types.NewInternalServiceError(err)
+	}
+
+	err = s.DbClient.UpsertLatestBtcInfo(ctx, btcHeight, confirmedTvl, unconfirmedTvl)
 	if err != nil {
 		log.Ctx(ctx).Error().Err(err).Msg("error while upserting latest btc info")
 		return types.NewInternalServiceError(err)
 	}
+	s.invalidateCache(ctx, OverallStatsCacheGroup)
+
+	if s.cfg.StatsSnapshot != nil && (previousBtcInfo == nil || previousBtcInfo.BtcHeight < btcHeight) {
+		// The snapshot is best effort and copies all the stats, hence it is taken
+		// outside of the queue handler. A failed one shall not block the btc info
+		// processing as the periodic snapshot will catch up on the next run.
+		snapshotCtx := context.WithoutCancel(ctx)
+		go func() {
+			snapshotErr := s.takeStatsSnapshotAtHeight(snapshotCtx, &model.BtcInfo{
+				BtcHeight:      btcHeight,
+				ConfirmedTvl:   confirmedTvl,
+				UnconfirmedTvl: unconfirmedTvl,
+			})
+			if snapshotErr != nil {
+				log.Ctx(snapshotCtx).Error().Err(snapshotErr).Uint64("btcHeight", btcHeight).
+					Msg("error while taking stats snapshot on btc height change")
+			}
+		}()
+	}
 	return nil
 }
+
+// getLatestBtcHeight returns the btc height of the latest processed btc info.
+// It returns 0 if no btc info has been processed yet.
+func (s *Services) getLatestBtcHeight(ctx context.Context) (uint64, error) {
+	btcInfo, err := s.DbClient.GetLatestBtcInfo(ctx)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return btcInfo.BtcHeight, nil
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/rs/zerolog/log"
+
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+type StatsHistoryPublic struct {
+	// Start of the interval bucket in unix timestamp (seconds)
+	Timestamp         int64  `json:"timestamp"`
+	BtcHeight         uint64 `json:"btc_height"`
+	ActiveTvl         int64  `json:"active_tvl"`
+	TotalTvl          int64  `json:"total_tvl"`
+	ActiveDelegations int64  `json:"active_delegations"`
+	TotalDelegations  int64  `json:"total_delegations"`
+}
+
+type OverallStatsHistoryPublic struct {
+	StatsHistoryPublic
+	TotalStakers   uint64 `json:"total_stakers"`
+	UnconfirmedTvl uint64 `json:"unconfirmed_tvl"`
+	PendingTvl     uint64 `json:"pending_tvl"`
+}
+
+// StartStatsSnapshotCron takes a stats snapshot at every configured interval
+// until the context is cancelled.
+func (s *Services) StartStatsSnapshotCron(ctx context.Context) error {
+	c := cron.New()
+	cronSpec := fmt.Sprintf("@every %ds", s.cfg.StatsSnapshot.Interval)
+
+	_, err := c.AddFunc(cronSpec, func() {
+		if err := s.TakeStatsSnapshot(ctx); err != nil {
+			log.Error().Err(err).Msg("error while taking periodic stats snapshot")
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Start()
+	log.Info().Msg("Initiated Stats Snapshot Cron")
+
+	go func() {
+		<-ctx.Done()
+		log.Info().Msg("Stopping Stats Snapshot Cron")
+		c.Stop()
+	}()
+
+	return nil
+}
+
+// TakeStatsSnapshot persists the current overall, finality provider and staker
+// stats keyed by the latest btc height and the current timestamp.
+func (s *Services) TakeStatsSnapshot(ctx context.Context) *types.Error {
+	btcInfo, err := s.DbClient.GetLatestBtcInfo(ctx)
+	if err != nil {
+		// The snapshot is keyed by the btc height, skip it until the first btc info is processed
+		if db.IsNotFoundError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("latest btc info not found, skip the stats snapshot")
+			return nil
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching latest btc info")
+		return types.NewInternalServiceError(err)
+	}
+	return s.takeStatsSnapshotAtHeight(ctx, btcInfo)
+}
+
+func (s *Services) takeStatsSnapshotAtHeight(ctx context.Context, btcInfo *model.BtcInfo) *types.Error {
+	// The height triggered and the periodic snapshots copy the same stats,
+	// run them one at a time to not pile up copies on the db server.
+	s.statsSnapshotMutex.Lock()
+	defer s.statsSnapshotMutex.Unlock()
+
+	stats, err := s.DbClient.GetOverallStats(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching overall stats")
+		return types.NewInternalServiceError(err)
+	}
+
+	now := time.Now()
+	retention := time.Duration(s.cfg.StatsSnapshot.Retention) * time.Second
+	snapshot := &model.OverallStatsSnapshotDocument{
+		BtcHeight:         btcInfo.BtcHeight,
+		Timestamp:         now.Unix(),
+		ActiveTvl:         stats.ActiveTvl,
+		TotalTvl:          stats.TotalTvl,
+		ActiveDelegations: stats.ActiveDelegations,
+		TotalDelegations:  stats.TotalDelegations,
+		TotalStakers:      stats.TotalStakers,
+		ConfirmedTvl:      btcInfo.ConfirmedTvl,
+		UnconfirmedTvl:    btcInfo.UnconfirmedTvl,
+		ExpiresAt:         now.Add(retention),
+	}
+	if err := s.DbClient.SaveStatsSnapshot(ctx, snapshot); err != nil {
+		log.Ctx(ctx).Error().Err(err).Uint64("btcHeight", btcInfo.BtcHeight).
+			Msg("error while saving stats snapshot")
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
+
+// GetOverallStatsHistory returns the latest overall stats snapshot of each
+// interval bucket between the from and to timestamps (in seconds).
+func (s *Services) GetOverallStatsHistory(
+	ctx context.Context, from, to, interval int64,
+) ([]OverallStatsHistoryPublic, *types.Error) {
+	snapshots, err := s.DbClient.FindOverallStatsSnapshots(ctx, from, to, interval)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching overall stats snapshots")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	history := make([]OverallStatsHistoryPublic, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		pendingTvl := uint64(0)
+		if snapshot.UnconfirmedTvl > snapshot.ConfirmedTvl {
+			pendingTvl = snapshot.UnconfirmedTvl - snapshot.ConfirmedTvl
+		}
+		history = append(history, OverallStatsHistoryPublic{
+			StatsHistoryPublic: StatsHistoryPublic{
+				Timestamp: toBucketStart(snapshot.Timestamp, interval),
+				BtcHeight: snapshot.BtcHeight,
+				// Same as the overall stats endpoint, the active tvl is the confirmed tvl from the btc info
+				ActiveTvl:         int64(snapshot.ConfirmedTvl),
+				TotalTvl:          snapshot.TotalTvl,
+				ActiveDelegations: snapshot.ActiveDelegations,
+				TotalDelegations:  snapshot.TotalDelegations,
+			},
+			TotalStakers:   snapshot.TotalStakers,
+			UnconfirmedTvl: snapshot.UnconfirmedTvl,
+			PendingTvl:     pendingTvl,
+		})
+	}
+	return history, nil
+}
+
+// GetFinalityProviderStatsHistory returns the latest stats snapshot of the
+// finality provider in each interval bucket between the from and to timestamps (in seconds).
+func (s *Services) GetFinalityProviderStatsHistory(
+	ctx context.Context, fpPkHex string, from, to, interval int64,
+) ([]StatsHistoryPublic, *types.Error) {
+	snapshots, err := s.DbClient.FindFinalityProviderStatsSnapshots(ctx, fpPkHex, from, to, interval)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("fpPkHex", fpPkHex).
+			Msg("error while fetching finality provider stats snapshots")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	history := make([]StatsHistoryPublic, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		history = append(history, StatsHistoryPublic{
+			Timestamp:         toBucketStart(snapshot.Timestamp, interval),
+			BtcHeight:         snapshot.BtcHeight,
+			ActiveTvl:         snapshot.ActiveTvl,
+			TotalTvl:          snapshot.TotalTvl,
+			ActiveDelegations: snapshot.ActiveDelegations,
+			TotalDelegations:  snapshot.TotalDelegations,
+		})
+	}
+	return history, nil
+}
+
+// GetStakerStatsHistory returns the latest stats snapshot of the staker in
+// each interval bucket between the from and to timestamps (in seconds).
+func (s *Services) GetStakerStatsHistory(
+	ctx context.Context, stakerPkHex string, from, to, interval int64,
+) ([]StatsHistoryPublic, *types.Error) {
+	snapshots, err := s.DbClient.FindStakerStatsSnapshots(ctx, stakerPkHex, from, to, interval)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("stakerPkHex", stakerPkHex).
+			Msg("error while fetching staker stats snapshots")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	history := make([]StatsHistoryPublic, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		history = append(history, StatsHistoryPublic{
+			Timestamp:         toBucketStart(snapshot.Timestamp, interval),
+			BtcHeight:         snapshot.BtcHeight,
+			ActiveTvl:         snapshot.ActiveTvl,
+			TotalTvl:          snapshot.TotalTvl,
+			ActiveDelegations: snapshot.ActiveDelegations,
+			TotalDelegations:  snapshot.TotalDelegations,
+		})
+	}
+	return history, nil
+}
+
+func toBucketStart(timestamp, interval int64) int64 {
+	return timestamp - timestamp%interval
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/babylonchain/staking-api-service/internal/db"
 	"github.com/babylonchain/staking-api-service/internal/types"
@@ -29,10 +30,18 @@ func (s *Services) ProcessExpireCheck(
 
 // TransitionToUnbondedState transitions the staking delegation to unbonded state.
 // It returns true if the delegation is found and successfully transitioned to unbonded state.
+// The expireHeight is the btc height at which the timelock expired.
 func (s *Services) TransitionToUnbondedState(
-	ctx context.Context, stakingType types.StakingTxType, stakingTxHashHex string,
+	ctx context.Context, stakingType types.StakingTxType, stakingTxHashHex string, expireHeight uint64,
 ) *types.Error {
-	err := s.DbClient.TransitionToUnbondedState(ctx, stakingTxHashHex, utils.QualifiedStatesToUnbonded(stakingType))
+	err := s.DbClient.TransitionToUnbondedState(
+		ctx, stakingTxHashHex, utils.QualifiedStatesToUnbonded(stakingType),
+		&db.StateTransitionInfo{
+			BtcHeight: expireHeight,
+			Timestamp: time.Now().Unix(),
+			Source:    types.ExpiredStakingEventSource,
+		},
+	)
 	if err != nil {
 		// If the delegation is not found, we can ignore the error, it just means the delegation is not in a state that we can transition to unbonded
 		if db.IsNotFoundError(err) {
@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/rs/zerolog/log"
 
@@ -16,6 +18,96 @@ import (
 // It returns an error if the delegation is not eligible for unbonding or if the unbonding request is invalid.
 // If successful, it will change the delegation state to `unbonding_requested`
 func (s *Services) UnbondDelegation(
+	ctx context.Context,
+	stakingTxHashHex,
+	unbondingTxHashHex,
+	unbondingTxHex,
+	signatureHex string) *types.Error {
+	if err := s.checkUnbondingRequest(
+		ctx, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex,
+	); err != nil {
+		return err
+	}
+
+	btcHeight, err := s.getLatestBtcHeight(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching latest btc height")
+		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
+	}
+
+	// 3. save unbonding tx into DB
+	err = s.DbClient.SaveUnbondingTx(
+		ctx, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex,
+		&db.StateTransitionInfo{
+			BtcHeight: btcHeight,
+			Timestamp: time.Now().Unix(),
+			Source:    types.UnbondingRequestSource,
+		},
+	)
+	if err != nil {
+		if ok := db.IsDuplicateKeyError(err); ok {
+			log.Ctx(ctx).Warn().Err(err).Msg("unbonding request already been submitted into the system")
+			return types.NewError(http.StatusForbidden, types.Forbidden, err)
+		} else if ok := db.IsNotFoundError(err); ok {
+			log.Ctx(ctx).Warn().Err(err).Msg("no active delegation found for unbonding request")
+			return types.NewError(http.StatusForbidden, types.Forbidden, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("failed to save unbonding tx")
+		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
+	}
+	return nil
+}
+
+// UnbondDelegationWithIdempotencyKey submits the unbonding request once per
+// idempotency key of the caller, the requests retried with the same key get
+// the outcome of the original one.
+func (s *Services) UnbondDelegationWithIdempotencyKey(
+	ctx context.Context,
+	callerId,
+	idempotencyKey,
+	stakingTxHashHex,
+	unbondingTxHashHex,
+	unbondingTxHex,
+	signatureHex string) *types.Error {
+	request := []string{stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex}
+	return s.withIdempotencyKey(
+		ctx, "unbonding", callerId, idempotencyKey, request,
+		func(ctx context.Context) *types.Error {
+			return s.UnbondDelegation(ctx, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex)
+		},
+	)
+}
+
+// VerifyUnbondDelegation runs all the checks of UnbondDelegation without
+// saving the unbonding tx, so that the staker can pre-flight the request.
+func (s *Services) VerifyUnbondDelegation(
+	ctx context.Context,
+	stakingTxHashHex,
+	unbondingTxHashHex,
+	unbondingTxHex,
+	signatureHex string) *types.Error {
+	if err := s.checkUnbondingRequest(
+		ctx, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex,
+	); err != nil {
+		return err
+	}
+
+	// The unbonding tx can only be submitted once
+	_, err := s.DbClient.FindUnbondingTxByHashHex(ctx, unbondingTxHashHex)
+	if err == nil {
+		log.Ctx(ctx).Warn().Msg("unbonding request already been submitted into the system")
+		return types.NewErrorWithMsg(http.StatusForbidden, types.Forbidden, "unbonding transaction already exists")
+	}
+	if !db.IsNotFoundError(err) {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching unbonding tx")
+		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
+	}
+	return nil
+}
+
+// checkUnbondingRequest checks the delegation is eligible for unbonding and
+// verifies the unbonding request against it
+func (s *Services) checkUnbondingRequest(
 	ctx context.Context,
 	stakingTxHashHex,
 	unbondingTxHashHex,
@@ -64,20 +156,6 @@ func (s *Services) UnbondDelegation(
 			delegationDoc.StakingTxHashHex, unbondingTxHashHex))
 		return types.NewError(http.StatusForbidden, types.ValidationError, err)
 	}
-
-	// 3. save unbonding tx into DB
-	err = s.DbClient.SaveUnbondingTx(ctx, stakingTxHashHex, unbondingTxHashHex, unbondingTxHex, signatureHex)
-	if err != nil {
-		if ok := db.IsDuplicateKeyError(err); ok {
-			log.Ctx(ctx).Warn().Err(err).Msg("unbonding request already been submitted into the system")
-			return types.NewError(http.StatusForbidden, types.Forbidden, err)
-		} else if ok := db.IsNotFoundError(err); ok {
-			log.Ctx(ctx).Warn().Err(err).Msg("no active delegation found for unbonding request")
-			return types.NewError(http.StatusForbidden, types.Forbidden, err)
-		}
-		log.Ctx(ctx).Error().Err(err).Msg("failed to save unbonding tx")
-		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
-	}
 	return nil
 }
 
@@ -99,6 +177,90 @@ func (s *Services) IsEligibleForUnbondingRequest(ctx context.Context, stakingTxH
 	return nil
 }
 
+type UnbondingTemplatePublic struct {
+	StakingTxHashHex   string `json:"staking_tx_hash_hex"`
+	UnbondingTxHashHex string `json:"unbonding_tx_hash_hex"`
+	UnbondingTxHex     string `json:"unbonding_tx_hex"`
+	// Base64 encoded PSBT of the unsigned unbonding tx
+	UnbondingTxPsbt string `json:"unbonding_tx_psbt"`
+	// The sighash of the unbonding path of the staking output to be signed by the staker
+	SigHashHex     string `json:"sighash_hex"`
+	UnbondingValue uint64 `json:"unbonding_value"`
+	UnbondingFee   uint64 `json:"unbonding_fee"`
+	UnbondingTime  uint64 `json:"unbonding_time"`
+	ParamsVersion  uint64 `json:"params_version"`
+}
+
+// GetUnbondingTemplate builds the unsigned unbonding tx expected by the
+// unbonding request of an active delegation. The unbonding fee and unbonding
+// script come from the params version applied at the staking tx height.
+func (s *Services) GetUnbondingTemplate(
+	ctx context.Context, stakingTxHashHex string,
+) (*UnbondingTemplatePublic, *types.Error) {
+	delegationDoc, err := s.DbClient.FindDelegationByTxHashHex(ctx, stakingTxHashHex)
+	if err != nil {
+		if ok := db.IsNotFoundError(err); ok {
+			log.Ctx(ctx).Warn().Err(err).Msg("delegation not found, hence no unbonding template")
+			return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "delegation not found")
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching delegation")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	if delegationDoc.State != types.Active {
+		log.Ctx(ctx).Warn().Msg("delegation state is not active, hence not eligible for unbonding")
+		return nil, types.NewErrorWithMsg(http.StatusForbidden, types.Forbidden, "delegation state is not active")
+	}
+
+	paramsVersion := s.GetVersionedGlobalParamsByHeight(delegationDoc.StakingTx.StartHeight)
+	if paramsVersion == nil {
+		log.Ctx(ctx).Error().Msg("failed to get global params")
+		return nil, types.NewErrorWithMsg(
+			http.StatusInternalServerError, types.InternalServiceError,
+			"failed to get global params based on the staking tx height",
+		)
+	}
+
+	template, err := utils.BuildUnbondingTxTemplate(
+		delegationDoc.StakingTxHashHex,
+		delegationDoc.StakerPkHex,
+		delegationDoc.FinalityProviderPkHex,
+		delegationDoc.StakingTx.TimeLock,
+		delegationDoc.StakingTx.OutputIndex,
+		delegationDoc.StakingValue,
+		paramsVersion,
+		s.cfg.Server.BTCNetParam,
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg(fmt.Sprintf("failed to build unbonding tx template, staking tx hash: %s",
+			delegationDoc.StakingTxHashHex))
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	unbondingTxHex, err := utils.SerializeBtcTxToHex(template.UnbondingTx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to serialize unbonding tx")
+		return nil, types.NewInternalServiceError(err)
+	}
+	encodedPsbt, err := template.Psbt.B64Encode()
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to encode unbonding tx psbt")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	return &UnbondingTemplatePublic{
+		StakingTxHashHex:   delegationDoc.StakingTxHashHex,
+		UnbondingTxHashHex: template.UnbondingTx.TxHash().String(),
+		UnbondingTxHex:     unbondingTxHex,
+		UnbondingTxPsbt:    encodedPsbt,
+		SigHashHex:         hex.EncodeToString(template.SigHash),
+		UnbondingValue:     uint64(template.UnbondingTx.TxOut[0].Value),
+		UnbondingFee:       paramsVersion.UnbondingFee,
+		UnbondingTime:      paramsVersion.UnbondingTime,
+		ParamsVersion:      paramsVersion.Version,
+	}, nil
+}
+
 // TransitionToUnbondingState process the actual confirmed unbonding tx by updating the delegation state to `unbonding`
 // It returns true if the delegation is found and successfully transitioned to unbonding state.
 func (s *Services) TransitionToUnbondingState(
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
+)
+
+type UnbondingStateTransitionPublic struct {
+	State     string `json:"state"`
+	Timestamp string `json:"timestamp"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// UnbondingStatusPublic is the progress of an unbonding request through the
+// unbonding pipeline
+type UnbondingStatusPublic struct {
+	StakingTxHashHex   string                           `json:"staking_tx_hash_hex"`
+	UnbondingTxHashHex string                           `json:"unbonding_tx_hash_hex"`
+	State              string                           `json:"state"`
+	FailureReason      string                           `json:"failure_reason,omitempty"`
+	History            []UnbondingStateTransitionPublic `json:"history"`
+}
+
+func fromUnbondingDocument(d *model.UnbondingDocument) *UnbondingStatusPublic {
+	status := &UnbondingStatusPublic{
+		StakingTxHashHex:   d.StakingTxHashHex,
+		UnbondingTxHashHex: d.UnbondingTxHashHex,
+		State:              d.State,
+		FailureReason:      d.FailureReason,
+		History:            make([]UnbondingStateTransitionPublic, 0, len(d.StateHistory)),
+	}
+	for _, transition := range d.StateHistory {
+		status.History = append(status.History, UnbondingStateTransitionPublic{
+			State:     transition.State,
+			Timestamp: utils.ParseTimestampToIsoFormat(transition.Timestamp),
+			Reason:    transition.Reason,
+		})
+	}
+	return status
+}
+
+func (s *Services) findUnbondingRequest(
+	ctx context.Context, stakingTxHashHex string,
+) (*model.UnbondingDocument, *types.Error) {
+	unbonding, err := s.DbClient.FindUnbondingTxByStakingTxHashHex(ctx, stakingTxHashHex)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return nil, types.NewErrorWithMsg(
+				http.StatusNotFound, types.NotFound, "unbonding request not found",
+			)
+		}
+		log.Ctx(ctx).Error().Err(err).Str("stakingTxHashHex", stakingTxHashHex).
+			Msg("Failed to find unbonding request by staking tx hash hex")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return unbonding, nil
+}
+
+// GetUnbondingStatus returns the state of the unbonding request of the staking
+// tx along with the states it went through.
+func (s *Services) GetUnbondingStatus(
+	ctx context.Context, stakingTxHashHex string,
+) (*UnbondingStatusPublic, *types.Error) {
+	unbonding, err := s.findUnbondingRequest(ctx, stakingTxHashHex)
+	if err != nil {
+		return nil, err
+	}
+	return fromUnbondingDocument(unbonding), nil
+}
+
+// UpdateUnbondingState records the state reported by the unbonding pipeline.
+// The request only moves forward in its lifecycle. Reporting its current state
+// again is a no-op so that the pipeline can safely retry.
+func (s *Services) UpdateUnbondingState(
+	ctx context.Context, stakingTxHashHex, state, reason string,
+) (*UnbondingStatusPublic, *types.Error) {
+	stateOrder := model.UnbondingStateOrder(state)
+	if stateOrder <= model.UnbondingStateOrder(model.UnbondingInitialState) {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "invalid unbonding state",
+		)
+	}
+	if state == model.UnbondingFailedState && reason == "" {
+		return nil, types.NewErrorWithMsg(
+			http.StatusBadRequest, types.BadRequest, "reason is required for the failed state",
+		)
+	}
+
+	unbonding, err := s.findUnbondingRequest(ctx, stakingTxHashHex)
+	if err != nil {
+		return nil, err
+	}
+	if unbonding.State == state {
+		return fromUnbondingDocument(unbonding), nil
+	}
+	notEligibleErr := types.NewErrorWithMsg(
+		http.StatusForbidden, types.Forbidden,
+		fmt.Sprintf("unbonding request in state %s can not transition to %s", unbonding.State, state),
+	)
+	if model.UnbondingStateOrder(unbonding.State) >= stateOrder {
+		return nil, notEligibleErr
+	}
+
+	// The request can skip the states which were not reported
+	var eligiblePreviousStates []string
+	for _, previousState := range []string{
+		model.UnbondingInitialState, model.UnbondingPickedUpState,
+		model.UnbondingCovenantSignaturesCollectedState, model.UnbondingBroadcastState,
+	} {
+		if model.UnbondingStateOrder(previousState) < stateOrder {
+			eligiblePreviousStates = append(eligiblePreviousStates, previousState)
+		}
+	}
+	transition := model.UnbondingStateTransition{
+		State:     state,
+		Timestamp: time.Now().Unix(),
+		Reason:    reason,
+	}
+	transitionErr := s.DbClient.TransitionUnbondingState(ctx, stakingTxHashHex, eligiblePreviousStates, &transition)
+	if transitionErr != nil {
+		if db.IsNotFoundError(transitionErr) {
+			// The state has been updated concurrently
+			log.Ctx(ctx).Warn().Err(transitionErr).Str("stakingTxHashHex", stakingTxHashHex).
+				Msg("unbonding request no longer eligible for the state transition")
+			return nil, notEligibleErr
+		}
+		log.Ctx(ctx).Error().Err(transitionErr).Str("stakingTxHashHex", stakingTxHashHex).
+			Msg("Failed to transition the unbonding request state")
+		return nil, types.NewInternalServiceError(transitionErr)
+	}
+
+	unbonding.State = state
+	if state == model.UnbondingFailedState {
+		unbonding.FailureReason = reason
+	}
+	unbonding.StateHistory = append(unbonding.StateHistory, transition)
+	return fromUnbondingDocument(unbonding), nil
+}
@@ -0,0 +1,346 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/babylonchain/staking-queue-client/client"
+	"github.com/rs/zerolog/log"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
+)
+
+// messageProcessingAttemptsTtl is how long the failed attempts of a message are
+// kept after its last attempt, in case it's neither processed nor dead-lettered
+const messageProcessingAttemptsTtl = 7 * 24 * time.Hour
+
+// MessageProcessingFailure describes a failed attempt to process a queue message
+type MessageProcessingFailure struct {
+	QueueName   string
+	MessageBody string
+	Receipt     string
+	// The number of times the message has been retried so far
+	Attempts int32
+	TraceId  string
+	Err      *types.Error
+}
+
+// messageKey identifies the message across its retries, the messages with an
+// identical body on the same queue share the same key
+func (f *MessageProcessingFailure) messageKey() string {
+	return messageProcessingKey(f.QueueName, f.MessageBody)
+}
+
+func (f *MessageProcessingFailure) toAttempt() *model.MessageProcessingAttempt {
+	return &model.MessageProcessingAttempt{
+		Timestamp:  time.Now().Unix(),
+		TraceId:    f.TraceId,
+		Error:      f.Err.Error(),
+		ErrorCode:  f.Err.ErrorCode.String(),
+		StatusCode: f.Err.StatusCode,
+	}
+}
+
+func messageProcessingKey(queueName, messageBody string) string {
+	hash := sha256.Sum256([]byte(messageBody))
+	return queueName + ":" + hex.EncodeToString(hash[:])
+}
+
+// QueueMessageSender sends the message to the queue with the given name
+type QueueMessageSender func(ctx context.Context, queueName, messageBody string) error
+
+type MessageProcessingAttemptPublic struct {
+	Timestamp  string `json:"timestamp"`
+	TraceId    string `json:"trace_id,omitempty"`
+	Error      string `json:"error"`
+	ErrorCode  string `json:"error_code"`
+	StatusCode int    `json:"status_code"`
+}
+
+type UnprocessableMessagePublic struct {
+	Id             string                           `json:"id"`
+	QueueName      string                           `json:"queue_name,omitempty"`
+	MessageBody    string                           `json:"message_body"`
+	Receipt        string                           `json:"receipt"`
+	Error          string                           `json:"error,omitempty"`
+	ErrorCode      string                           `json:"error_code,omitempty"`
+	StatusCode     int                              `json:"status_code,omitempty"`
+	TraceId        string                           `json:"trace_id,omitempty"`
+	Attempts       int32                            `json:"attempts,omitempty"`
+	AttemptHistory []MessageProcessingAttemptPublic `json:"attempt_history"`
+	FailedAt       string                           `json:"failed_at,omitempty"`
+}
+
+type UnprocessableMessagesReplayPublic struct {
+	Replayed []string `json:"replayed"`
+	Failed   []string `json:"failed"`
+}
+
+func fromUnprocessableMessageDocument(d *model.UnprocessableMessageDocument) UnprocessableMessagePublic {
+	attemptHistory := make([]MessageProcessingAttemptPublic, len(d.AttemptHistory))
+	for i, attempt := range d.AttemptHistory {
+		attemptHistory[i] = MessageProcessingAttemptPublic{
+			Timestamp:  utils.ParseTimestampToIsoFormat(attempt.Timestamp),
+			TraceId:    attempt.TraceId,
+			Error:      attempt.Error,
+			ErrorCode:  attempt.ErrorCode,
+			StatusCode: attempt.StatusCode,
+		}
+	}
+	message := UnprocessableMessagePublic{
+		Id:             d.Id.Hex(),
+		QueueName:      d.QueueName,
+		MessageBody:    d.MessageBody,
+		Receipt:        d.Receipt,
+		Error:          d.Error,
+		ErrorCode:      d.ErrorCode,
+		StatusCode:     d.StatusCode,
+		TraceId:        d.TraceId,
+		Attempts:       d.Attempts,
+		AttemptHistory: attemptHistory,
+	}
+	if d.FailedAt != 0 {
+		message.FailedAt = utils.ParseTimestampToIsoFormat(d.FailedAt)
+	}
+	return message
+}
+
+// RecordMessageProcessingFailure records the failed attempt of the message
+// which is going to be retried
+func (s *Services) RecordMessageProcessingFailure(ctx context.Context, failure *MessageProcessingFailure) *types.Error {
+	err := s.DbClient.RecordMessageProcessingAttempt(
+		ctx, failure.messageKey(), failure.toAttempt(), time.Now().Add(messageProcessingAttemptsTtl),
+	)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while recording message processing attempt")
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
+
+// ClearMessageProcessingFailures removes the failed attempts of the message
+// once it has been processed
+func (s *Services) ClearMessageProcessingFailures(ctx context.Context, queueName, messageBody string) *types.Error {
+	if _, err := s.DbClient.PopMessageProcessingAttempts(ctx, messageProcessingKey(queueName, messageBody)); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while clearing message processing attempts")
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
+
+// SetQueueMessageSender sets the sender used to replay the unprocessable
+// messages. The queues are set up after the services, hence it can't be
+// provided on creation.
+func (s *Services) SetQueueMessageSender(sender QueueMessageSender) {
+	s.queueMessageSender = sender
+}
+
+// GetUnprocessableMessages returns the unprocessable messages matching the
+// filter, the latest first
+func (s *Services) GetUnprocessableMessages(
+	ctx context.Context, filter *db.UnprocessableMessageFilter, paginationToken string,
+) ([]UnprocessableMessagePublic, string, *types.Error) {
+	err := s.saveUnprocessableMessageAudit(ctx, &model.UnprocessableMessageAuditDocument{
+		Action:    types.UnprocessableMessageList,
+		QueueName: filter.QueueName,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	resultMap, dbErr := s.DbClient.FindUnprocessableMessagesWithPagination(ctx, filter, paginationToken)
+	if dbErr != nil {
+		if db.IsInvalidPaginationTokenError(dbErr) {
+			log.Ctx(ctx).Warn().Err(dbErr).Msg("Invalid pagination token when fetching unprocessable messages")
+			return nil, "", types.NewError(http.StatusBadRequest, types.BadRequest, dbErr)
+		}
+		log.Ctx(ctx).Error().Err(dbErr).Msg("error while fetching unprocessable messages")
+		return nil, "", types.NewInternalServiceError(dbErr)
+	}
+	messages := make([]UnprocessableMessagePublic, len(resultMap.Data))
+	for i := range resultMap.Data {
+		messages[i] = fromUnprocessableMessageDocument(&resultMap.Data[i])
+	}
+	return messages, resultMap.PaginationToken, nil
+}
+
+func (s *Services) GetUnprocessableMessage(
+	ctx context.Context, id string,
+) (*UnprocessableMessagePublic, *types.Error) {
+	message, err := s.findUnprocessableMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	err = s.saveUnprocessableMessageAudit(ctx, &model.UnprocessableMessageAuditDocument{
+		Action:    types.UnprocessableMessageInspect,
+		MessageId: message.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	messagePublic := fromUnprocessableMessageDocument(message)
+	return &messagePublic, nil
+}
+
+// ReplayUnprocessableMessage sends the message back to its original queue and
+// removes it from the unprocessable messages
+func (s *Services) ReplayUnprocessableMessage(ctx context.Context, id string) *types.Error {
+	message, err := s.findUnprocessableMessage(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.replayUnprocessableMessage(ctx, message)
+}
+
+// ReplayUnprocessableMessages replays the messages matching the filter, the
+// oldest first. A failed replay doesn't stop the remaining ones.
+func (s *Services) ReplayUnprocessableMessages(
+	ctx context.Context, filter *db.UnprocessableMessageFilter,
+) (*UnprocessableMessagesReplayPublic, *types.Error) {
+	messages, err := s.DbClient.FindUnprocessableMessagesByFilter(ctx, filter)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching unprocessable messages")
+		return nil, types.NewInternalServiceError(err)
+	}
+	result := &UnprocessableMessagesReplayPublic{
+		Replayed: []string{},
+		Failed:   []string{},
+	}
+	for i := range messages {
+		if err := s.replayUnprocessableMessage(ctx, &messages[i]); err != nil {
+			result.Failed = append(result.Failed, messages[i].Id.Hex())
+			continue
+		}
+		result.Replayed = append(result.Replayed, messages[i].Id.Hex())
+	}
+	return result, nil
+}
+
+// DiscardUnprocessableMessage removes the message without replaying it, the
+// message is kept in the audit along with the reason
+func (s *Services) DiscardUnprocessableMessage(ctx context.Context, id, reason string) *types.Error {
+	message, err := s.findUnprocessableMessage(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.deleteUnprocessableMessage(ctx, message, &model.UnprocessableMessageAuditDocument{
+		Action:    types.UnprocessableMessageDiscard,
+		MessageId: message.Id,
+		QueueName: message.QueueName,
+		Reason:    reason,
+		Message:   message,
+	})
+}
+
+func (s *Services) findUnprocessableMessage(
+	ctx context.Context, id string,
+) (*model.UnprocessableMessageDocument, *types.Error) {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "unprocessable message not found")
+	}
+	message, err := s.DbClient.FindUnprocessableMessageById(ctx, objectId)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "unprocessable message not found")
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching unprocessable message")
+		return nil, types.NewInternalServiceError(err)
+	}
+	return message, nil
+}
+
+func (s *Services) replayUnprocessableMessage(
+	ctx context.Context, message *model.UnprocessableMessageDocument,
+) *types.Error {
+	if s.queueMessageSender == nil {
+		return types.NewErrorWithMsg(
+			http.StatusServiceUnavailable, types.InternalServiceError, "queues are not available for replay",
+		)
+	}
+	queueName := message.QueueName
+	if queueName == "" {
+		var err error
+		queueName, err = QueueNameFromMessageBody(message.MessageBody)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("messageId", message.Id.Hex()).
+				Msg("unable to determine the queue of the unprocessable message")
+			return types.NewErrorWithMsg(http.StatusUnprocessableEntity, types.UnprocessableEntity, err.Error())
+		}
+	}
+	if err := s.queueMessageSender(ctx, queueName, message.MessageBody); err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("messageId", message.Id.Hex()).
+			Msg("error while replaying unprocessable message")
+		return types.NewInternalServiceError(err)
+	}
+	return s.deleteUnprocessableMessage(ctx, message, &model.UnprocessableMessageAuditDocument{
+		Action:    types.UnprocessableMessageReplay,
+		MessageId: message.Id,
+		QueueName: queueName,
+		Message:   message,
+	})
+}
+
+func (s *Services) deleteUnprocessableMessage(
+	ctx context.Context, message *model.UnprocessableMessageDocument,
+	audit *model.UnprocessableMessageAuditDocument,
+) *types.Error {
+	audit.Timestamp = time.Now().Unix()
+	err := s.DbClient.DeleteUnprocessableMessageWithAudit(ctx, message.Id, audit)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "unprocessable message not found")
+		}
+		log.Ctx(ctx).Error().Err(err).Str("messageId", message.Id.Hex()).
+			Msg("error while deleting unprocessable message")
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
+
+func (s *Services) saveUnprocessableMessageAudit(
+	ctx context.Context, audit *model.UnprocessableMessageAuditDocument,
+) *types.Error {
+	audit.Timestamp = time.Now().Unix()
+	if err := s.DbClient.SaveUnprocessableMessageAudit(ctx, audit); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while saving unprocessable message audit")
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
+
+// QueueNameFromMessageBody determines the queue of the messages dead-lettered
+// before their queue was recorded by their event type
+func QueueNameFromMessageBody(messageBody string) (string, error) {
+	var event struct {
+		EventType client.EventType `json:"event_type"`
+	}
+	if err := json.Unmarshal([]byte(messageBody), &event); err != nil {
+		return "", fmt.Errorf("failed to unmarshal event message: %w", err)
+	}
+	switch event.EventType {
+	case client.ActiveStakingEventType:
+		return client.ActiveStakingQueueName, nil
+	case client.UnbondingStakingEventType:
+		return client.UnbondingStakingQueueName, nil
+	case client.WithdrawStakingEventType:
+		return client.WithdrawStakingQueueName, nil
+	case client.ExpiredStakingEventType:
+		return client.ExpiredStakingQueueName, nil
+	case client.StatsEventType:
+		return client.StakingStatsQueueName, nil
+	case client.BtcInfoEventType:
+		return client.BtcInfoQueueName, nil
+	default:
+		return "", fmt.Errorf("unknown event type: %v", event.EventType)
+	}
+}
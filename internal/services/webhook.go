@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
+)
+
+const (
+	webhookSubscriptionIdLength     = 16
+	webhookSubscriptionSecretLength = 32
+)
+
+type WebhookSubscriptionPublic struct {
+	Id                    string   `json:"id"`
+	Url                   string   `json:"url"`
+	StakerPkHex           string   `json:"staker_pk_hex,omitempty"`
+	FinalityProviderPkHex string   `json:"finality_provider_pk_hex,omitempty"`
+	EventTypes            []string `json:"event_types"`
+	CreatedAt             string   `json:"created_at"`
+	// The HMAC key of the payload signatures, only returned on creation
+	Secret string `json:"secret,omitempty"`
+}
+
+type WebhookEventPublic struct {
+	EventType             string `json:"event_type"`
+	StakingTxHashHex      string `json:"staking_tx_hash_hex"`
+	StakerPkHex           string `json:"staker_pk_hex"`
+	FinalityProviderPkHex string `json:"finality_provider_pk_hex"`
+	StakingValue          uint64 `json:"staking_value"`
+	PreviousState         string `json:"previous_state,omitempty"`
+	NewState              string `json:"new_state"`
+	BtcHeight             uint64 `json:"btc_height"`
+	Timestamp             string `json:"timestamp"`
+	Source                string `json:"source"`
+}
+
+type WebhookDeliveryAttemptPublic struct {
+	Timestamp  string `json:"timestamp"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+type WebhookDeliveryPublic struct {
+	Id             string                         `json:"id"`
+	SubscriptionId string                         `json:"subscription_id"`
+	Event          WebhookEventPublic             `json:"event"`
+	State          string                         `json:"state"`
+	Attempts       []WebhookDeliveryAttemptPublic `json:"attempts"`
+	// Only set for pending deliveries
+	NextAttemptAt string `json:"next_attempt_at,omitempty"`
+	CreatedAt     string `json:"created_at"`
+}
+
+func fromWebhookSubscriptionDocument(d *model.WebhookSubscriptionDocument) WebhookSubscriptionPublic {
+	eventTypes := make([]string, len(d.EventTypes))
+	for i, eventType := range d.EventTypes {
+		eventTypes[i] = eventType.ToString()
+	}
+	return WebhookSubscriptionPublic{
+		Id:                    d.Id,
+		Url:                   d.Url,
+		StakerPkHex:           d.StakerPkHex,
+		FinalityProviderPkHex: d.FinalityProviderPkHex,
+		EventTypes:            eventTypes,
+		CreatedAt:             utils.ParseTimestampToIsoFormat(d.CreatedAt),
+	}
+}
+
+func fromWebhookEvent(e *model.WebhookEvent) WebhookEventPublic {
+	return WebhookEventPublic{
+		EventType:             e.EventType.ToString(),
+		StakingTxHashHex:      e.StakingTxHashHex,
+		StakerPkHex:           e.StakerPkHex,
+		FinalityProviderPkHex: e.FinalityProviderPkHex,
+		StakingValue:          e.StakingValue,
+		PreviousState:         e.PreviousState.ToString(),
+		NewState:              e.NewState.ToString(),
+		BtcHeight:             e.BtcHeight,
+		Timestamp:             utils.ParseTimestampToIsoFormat(e.Timestamp),
+		Source:                e.Source.ToString(),
+	}
+}
+
+func fromWebhookDeliveryDocument(d *model.WebhookDeliveryDocument) WebhookDeliveryPublic {
+	attempts := make([]WebhookDeliveryAttemptPublic, len(d.Attempts))
+	for i, attempt := range d.Attempts {
+		attempts[i] = WebhookDeliveryAttemptPublic{
+			Timestamp:  utils.ParseTimestampToIsoFormat(attempt.Timestamp),
+			StatusCode: attempt.StatusCode,
+			Error:      attempt.Error,
+		}
+	}
+	delivery := WebhookDeliveryPublic{
+		Id:             d.Id.Hex(),
+		SubscriptionId: d.SubscriptionId,
+		Event:          fromWebhookEvent(&d.Event),
+		State:          d.State.ToString(),
+		Attempts:       attempts,
+		CreatedAt:      utils.ParseTimestampToIsoFormat(d.CreatedAt),
+	}
+	if d.State == types.WebhookDeliveryPending {
+		delivery.NextAttemptAt = utils.ParseTimestampToIsoFormat(d.NextAttemptAt)
+	}
+	return delivery
+}
+
+// CreateWebhookSubscription registers the webhook and returns it along with
+// the generated secret used to sign the payloads. Empty filters match any
+// staker, finality provider or event type.
+func (s *Services) CreateWebhookSubscription(
+	ctx context.Context, url, stakerPkHex, finalityProviderPkHex string,
+	eventTypes []types.WebhookEventType,
+) (*WebhookSubscriptionPublic, *types.Error) {
+	id, err := generateRandomHex(webhookSubscriptionIdLength)
+	if err != nil {
+		return nil, types.NewInternalServiceError(err)
+	}
+	secret, err := generateRandomHex(webhookSubscriptionSecretLength)
+	if err != nil {
+		return nil, types.NewInternalServiceError(err)
+	}
+	if eventTypes == nil {
+		eventTypes = []types.WebhookEventType{}
+	}
+
+	subscription := &model.WebhookSubscriptionDocument{
+		Id:                    id,
+		Url:                   url,
+		Secret:                secret,
+		StakerPkHex:           stakerPkHex,
+		FinalityProviderPkHex: finalityProviderPkHex,
+		EventTypes:            eventTypes,
+		CreatedAt:             time.Now().Unix(),
+	}
+	if err := s.DbClient.SaveWebhookSubscription(ctx, subscription); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while saving webhook subscription")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	subscriptionPublic := fromWebhookSubscriptionDocument(subscription)
+	subscriptionPublic.Secret = secret
+	return &subscriptionPublic, nil
+}
+
+func (s *Services) GetWebhookSubscriptions(ctx context.Context) ([]WebhookSubscriptionPublic, *types.Error) {
+	subscriptions, err := s.DbClient.FindWebhookSubscriptions(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching webhook subscriptions")
+		return nil, types.NewInternalServiceError(err)
+	}
+	subscriptionsPublic := make([]WebhookSubscriptionPublic, len(subscriptions))
+	for i := range subscriptions {
+		subscriptionsPublic[i] = fromWebhookSubscriptionDocument(&subscriptions[i])
+	}
+	return subscriptionsPublic, nil
+}
+
+func (s *Services) DeleteWebhookSubscription(ctx context.Context, id string) *types.Error {
+	if err := s.DbClient.DeleteWebhookSubscription(ctx, id); err != nil {
+		if db.IsNotFoundError(err) {
+			return types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "webhook subscription not found")
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while deleting webhook subscription")
+		return types.NewInternalServiceError(err)
+	}
+	return nil
+}
+
+// GetWebhookDeliveries returns the deliveries of the subscription along with
+// their attempts, the latest first
+func (s *Services) GetWebhookDeliveries(
+	ctx context.Context, subscriptionId, paginationToken string,
+) ([]WebhookDeliveryPublic, string, *types.Error) {
+	_, err := s.DbClient.FindWebhookSubscriptionById(ctx, subscriptionId)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return nil, "", types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "webhook subscription not found")
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching webhook subscription")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+
+	resultMap, err := s.DbClient.FindWebhookDeliveriesBySubscriptionId(ctx, subscriptionId, paginationToken)
+	if err != nil {
+		if db.IsInvalidPaginationTokenError(err) {
+			log.Ctx(ctx).Warn().Err(err).Msg("Invalid pagination token when fetching webhook deliveries")
+			return nil, "", types.NewError(http.StatusBadRequest, types.BadRequest, err)
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching webhook deliveries")
+		return nil, "", types.NewInternalServiceError(err)
+	}
+	deliveries := make([]WebhookDeliveryPublic, len(resultMap.Data))
+	for i := range resultMap.Data {
+		deliveries[i] = fromWebhookDeliveryDocument(&resultMap.Data[i])
+	}
+	return deliveries, resultMap.PaginationToken, nil
+}
+
+func generateRandomHex(length int) (string, error) {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/babylonchain/staking-api-service/internal/db"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+// The headers of the webhook delivery requests. The signature is the hex
+// encoded HMAC-SHA256 of "{timestamp}.{body}" keyed by the subscription secret.
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// maxWebhookResponseBodySize bounds how much of the subscriber response is read
+const maxWebhookResponseBodySize = 64 * 1024
+
+// SignWebhookPayload signs the payload sent at the timestamp with the secret
+func SignWebhookPayload(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// StartWebhookDelivery periodically sends the due webhook deliveries until the
+// context is cancelled. The deliveries are claimed from the database, hence
+// multiple service replicas can deliver concurrently.
+func (s *Services) StartWebhookDelivery(ctx context.Context) {
+	client := &http.Client{Timeout: s.cfg.Webhooks.RequestTimeout}
+	go func() {
+		ticker := time.NewTicker(s.cfg.Webhooks.DeliveryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.deliverDueWebhooks(ctx, client)
+			}
+		}
+	}()
+}
+
+func (s *Services) deliverDueWebhooks(ctx context.Context, client *http.Client) {
+	for i := 0; i < s.cfg.Webhooks.BatchSize; i++ {
+		now := time.Now()
+		// The lease outlasts the delivery request, the delivery is picked up
+		// again if the attempt is not recorded, e.g. the replica is stopped
+		leaseUntil := now.Add(2 * s.cfg.Webhooks.RequestTimeout).Unix()
+		delivery, err := s.DbClient.ClaimDueWebhookDelivery(ctx, now.Unix(), leaseUntil)
+		if err != nil {
+			if !db.IsNotFoundError(err) {
+				log.Ctx(ctx).Error().Err(err).Msg("error while claiming due webhook delivery")
+			}
+			return
+		}
+		s.deliverWebhook(ctx, client, delivery)
+	}
+}
+
+func (s *Services) deliverWebhook(
+	ctx context.Context, client *http.Client, delivery *model.WebhookDeliveryDocument,
+) {
+	attempt := &model.WebhookDeliveryAttempt{Timestamp: time.Now().Unix()}
+	subscription, err := s.DbClient.FindWebhookSubscriptionById(ctx, delivery.SubscriptionId)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			attempt.Error = "webhook subscription has been deleted"
+			s.recordWebhookDeliveryAttempt(ctx, delivery, attempt, types.WebhookDeliveryFailed)
+			return
+		}
+		// The delivery is retried once the lease expires
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching webhook subscription")
+		return
+	}
+
+	attempt.StatusCode, err = postWebhook(ctx, client, subscription, delivery)
+	if err == nil {
+		s.recordWebhookDeliveryAttempt(ctx, delivery, attempt, types.WebhookDeliveryDelivered)
+		return
+	}
+	attempt.Error = err.Error()
+	log.Ctx(ctx).Warn().Err(err).Str("deliveryId", delivery.Id.Hex()).
+		Str("subscriptionId", subscription.Id).Msg("webhook delivery attempt failed")
+	if len(delivery.Attempts)+1 >= s.cfg.Webhooks.MaxAttempts {
+		s.recordWebhookDeliveryAttempt(ctx, delivery, attempt, types.WebhookDeliveryFailed)
+		return
+	}
+	s.recordWebhookDeliveryAttempt(ctx, delivery, attempt, types.WebhookDeliveryPending)
+}
+
+// recordWebhookDeliveryAttempt records the attempt, pending deliveries are
+// retried with exponential backoff
+func (s *Services) recordWebhookDeliveryAttempt(
+	ctx context.Context, delivery *model.WebhookDeliveryDocument,
+	attempt *model.WebhookDeliveryAttempt, state types.WebhookDeliveryState,
+) {
+	var nextAttemptAt int64
+	if state == types.WebhookDeliveryPending {
+		backoff := s.webhookBackoff(len(delivery.Attempts) + 1)
+		nextAttemptAt = time.Unix(attempt.Timestamp, 0).Add(backoff).Unix()
+	}
+	err := s.DbClient.RecordWebhookDeliveryAttempt(ctx, delivery.Id, attempt, state, nextAttemptAt)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Str("deliveryId", delivery.Id.Hex()).
+			Msg("error while recording webhook delivery attempt")
+	}
+}
+
+// webhookBackoff returns the wait after the given number of failed attempts
+func (s *Services) webhookBackoff(failedAttempts int) time.Duration {
+	backoff := s.cfg.Webhooks.InitialBackoff
+	for i := 1; i < failedAttempts && backoff < s.cfg.Webhooks.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > s.cfg.Webhooks.MaxBackoff {
+		backoff = s.cfg.Webhooks.MaxBackoff
+	}
+	return backoff
+}
+
+// postWebhook sends the signed event to the subscriber. It returns the
+// response status code, if any, and an error unless the status is 2xx.
+func postWebhook(
+	ctx context.Context, client *http.Client,
+	subscription *model.WebhookSubscriptionDocument, delivery *model.WebhookDeliveryDocument,
+) (int, error) {
+	payload, err := json.Marshal(fromWebhookEvent(&delivery.Event))
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal webhook event: %w", err)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookSignatureHeader, SignWebhookPayload(subscription.Secret, timestamp, payload))
+	request.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(WebhookEventHeader, delivery.Event.EventType.ToString())
+	request.Header.Set(WebhookDeliveryHeader, delivery.Id.Hex())
+
+	response, err := client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	// Drain the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, maxWebhookResponseBodySize))
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("unexpected response status code %d", response.StatusCode)
+	}
+	return response.StatusCode, nil
+}
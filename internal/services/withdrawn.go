@@ -2,17 +2,30 @@ package services
 
 import (
 	"context"
+	"encoding/hex"
 	"net/http"
+	"time"
 
 	"github.com/babylonchain/staking-api-service/internal/db"
 	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
+	"github.com/btcsuite/btcd/btcutil"
 	"github.com/rs/zerolog/log"
 )
 
 func (s *Services) TransitionToWithdrawnState(
 	ctx context.Context, stakingTxHashHex string,
 ) *types.Error {
-	err := s.DbClient.TransitionToWithdrawnState(ctx, stakingTxHashHex)
+	btcHeight, err := s.getLatestBtcHeight(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Str("stakingTxHashHex", stakingTxHashHex).Err(err).Msg("failed to fetch latest btc height")
+		return types.NewError(http.StatusInternalServerError, types.InternalServiceError, err)
+	}
+	err = s.DbClient.TransitionToWithdrawnState(ctx, stakingTxHashHex, &db.StateTransitionInfo{
+		BtcHeight: btcHeight,
+		Timestamp: time.Now().Unix(),
+		Source:    types.WithdrawStakingEventSource,
+	})
 	if err != nil {
 		if ok := db.IsNotFoundError(err); ok {
 			log.Ctx(ctx).Warn().Str("stakingTxHashHex", stakingTxHashHex).Err(err).Msg("delegation not found or no longer eligible for withdraw")
@@ -23,3 +36,118 @@ func (s *Services) TransitionToWithdrawnState(
 	}
 	return nil
 }
+
+type WithdrawalTemplatePublic struct {
+	StakingTxHashHex string `json:"staking_tx_hash_hex"`
+	// The tx whose timelock output is spent, either the staking or the unbonding tx
+	SpendingTxHashHex     string `json:"spending_tx_hash_hex"`
+	SpendingOutputIndex   uint64 `json:"spending_output_index"`
+	SpendingFromUnbonding bool   `json:"spending_from_unbonding"`
+	WithdrawalTxHashHex   string `json:"withdrawal_tx_hash_hex"`
+	WithdrawalTxHex       string `json:"withdrawal_tx_hex"`
+	// Base64 encoded PSBT of the unsigned withdrawal tx
+	WithdrawalTxPsbt string `json:"withdrawal_tx_psbt"`
+	SigHashHex       string `json:"sighash_hex"`
+	// The timelock path script and its control block, to be placed in the
+	// witness after the staker signature
+	TimeLockScriptHex string `json:"timelock_script_hex"`
+	ControlBlockHex   string `json:"control_block_hex"`
+	TimeLock          uint64 `json:"timelock"`
+	Destination       string `json:"destination"`
+	WithdrawalValue   uint64 `json:"withdrawal_value"`
+	FeeRate           uint64 `json:"fee_rate"`
+	Fee               uint64 `json:"fee"`
+	ParamsVersion     uint64 `json:"params_version"`
+}
+
+// GetWithdrawalTemplate builds the unsigned withdrawal tx of an unbonded
+// delegation. The unbonding output is spent if the delegation has been
+// unbonded early, otherwise the staking output is spent.
+func (s *Services) GetWithdrawalTemplate(
+	ctx context.Context, stakingTxHashHex, destination string, feeRate uint64,
+) (*WithdrawalTemplatePublic, *types.Error) {
+	destinationAddress, err := btcutil.DecodeAddress(destination, s.cfg.Server.BTCNetParam)
+	if err != nil {
+		return nil, types.NewErrorWithMsg(http.StatusBadRequest, types.BadRequest, "invalid destination")
+	}
+
+	delegationDoc, err := s.DbClient.FindDelegationByTxHashHex(ctx, stakingTxHashHex)
+	if err != nil {
+		if ok := db.IsNotFoundError(err); ok {
+			log.Ctx(ctx).Warn().Err(err).Msg("delegation not found, hence no withdrawal template")
+			return nil, types.NewErrorWithMsg(http.StatusNotFound, types.NotFound, "delegation not found")
+		}
+		log.Ctx(ctx).Error().Err(err).Msg("error while fetching delegation")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	if delegationDoc.State != types.Unbonded {
+		log.Ctx(ctx).Warn().Msg("delegation state is not unbonded, hence not eligible for withdraw")
+		return nil, types.NewErrorWithMsg(http.StatusForbidden, types.Forbidden, "delegation state is not unbonded")
+	}
+
+	paramsVersion := s.GetVersionedGlobalParamsByHeight(delegationDoc.StakingTx.StartHeight)
+	if paramsVersion == nil {
+		log.Ctx(ctx).Error().Msg("failed to get global params")
+		return nil, types.NewErrorWithMsg(
+			http.StatusInternalServerError, types.InternalServiceError,
+			"failed to get global params based on the staking tx height",
+		)
+	}
+
+	timelockTx := delegationDoc.StakingTx
+	fromUnbondingTx := delegationDoc.UnbondingTx != nil
+	if fromUnbondingTx {
+		timelockTx = delegationDoc.UnbondingTx
+	}
+
+	template, err := utils.BuildWithdrawalTxTemplate(
+		delegationDoc.StakerPkHex,
+		delegationDoc.FinalityProviderPkHex,
+		delegationDoc.StakingTx.TimeLock,
+		delegationDoc.StakingValue,
+		timelockTx.TxHex,
+		timelockTx.OutputIndex,
+		timelockTx.TimeLock,
+		fromUnbondingTx,
+		destinationAddress,
+		feeRate,
+		paramsVersion,
+		s.cfg.Server.BTCNetParam,
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("stakingTxHashHex", stakingTxHashHex).Msg("failed to build withdrawal tx template")
+		return nil, types.NewError(http.StatusBadRequest, types.ValidationError, err)
+	}
+
+	withdrawalTxHex, err := utils.SerializeBtcTxToHex(template.WithdrawalTx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to serialize withdrawal tx")
+		return nil, types.NewInternalServiceError(err)
+	}
+	encodedPsbt, err := template.Psbt.B64Encode()
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to encode withdrawal tx psbt")
+		return nil, types.NewInternalServiceError(err)
+	}
+
+	withdrawalTxIn := template.WithdrawalTx.TxIn[0]
+	return &WithdrawalTemplatePublic{
+		StakingTxHashHex:      delegationDoc.StakingTxHashHex,
+		SpendingTxHashHex:     withdrawalTxIn.PreviousOutPoint.Hash.String(),
+		SpendingOutputIndex:   uint64(withdrawalTxIn.PreviousOutPoint.Index),
+		SpendingFromUnbonding: fromUnbondingTx,
+		WithdrawalTxHashHex:   template.WithdrawalTx.TxHash().String(),
+		WithdrawalTxHex:       withdrawalTxHex,
+		WithdrawalTxPsbt:      encodedPsbt,
+		SigHashHex:            hex.EncodeToString(template.SigHash),
+		TimeLockScriptHex:     hex.EncodeToString(template.TimeLockScript),
+		ControlBlockHex:       hex.EncodeToString(template.ControlBlock),
+		TimeLock:              timelockTx.TimeLock,
+		Destination:           destinationAddress.EncodeAddress(),
+		WithdrawalValue:       uint64(template.WithdrawalTx.TxOut[0].Value),
+		FeeRate:               feeRate,
+		Fee:                   uint64(template.Fee),
+		ParamsVersion:         paramsVersion.Version,
+	}, nil
+}
@@ -0,0 +1,8 @@
+package types
+
+// ApiCaller identifies the caller authenticated by its api key
+type ApiCaller struct {
+	KeyId string
+	Owner string
+	Tier  string
+}
@@ -32,3 +32,18 @@ func FromStringToDelegationState(s string) (DelegationState, error) {
 		return "", fmt.Errorf("invalid delegation state: %s", s)
 	}
 }
+
+// DelegationStateSource identifies what triggered a delegation state transition
+type DelegationStateSource string
+
+const (
+	ActiveStakingEventSource    DelegationStateSource = "active_staking_event"
+	UnbondingRequestSource      DelegationStateSource = "unbonding_request"
+	UnbondingStakingEventSource DelegationStateSource = "unbonding_staking_event"
+	ExpiredStakingEventSource   DelegationStateSource = "expired_staking_event"
+	WithdrawStakingEventSource  DelegationStateSource = "withdraw_staking_event"
+)
+
+func (s DelegationStateSource) ToString() string {
+	return string(s)
+}
@@ -18,8 +18,10 @@ const (
 	NotFound             ErrorCode = "NOT_FOUND"
 	BadRequest           ErrorCode = "BAD_REQUEST"
 	Forbidden            ErrorCode = "FORBIDDEN"
+	Unauthorized         ErrorCode = "UNAUTHORIZED"
 	UnprocessableEntity  ErrorCode = "UNPROCESSABLE_ENTITY"
 	RequestTimeout       ErrorCode = "REQUEST_TIMEOUT"
+	Conflict             ErrorCode = "CONFLICT"
 )
 
 // Error represents an error with an HTTP status code and an application-specific error code.
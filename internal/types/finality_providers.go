@@ -28,9 +28,13 @@ func NewFinalityProviders(filePath string) ([]FinalityProviderDetails, error) {
 	if err != nil {
 		return nil, err
 	}
+	return NewFinalityProvidersFromBytes(data)
+}
 
+// NewFinalityProvidersFromBytes parses the finality providers file content
+func NewFinalityProvidersFromBytes(data []byte) ([]FinalityProviderDetails, error) {
 	var finalityProviders FinalityProviders
-	err = json.Unmarshal(data, &finalityProviders)
+	err := json.Unmarshal(data, &finalityProviders)
 	if err != nil {
 		return nil, err
 	}
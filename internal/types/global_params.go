@@ -18,9 +18,13 @@ func NewGlobalParams(filePath string) (*GlobalParams, error) {
 	if err != nil {
 		return nil, err
 	}
+	return NewGlobalParamsFromBytes(data)
+}
 
+// NewGlobalParamsFromBytes parses and validates the global params file content
+func NewGlobalParamsFromBytes(data []byte) (*GlobalParams, error) {
 	var globalParams GlobalParams
-	err = json.Unmarshal(data, &globalParams)
+	err := json.Unmarshal(data, &globalParams)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,25 @@
+package types
+
+import "fmt"
+
+type SortOrder string
+
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+func (s SortOrder) ToString() string {
+	return string(s)
+}
+
+func SortOrderFromString(s string) (SortOrder, error) {
+	switch s {
+	case SortAscending.ToString():
+		return SortAscending, nil
+	case SortDescending.ToString():
+		return SortDescending, nil
+	default:
+		return "", fmt.Errorf("invalid sort order: %s", s)
+	}
+}
@@ -0,0 +1,16 @@
+package types
+
+// UnprocessableMessageAction is an admin action recorded in the audit of the
+// unprocessable messages
+type UnprocessableMessageAction string
+
+const (
+	UnprocessableMessageList    UnprocessableMessageAction = "list"
+	UnprocessableMessageInspect UnprocessableMessageAction = "inspect"
+	UnprocessableMessageReplay  UnprocessableMessageAction = "replay"
+	UnprocessableMessageDiscard UnprocessableMessageAction = "discard"
+)
+
+func (a UnprocessableMessageAction) ToString() string {
+	return string(a)
+}
@@ -0,0 +1,46 @@
+package types
+
+import "fmt"
+
+// WebhookEventType is emitted for each delegation state transition
+type WebhookEventType string
+
+const (
+	DelegationActiveEvent             WebhookEventType = "delegation_active"
+	DelegationUnbondingRequestedEvent WebhookEventType = "delegation_unbonding_requested"
+	DelegationUnbondingEvent          WebhookEventType = "delegation_unbonding"
+	DelegationUnbondedEvent           WebhookEventType = "delegation_unbonded"
+	DelegationWithdrawnEvent          WebhookEventType = "delegation_withdrawn"
+)
+
+func (t WebhookEventType) ToString() string {
+	return string(t)
+}
+
+func WebhookEventTypeFromString(s string) (WebhookEventType, error) {
+	switch WebhookEventType(s) {
+	case DelegationActiveEvent, DelegationUnbondingRequestedEvent, DelegationUnbondingEvent,
+		DelegationUnbondedEvent, DelegationWithdrawnEvent:
+		return WebhookEventType(s), nil
+	default:
+		return "", fmt.Errorf("unknown webhook event type: %s", s)
+	}
+}
+
+// WebhookEventTypeFromDelegationState returns the event type emitted once the
+// delegation transitioned to the state
+func WebhookEventTypeFromDelegationState(state DelegationState) WebhookEventType {
+	return WebhookEventType("delegation_" + state.ToString())
+}
+
+type WebhookDeliveryState string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryState = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryState = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryState = "failed"
+)
+
+func (s WebhookDeliveryState) ToString() string {
+	return string(s)
+}
@@ -4,15 +4,20 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"math"
 
 	"github.com/babylonchain/babylon/btcstaking"
 	"github.com/babylonchain/babylon/crypto/bip322"
 	bbntypes "github.com/babylonchain/babylon/types"
+	"github.com/btcsuite/btcd/blockchain"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/mempool"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 
 	"github.com/babylonchain/staking-api-service/internal/types"
@@ -113,24 +118,70 @@ func VerifyUnbondingRequest(
 	}
 
 	// 4. verify that the unbonding output is constructed as expected
-	covenantPks, err := GetCovenantPksFromStrings(params.CovenantPks)
+	stakingInfo, unbondingInfo, err := buildStakingAndUnbondingInfo(
+		stakerPkHex, finalityProviderPkHex, stakingTimeLock, stakingValue, params, btcNetParam,
+	)
 	if err != nil {
-		return fmt.Errorf("failed to decode coveant public keys from strings: %w", err)
+		return err
+	}
+
+	if !outputsAreEqual(unbondingInfo.UnbondingOutput, unbondingTx.TxOut[0]) {
+		return fmt.Errorf("unbonding output does not match expected output")
 	}
 
+	// 5. verify the signature
 	stakerPk, err := GetSchnorrPkFromHex(stakerPkHex)
 	if err != nil {
 		return fmt.Errorf("failed to decode staker public key from hex: %w", err)
 	}
+	sigBytes, err := hex.DecodeString(unbondingSigHex)
+	if err != nil {
+		return fmt.Errorf("failed to decode unbonding signature from hex")
+	}
+	unbondingSpendInfo, err := stakingInfo.UnbondingPathSpendInfo()
+	if err != nil {
+		return fmt.Errorf("failed to build unbonding path spend info")
+	}
+	if err := btcstaking.VerifyTransactionSigWithOutput(
+		unbondingTx,
+		stakingInfo.StakingOutput,
+		unbondingSpendInfo.GetPkScriptPath(),
+		stakerPk,
+		sigBytes,
+	); err != nil {
+		return fmt.Errorf("invalid unbonding signature")
+	}
+	return nil
+}
+
+// buildStakingAndUnbondingInfo rebuilds the staking output of the delegation
+// and the unbonding output expected by the params version of the delegation
+func buildStakingAndUnbondingInfo(
+	stakerPkHex,
+	finalityProviderPkHex string,
+	stakingTimeLock,
+	stakingValue uint64,
+	params *types.VersionedGlobalParams,
+	btcNetParam *chaincfg.Params,
+) (*btcstaking.StakingInfo, *btcstaking.UnbondingInfo, error) {
+	covenantPks, err := GetCovenantPksFromStrings(params.CovenantPks)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode coveant public keys from strings: %w", err)
+	}
+
+	stakerPk, err := GetSchnorrPkFromHex(stakerPkHex)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode staker public key from hex: %w", err)
+	}
 
 	finalityProviderPk, err := GetSchnorrPkFromHex(finalityProviderPkHex)
 	if err != nil {
-		return fmt.Errorf("failed to decode finality provider public key from hex: %w", err)
+		return nil, nil, fmt.Errorf("failed to decode finality provider public key from hex: %w", err)
 	}
 
 	expectedUnbondingOutputValue := btcutil.Amount(stakingValue) - btcutil.Amount(params.UnbondingFee)
 	if expectedUnbondingOutputValue <= 0 {
-		return fmt.Errorf("staking output value is too low, got %v, unbonding fee: %v",
+		return nil, nil, fmt.Errorf("staking output value is too low, got %v, unbonding fee: %v",
 			btcutil.Amount(stakingValue), btcutil.Amount(params.UnbondingFee))
 	}
 
@@ -144,14 +195,9 @@ func VerifyUnbondingRequest(
 		btcNetParam,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to build unbonding info")
-	}
-
-	if !outputsAreEqual(unbondingInfo.UnbondingOutput, unbondingTx.TxOut[0]) {
-		return fmt.Errorf("unbonding output does not match expected output")
+		return nil, nil, fmt.Errorf("failed to build unbonding info")
 	}
 
-	// 5. verify the signature
 	stakingInfo, err := btcstaking.BuildStakingInfo(
 		stakerPk,
 		[]*btcec.PublicKey{finalityProviderPk},
@@ -162,26 +208,276 @@ func VerifyUnbondingRequest(
 		btcNetParam,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to build staking info")
+		return nil, nil, fmt.Errorf("failed to build staking info")
 	}
-	sigBytes, err := hex.DecodeString(unbondingSigHex)
+
+	return stakingInfo, unbondingInfo, nil
+}
+
+// UnbondingTxTemplate is the unsigned unbonding tx which passes
+// VerifyUnbondingRequest once signed by the staker
+type UnbondingTxTemplate struct {
+	UnbondingTx *wire.MsgTx
+	// Psbt carries the staking output and the unbonding path leaf script
+	// required by wallets to sign the unbonding tx
+	Psbt *psbt.Packet
+	// SigHash is the taproot script path sighash of the unbonding path
+	// of the staking output, which the staker must sign
+	SigHash []byte
+}
+
+// BuildUnbondingTxTemplate builds the unsigned unbonding tx spending the
+// staking output through the unbonding path. The single output pays the staking
+// value minus the unbonding fee of the params version to the unbonding script.
+func BuildUnbondingTxTemplate(
+	stakingTxHashHex,
+	stakerPkHex,
+	finalityProviderPkHex string,
+	stakingTimeLock,
+	stakingOutputIndex,
+	stakingValue uint64,
+	params *types.VersionedGlobalParams,
+	btcNetParam *chaincfg.Params,
+) (*UnbondingTxTemplate, error) {
+	stakingTxHash, err := chainhash.NewHashFromStr(stakingTxHashHex)
 	if err != nil {
-		return fmt.Errorf("failed to decode unbonding signature from hex")
+		return nil, fmt.Errorf("failed to decode staking tx hash from hex: %w", err)
 	}
+
+	stakingInfo, unbondingInfo, err := buildStakingAndUnbondingInfo(
+		stakerPkHex, finalityProviderPkHex, stakingTimeLock, stakingValue, params, btcNetParam,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	unbondingTx := wire.NewMsgTx(2)
+	unbondingTx.AddTxIn(wire.NewTxIn(
+		wire.NewOutPoint(stakingTxHash, uint32(stakingOutputIndex)), nil, nil,
+	))
+	unbondingTx.AddTxOut(unbondingInfo.UnbondingOutput)
+
 	unbondingSpendInfo, err := stakingInfo.UnbondingPathSpendInfo()
 	if err != nil {
-		return fmt.Errorf("failed to build unbonding path spend info")
+		return nil, fmt.Errorf("failed to build unbonding path spend info")
 	}
-	if err := btcstaking.VerifyTransactionSigWithOutput(
+
+	prevOutFetcher := txscript.NewCannedPrevOutputFetcher(
+		stakingInfo.StakingOutput.PkScript, stakingInfo.StakingOutput.Value,
+	)
+	sigHash, err := txscript.CalcTapscriptSignaturehash(
+		txscript.NewTxSigHashes(unbondingTx, prevOutFetcher),
+		txscript.SigHashDefault,
 		unbondingTx,
-		stakingInfo.StakingOutput,
-		unbondingSpendInfo.GetPkScriptPath(),
-		stakerPk,
-		sigBytes,
-	); err != nil {
-		return fmt.Errorf("invalid unbonding signature")
+		0,
+		prevOutFetcher,
+		unbondingSpendInfo.RevealedLeaf,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate unbonding tx sighash: %w", err)
 	}
-	return nil
+
+	packet, err := psbt.NewFromUnsignedTx(unbondingTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build unbonding tx psbt: %w", err)
+	}
+	controlBlock, err := unbondingSpendInfo.ControlBlock.ToBytes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize unbonding path control block: %w", err)
+	}
+	packet.Inputs[0].WitnessUtxo = stakingInfo.StakingOutput
+	packet.Inputs[0].TaprootLeafScript = []*psbt.TaprootTapLeafScript{
+		{
+			ControlBlock: controlBlock,
+			Script:       unbondingSpendInfo.RevealedLeaf.Script,
+			LeafVersion:  unbondingSpendInfo.RevealedLeaf.LeafVersion,
+		},
+	}
+
+	return &UnbondingTxTemplate{
+		UnbondingTx: unbondingTx,
+		Psbt:        packet,
+		SigHash:     sigHash,
+	}, nil
+}
+
+// WithdrawalTxTemplate is the unsigned withdrawal tx spending the timelock
+// path of either the staking output or the unbonding output
+type WithdrawalTxTemplate struct {
+	WithdrawalTx *wire.MsgTx
+	Psbt         *psbt.Packet
+	SigHash      []byte
+	// TimeLockScript and ControlBlock are required in the witness of the
+	// timelock path spend, after the staker signature
+	TimeLockScript []byte
+	ControlBlock   []byte
+	// Fee is estimated from the fee rate and the virtual size of the signed tx
+	Fee btcutil.Amount
+}
+
+// BuildWithdrawalTxTemplate builds the unsigned withdrawal tx spending the
+// output of the timelock tx through the timelock path. The timelock tx is
+// either the staking tx or, if fromUnbondingTx is set, the unbonding tx.
+// The single output pays the output value minus the fee to the destination.
+func BuildWithdrawalTxTemplate(
+	stakerPkHex,
+	finalityProviderPkHex string,
+	stakingTimeLock,
+	stakingValue uint64,
+	timelockTxHex string,
+	timelockOutputIndex,
+	timeLock uint64,
+	fromUnbondingTx bool,
+	destination btcutil.Address,
+	feeRate uint64,
+	params *types.VersionedGlobalParams,
+	btcNetParam *chaincfg.Params,
+) (*WithdrawalTxTemplate, error) {
+	timelockTx, _, err := bbntypes.NewBTCTxFromHex(timelockTxHex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode timelock tx from hex: %w", err)
+	}
+	if timelockOutputIndex >= uint64(len(timelockTx.TxOut)) {
+		return nil, fmt.Errorf("timelock tx output index %d is out of range", timelockOutputIndex)
+	}
+	timelockOutput := timelockTx.TxOut[timelockOutputIndex]
+
+	stakingInfo, unbondingInfo, err := buildStakingAndUnbondingInfo(
+		stakerPkHex, finalityProviderPkHex, stakingTimeLock, stakingValue, params, btcNetParam,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	expectedOutput := stakingInfo.StakingOutput
+	timeLockSpendInfo, err := stakingInfo.TimeLockPathSpendInfo()
+	if fromUnbondingTx {
+		expectedOutput = unbondingInfo.UnbondingOutput
+		timeLockSpendInfo, err = unbondingInfo.TimeLockPathSpendInfo()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to build timelock path spend info")
+	}
+	if !outputsAreEqual(expectedOutput, timelockOutput) {
+		return nil, fmt.Errorf("timelock tx output does not match expected output")
+	}
+	controlBlock, err := timeLockSpendInfo.ControlBlock.ToBytes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize timelock path control block: %w", err)
+	}
+	timeLockScript := timeLockSpendInfo.GetPkScriptPath()
+
+	destinationPkScript, err := txscript.PayToAddrScript(destination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build destination script: %w", err)
+	}
+
+	// The relative timelock of the timelock path is enforced by the sequence
+	withdrawalTx := wire.NewMsgTx(2)
+	timelockTxHash := timelockTx.TxHash()
+	withdrawalTxIn := wire.NewTxIn(
+		wire.NewOutPoint(&timelockTxHash, uint32(timelockOutputIndex)), nil, nil,
+	)
+	withdrawalTxIn.Sequence = uint32(timeLock)
+	withdrawalTx.AddTxIn(withdrawalTxIn)
+	withdrawalTx.AddTxOut(wire.NewTxOut(0, destinationPkScript))
+
+	vsize := estimateTimeLockPathSpendVSize(withdrawalTx, timeLockScript, controlBlock)
+	// The fee shall neither overflow nor exceed the spent output value
+	if feeRate > math.MaxInt64/vsize || int64(vsize*feeRate) >= timelockOutput.Value {
+		return nil, fmt.Errorf("withdrawal output value is too low, got %v, fee rate: %d sat/vB",
+			btcutil.Amount(timelockOutput.Value), feeRate)
+	}
+	fee := btcutil.Amount(vsize * feeRate)
+	withdrawalTx.TxOut[0].Value = timelockOutput.Value - int64(fee)
+	if mempool.IsDust(withdrawalTx.TxOut[0], mempool.DefaultMinRelayTxFee) {
+		return nil, fmt.Errorf("withdrawal output value is too low, got %v, fee: %v",
+			btcutil.Amount(timelockOutput.Value), fee)
+	}
+
+	prevOutFetcher := txscript.NewCannedPrevOutputFetcher(
+		timelockOutput.PkScript, timelockOutput.Value,
+	)
+	sigHash, err := txscript.CalcTapscriptSignaturehash(
+		txscript.NewTxSigHashes(withdrawalTx, prevOutFetcher),
+		txscript.SigHashDefault,
+		withdrawalTx,
+		0,
+		prevOutFetcher,
+		timeLockSpendInfo.RevealedLeaf,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate withdrawal tx sighash: %w", err)
+	}
+
+	packet, err := psbt.NewFromUnsignedTx(withdrawalTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build withdrawal tx psbt: %w", err)
+	}
+	packet.Inputs[0].WitnessUtxo = timelockOutput
+	packet.Inputs[0].TaprootLeafScript = []*psbt.TaprootTapLeafScript{
+		{
+			ControlBlock: controlBlock,
+			Script:       timeLockScript,
+			LeafVersion:  timeLockSpendInfo.RevealedLeaf.LeafVersion,
+		},
+	}
+
+	return &WithdrawalTxTemplate{
+		WithdrawalTx:   withdrawalTx,
+		Psbt:           packet,
+		SigHash:        sigHash,
+		TimeLockScript: timeLockScript,
+		ControlBlock:   controlBlock,
+		Fee:            fee,
+	}, nil
+}
+
+// estimateTimeLockPathSpendVSize returns the virtual size of the tx once its
+// single input is signed, with the witness stack [signature, script, control block]
+func estimateTimeLockPathSpendVSize(tx *wire.MsgTx, script, controlBlock []byte) uint64 {
+	witnessSize := 2 + // segwit marker and flag
+		wire.VarIntSerializeSize(3) +
+		1 + schnorr.SignatureSize +
+		wire.VarIntSerializeSize(uint64(len(script))) + len(script) +
+		wire.VarIntSerializeSize(uint64(len(controlBlock))) + len(controlBlock)
+	weight := tx.SerializeSizeStripped()*blockchain.WitnessScaleFactor + witnessSize
+	return uint64((weight + blockchain.WitnessScaleFactor - 1) / blockchain.WitnessScaleFactor)
+}
+
+// SerializeBtcTxToHex serializes the tx, including the witness if any, to hex
+func SerializeBtcTxToHex(tx *wire.MsgTx) (string, error) {
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf.Bytes()), nil
+}
+
+// ParseStakingTxHex parses the staking tx and verifies its staking output and
+// op_return output are built with the tag and covenant committee of the params
+func ParseStakingTxHex(
+	stakingTxHex string, params *types.VersionedGlobalParams, btcNetParam *chaincfg.Params,
+) (*wire.MsgTx, *btcstaking.ParsedV0StakingTx, error) {
+	stakingTx, _, err := bbntypes.NewBTCTxFromHex(stakingTxHex)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode staking tx from hex: %w", err)
+	}
+	tag, err := hex.DecodeString(params.Tag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode tag from hex: %w", err)
+	}
+	covenantPks, err := GetCovenantPksFromStrings(params.CovenantPks)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode covenant public keys from strings: %w", err)
+	}
+	parsedStakingTx, err := btcstaking.ParseV0StakingTx(
+		stakingTx, tag, covenantPks, uint32(params.CovenantQuorum), btcNetParam,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stakingTx, parsedStakingTx, nil
 }
 
 func outputsAreEqual(a *wire.TxOut, b *wire.TxOut) bool {
@@ -226,3 +522,67 @@ func GetTaprootAddressFromPk(pkHex string, netParams *chaincfg.Params) (string,
 	}
 	return address.EncodeAddress(), nil
 }
+
+// StakerBtcAddresses holds the standard address forms that can be derived from
+// a staker public key. The staker public key is in x-only format, hence the
+// segwit addresses are derived from both the even and the odd compressed keys.
+type StakerBtcAddresses struct {
+	Taproot          string
+	NativeSegwitEven string
+	NativeSegwitOdd  string
+	NestedSegwitEven string
+	NestedSegwitOdd  string
+}
+
+func DeriveBtcAddressesFromPk(pkHex string, netParams *chaincfg.Params) (*StakerBtcAddresses, error) {
+	taprootAddress, err := GetTaprootAddressFromPk(pkHex, netParams)
+	if err != nil {
+		return nil, err
+	}
+	pk, err := GetSchnorrPkFromHex(pkHex)
+	if err != nil {
+		return nil, err
+	}
+	// The schnorr public key is parsed with an even y coordinate
+	evenKey := pk.SerializeCompressed()
+	oddKey := make([]byte, len(evenKey))
+	copy(oddKey, evenKey)
+	// 0x03 is the prefix of a compressed public key with an odd y coordinate
+	oddKey[0] = 0x03
+
+	nativeSegwitEven, nestedSegwitEven, err := getSegwitAddressesFromCompressedPk(evenKey, netParams)
+	if err != nil {
+		return nil, err
+	}
+	nativeSegwitOdd, nestedSegwitOdd, err := getSegwitAddressesFromCompressedPk(oddKey, netParams)
+	if err != nil {
+		return nil, err
+	}
+	return &StakerBtcAddresses{
+		Taproot:          taprootAddress,
+		NativeSegwitEven: nativeSegwitEven,
+		NativeSegwitOdd:  nativeSegwitOdd,
+		NestedSegwitEven: nestedSegwitEven,
+		NestedSegwitOdd:  nestedSegwitOdd,
+	}, nil
+}
+
+// getSegwitAddressesFromCompressedPk returns the native segwit (P2WPKH) and
+// the nested segwit (P2SH-P2WPKH) addresses of the compressed public key
+func getSegwitAddressesFromCompressedPk(
+	compressedPk []byte, netParams *chaincfg.Params,
+) (string, string, error) {
+	nativeSegwit, err := btcutil.NewAddressWitnessPubKeyHash(btcutil.Hash160(compressedPk), netParams)
+	if err != nil {
+		return "", "", err
+	}
+	witnessProgram, err := txscript.PayToAddrScript(nativeSegwit)
+	if err != nil {
+		return "", "", err
+	}
+	nestedSegwit, err := btcutil.NewAddressScriptHash(witnessProgram, netParams)
+	if err != nil {
+		return "", "", err
+	}
+	return nativeSegwit.EncodeAddress(), nestedSegwit.EncodeAddress(), nil
+}
@@ -45,3 +45,23 @@ func QualifiedStatesToWithdraw() []types.DelegationState {
 func OutdatedStatesForWithdraw() []types.DelegationState {
 	return []types.DelegationState{types.Withdrawn}
 }
+
+// DelegationStateOrder returns the position of the state within the delegation lifecycle.
+// A delegation only moves forward in its lifecycle, hence the position can be
+// used to sort the state transitions of a delegation.
+func DelegationStateOrder(state types.DelegationState) int {
+	switch state {
+	case types.Active:
+		return 0
+	case types.UnbondingRequested:
+		return 1
+	case types.Unbonding:
+		return 2
+	case types.Unbonded:
+		return 3
+	case types.Withdrawn:
+		return 4
+	default:
+		return -1
+	}
+}
@@ -34,6 +34,22 @@ func IsValidBtcAddress(btcAddress string, params *chaincfg.Params) error {
 	}
 }
 
+// IsValidStakerBtcAddress checks if the provided address is in one of the
+// formats that can be derived from a staker public key, which are Taproot,
+// native SegWit (P2WPKH) and nested SegWit (P2SH-P2WPKH) addresses
+func IsValidStakerBtcAddress(btcAddress string, params *chaincfg.Params) error {
+	decodedAddr, err := btcutil.DecodeAddress(btcAddress, params)
+	if err != nil {
+		return fmt.Errorf("can not decode btc address: %w", err)
+	}
+	switch decodedAddr.(type) {
+	case *btcutil.AddressWitnessPubKeyHash, *btcutil.AddressTaproot, *btcutil.AddressScriptHash:
+		return nil
+	default:
+		return fmt.Errorf("unsupported btc address type")
+	}
+}
+
 // IsValidTxHash checks if the given string is a valid BTC transaction hash
 // Note: it does not check the actual content of the hash.
 func IsValidTxHash(txHash string) bool {
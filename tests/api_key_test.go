@@ -0,0 +1,167 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonchain/staking-api-service/internal/config"
+	"github.com/babylonchain/staking-api-service/internal/services"
+)
+
+const (
+	testApiKeyHeader    = "X-Partner-Api-Key"
+	testApiKeyTier      = "partner"
+	checkDelegationPath = "/v1/staker/delegation/check"
+)
+
+func apiKeyTestConfig(t *testing.T) *config.Config {
+	cfg := loadTestConfig(t)
+	cfg.ApiKeys = &config.ApiKeysConfig{Header: testApiKeyHeader}
+	cfg.RateLimit = &config.RateLimitConfig{
+		Backend: "memory",
+		Groups: map[string]*config.RateLimitGroupConfig{
+			config.RateLimitPublicGroup: {
+				// The bucket is not refilled within the test
+				RateLimitRule: config.RateLimitRule{RequestsPerSecond: 0.001, Burst: 2},
+				Tiers: map[string]*config.RateLimitRule{
+					testApiKeyTier: {RequestsPerSecond: 0.001, Burst: 4},
+				},
+			},
+		},
+	}
+	return cfg
+}
+
+func issueTestApiKey(t *testing.T, allowedRoutes []string, expiresAt int64) *services.ApiKeyPublic {
+	s := &services.Services{DbClient: directDbConnection(t)}
+	apiKey, err := s.IssueApiKey(context.Background(), "test-partner", testApiKeyTier, allowedRoutes, expiresAt)
+	require.Nil(t, err)
+	require.NotEmpty(t, apiKey.Key)
+	return apiKey
+}
+
+func getWithApiKey(t *testing.T, url, key string) *http.Response {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if key != "" {
+		req.Header.Set(testApiKeyHeader, key)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp
+}
+
+func TestApiKeyAuthentication(t *testing.T) {
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: apiKeyTestConfig(t)})
+	defer testServer.Close()
+	url := testServer.Server.URL + "/v1/global-params"
+
+	// Anonymous requests are still served
+	resp := getWithApiKey(t, url, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	resp = getWithApiKey(t, url, "invalid-api-key")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "expected HTTP 401 Unauthorized status")
+
+	apiKey := issueTestApiKey(t, nil, 0)
+	resp = getWithApiKey(t, url, apiKey.Key)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	s := &services.Services{DbClient: directDbConnection(t)}
+	require.Nil(t, s.RevokeApiKey(context.Background(), apiKey.Id))
+	resp = getWithApiKey(t, url, apiKey.Key)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "expected HTTP 401 Unauthorized status")
+
+	expiredApiKey := issueTestApiKey(t, nil, time.Now().Add(-time.Minute).Unix())
+	resp = getWithApiKey(t, url, expiredApiKey.Key)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "expected HTTP 401 Unauthorized status")
+}
+
+func TestInvalidApiKeysShouldBeRateLimitedByIp(t *testing.T) {
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: apiKeyTestConfig(t)})
+	defer testServer.Close()
+	url := testServer.Server.URL + "/v1/global-params"
+
+	// Limited by the most permissive rule of the group, i.e. the tier burst
+	for i := 0; i < 4; i++ {
+		resp := getWithApiKey(t, url, "invalid-api-key")
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "expected HTTP 401 Unauthorized status")
+	}
+	resp := getWithApiKey(t, url, "another-invalid-api-key")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "expected HTTP 429 Too Many Requests status")
+
+	// The anonymous requests have their own bucket
+	resp = getWithApiKey(t, url, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+}
+
+func TestApiKeyAllowedRoutes(t *testing.T) {
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: apiKeyTestConfig(t)})
+	defer testServer.Close()
+
+	apiKey := issueTestApiKey(t, []string{checkDelegationPath, "/v1/staker/*"}, 0)
+
+	resp := getWithApiKey(t, testServer.Server.URL+"/v1/staker/delegations?staker_btc_pk=invalid", apiKey.Key)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected the request to reach the handler")
+
+	resp = getWithApiKey(t, testServer.Server.URL+"/v1/global-params", apiKey.Key)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, "expected HTTP 403 Forbidden status")
+}
+
+func TestApiKeyTierRateLimit(t *testing.T) {
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: apiKeyTestConfig(t)})
+	defer testServer.Close()
+	url := testServer.Server.URL + "/v1/global-params"
+
+	for i := 0; i < 2; i++ {
+		resp := getWithApiKey(t, url, "")
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status within the burst")
+	}
+	resp := getWithApiKey(t, url, "")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "expected HTTP 429 Too Many Requests status")
+
+	// The caller of an api key has the limit of its tier
+	apiKey := issueTestApiKey(t, nil, 0)
+	for i := 0; i < 4; i++ {
+		resp := getWithApiKey(t, url, apiKey.Key)
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status within the tier burst")
+	}
+	resp = getWithApiKey(t, url, apiKey.Key)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "expected HTTP 429 Too Many Requests status")
+}
+
+func TestApiKeyHeaderShouldBeAllowedByCors(t *testing.T) {
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: apiKeyTestConfig(t)})
+	defer testServer.Close()
+
+	testCases := []struct {
+		path          string
+		origin        string
+		allowedOrigin string
+	}{
+		{"/v1/global-params", "https://dashboard.testnet3.babylonchain.io", "*"},
+		{checkDelegationPath, "https://app.galxe.com", "https://app.galxe.com"},
+	}
+	for _, tc := range testCases {
+		req, err := http.NewRequest(http.MethodOptions, testServer.Server.URL+tc.path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", tc.origin)
+		req.Header.Set("Access-Control-Request-Method", "GET")
+		req.Header.Set("Access-Control-Request-Headers", testApiKeyHeader)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode, "expected HTTP 204 for %s", tc.path)
+		assert.Equal(t, tc.allowedOrigin, resp.Header.Get("Access-Control-Allow-Origin"),
+			"expected the origin to be allowed for %s", tc.path)
+		assert.Contains(t, resp.Header.Get("Access-Control-Allow-Headers"), testApiKeyHeader,
+			"expected the api key header to be allowed for %s", tc.path)
+	}
+}
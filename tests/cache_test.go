@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/babylonchain/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonchain/staking-api-service/internal/config"
+)
+
+func cacheTestConfig(t *testing.T, backend string) *config.Config {
+	cfg := loadTestConfig(t)
+	cfg.Cache = &config.CacheConfig{
+		Backend: backend,
+		Ttl:     time.Hour,
+	}
+	return cfg
+}
+
+// getWithETag makes a GET request, conditional if the etag is provided, and
+// returns the response status code and ETag
+func getWithETag(t *testing.T, url, etag string) (int, string) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	if resp.StatusCode == http.StatusNotModified {
+		assert.Empty(t, body, "expected no body for HTTP 304 Not Modified status")
+	}
+	return resp.StatusCode, resp.Header.Get("ETag")
+}
+
+func TestCachedEndpointsShouldAnswerConditionalRequests(t *testing.T) {
+	testServer := setupTestServer(t, &TestServerDependency{
+		ConfigOverrides: cacheTestConfig(t, config.CacheMemoryBackend),
+	})
+	defer testServer.Close()
+
+	for _, path := range []string{overallStatsEndpoint, finalityProvidersPath, globalParamsPath} {
+		url := testServer.Server.URL + path
+		statusCode, etag := getWithETag(t, url, "")
+		require.Equal(t, http.StatusOK, statusCode, "expected HTTP 200 OK status")
+		require.NotEmpty(t, etag, "expected an ETag header")
+
+		statusCode, notModifiedEtag := getWithETag(t, url, etag)
+		assert.Equal(t, http.StatusNotModified, statusCode, "expected HTTP 304 Not Modified status")
+		assert.Equal(t, etag, notModifiedEtag)
+
+		statusCode, _ = getWithETag(t, url, `"other-etag", W/`+etag)
+		assert.Equal(t, http.StatusNotModified, statusCode, "expected any of the ETags to match")
+
+		statusCode, _ = getWithETag(t, url, `"other-etag"`)
+		assert.Equal(t, http.StatusOK, statusCode, "expected HTTP 200 OK status")
+	}
+}
+
+func testCacheInvalidation(t *testing.T, backend string) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cacheTestConfig(t, backend)})
+	defer testServer.Close()
+
+	// Cache the responses before any stats are written
+	overallStats := fetchOverallStatsEndpoint(t, testServer)
+	assert.Equal(t, int64(0), overallStats.TotalTvl)
+	_, statsEtag := getWithETag(t, testServer.Server.URL+overallStatsEndpoint, "")
+	_, fpsEtag := getWithETag(t, testServer.Server.URL+finalityProvidersPath, "")
+
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []client.ActiveStakingEvent{*activeStakingEvent})
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	// The stats calculation invalidates the overall stats and finality providers
+	statusCode, _ := getWithETag(t, testServer.Server.URL+overallStatsEndpoint, statsEtag)
+	assert.Equal(t, http.StatusOK, statusCode, "expected the cached overall stats to be invalidated")
+	overallStats = fetchOverallStatsEndpoint(t, testServer)
+	assert.Equal(t, int64(activeStakingEvent.StakingValue), overallStats.TotalTvl)
+
+	statusCode, _ = getWithETag(t, testServer.Server.URL+finalityProvidersPath, fpsEtag)
+	assert.Equal(t, http.StatusOK, statusCode, "expected the cached finality providers to be invalidated")
+	for _, fp := range fetchFinalityEndpoint(t, testServer) {
+		if fp.BtcPk == activeStakingEvent.FinalityProviderPkHex {
+			assert.Equal(t, int64(activeStakingEvent.StakingValue), fp.TotalTvl)
+		}
+	}
+
+	// The btc info invalidates the overall stats
+	btcInfoEvent := &client.BtcInfoEvent{
+		EventType:      client.BtcInfoEventType,
+		Height:         100,
+		ConfirmedTvl:   90,
+		UnconfirmedTvl: 100,
+	}
+	err = sendTestMessage(testServer.Queues.BtcInfoQueueClient, []*client.BtcInfoEvent{btcInfoEvent})
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	overallStats = fetchOverallStatsEndpoint(t, testServer)
+	assert.Equal(t, uint64(100), overallStats.UnconfirmedTvl)
+	assert.Equal(t, int64(90), overallStats.ActiveTvl)
+}
+
+func TestMemoryCacheInvalidation(t *testing.T) {
+	testCacheInvalidation(t, config.CacheMemoryBackend)
+}
+
+func TestMongoCacheInvalidation(t *testing.T) {
+	testCacheInvalidation(t, config.CacheMongoBackend)
+}
@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/babylonchain/staking-api-service/internal/clients"
+	"github.com/babylonchain/staking-api-service/internal/services"
+	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigWatcherShouldReloadOnFileChange(t *testing.T) {
+	cfg := loadTestConfig(t)
+	dir := t.TempDir()
+	paramsPath := filepath.Join(dir, "global-params.json")
+	fpsPath := filepath.Join(dir, "finality-providers.json")
+	copyTestFile(t, "./config/global-params-test.json", paramsPath)
+	copyTestFile(t, "./config/finality-providers-test.json", fpsPath)
+
+	params, err := types.NewGlobalParams(paramsPath)
+	assert.NoError(t, err)
+	fps, err := types.NewFinalityProviders(fpsPath)
+	assert.NoError(t, err)
+	s, err := services.New(context.Background(), cfg, params, fps, clients.New(cfg))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err = s.StartConfigWatcher(ctx, paramsPath, fpsPath)
+	assert.NoError(t, err)
+
+	initialParamsInfo := s.GetGlobalParamsFileInfo()
+	initialFpsInfo := s.GetFinalityProvidersFileInfo()
+	assert.NotNil(t, initialParamsInfo)
+	assert.NotNil(t, initialFpsInfo)
+	initialVersions := len(s.GetGlobalParamsPublic().Versions)
+
+	// Remove one of the finality providers, the change shall be picked up
+	updatedFps := types.FinalityProviders{FinalityProviders: fps[1:]}
+	data, err := json.Marshal(updatedFps)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(fpsPath, data, 0o644))
+	assert.Eventually(t, func() bool {
+		return len(s.GetFinalityProvidersFromGlobalParams()) == len(fps)-1
+	}, 5*time.Second, 100*time.Millisecond, "expected finality providers to be reloaded")
+	assert.NotEqual(t, initialFpsInfo.Hash, s.GetFinalityProvidersFileInfo().Hash)
+
+	// Invalid content shall be rejected and the current params kept
+	assert.NoError(t, os.WriteFile(paramsPath, []byte(`{"versions": [{"version": 0}`), 0o644))
+	time.Sleep(1 * time.Second)
+	assert.Equal(t, initialParamsInfo.Hash, s.GetGlobalParamsFileInfo().Hash)
+	assert.Equal(t, initialVersions, len(s.GetGlobalParamsPublic().Versions))
+
+	// An empty finality providers list is treated as invalid as well
+	assert.NoError(t, os.WriteFile(fpsPath, []byte(`{"finality_providers": []}`), 0o644))
+	time.Sleep(1 * time.Second)
+	assert.Equal(t, len(fps)-1, len(s.GetFinalityProvidersFromGlobalParams()))
+}
+
+func copyTestFile(t *testing.T, src, dst string) {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		t.Fatalf("failed to read test file %s: %v", src, err)
+	}
+	if err := os.WriteFile(dst, data, 0o644); err != nil {
+		t.Fatalf("failed to write test file %s: %v", dst, err)
+	}
+}
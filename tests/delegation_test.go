@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
 	"math/rand"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/babylonchain/staking-queue-client/client"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/babylonchain/staking-api-service/internal/api/handlers"
 	"github.com/babylonchain/staking-api-service/internal/services"
@@ -17,7 +19,9 @@ import (
 )
 
 const (
-	delegationRouter = "/v1/delegation"
+	delegationRouter        = "/v1/delegation"
+	delegationHistoryRouter = "/v1/delegation/history"
+	delegationsLookupRouter = "/v1/delegations/lookup"
 )
 
 func TestGetDelegationByTxHashHex(t *testing.T) {
@@ -57,3 +61,139 @@ func TestGetDelegationByTxHashHex(t *testing.T) {
 	assert.Equal(t, "unbonded", response.Data.State)
 	assert.Equal(t, activeStakingEvent[0].StakingTxHashHex, response.Data.StakingTxHashHex)
 }
+
+func TestGetDelegationHistoryByTxHashHex(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []client.ActiveStakingEvent{*activeStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	expiredStakingEvent := client.NewExpiredStakingEvent(activeStakingEvent.StakingTxHashHex, types.ActiveTxType.ToString())
+	sendTestMessage(testServer.Queues.ExpiredStakingQueueClient, []client.ExpiredStakingEvent{expiredStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	withdrawEvent := client.WithdrawStakingEvent{
+		EventType:        client.WithdrawStakingEventType,
+		StakingTxHashHex: activeStakingEvent.StakingTxHashHex,
+	}
+	sendTestMessage(testServer.Queues.WithdrawStakingQueueClient, []client.WithdrawStakingEvent{withdrawEvent})
+	time.Sleep(2 * time.Second)
+
+	url := testServer.Server.URL + delegationHistoryRouter + "?staking_tx_hash_hex=" + activeStakingEvent.StakingTxHashHex
+	resp, err := http.Get(url)
+	assert.NoError(t, err, "making GET request to delegation history should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[[]services.DelegationStateHistoryPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	history := response.Data
+	assert.Equal(t, 3, len(history))
+	assert.Equal(t, "", history[0].PreviousState)
+	assert.Equal(t, types.Active.ToString(), history[0].NewState)
+	assert.Equal(t, activeStakingEvent.StakingStartHeight, history[0].BtcHeight)
+	assert.Equal(t, types.ActiveStakingEventSource.ToString(), history[0].Source)
+	assert.Equal(t, types.Active.ToString(), history[1].PreviousState)
+	assert.Equal(t, types.Unbonded.ToString(), history[1].NewState)
+	assert.Equal(t, activeStakingEvent.StakingStartHeight+activeStakingEvent.StakingTimeLock, history[1].BtcHeight)
+	assert.Equal(t, types.ExpiredStakingEventSource.ToString(), history[1].Source)
+	assert.Equal(t, types.Unbonded.ToString(), history[2].PreviousState)
+	assert.Equal(t, types.Withdrawn.ToString(), history[2].NewState)
+	assert.Equal(t, types.WithdrawStakingEventSource.ToString(), history[2].Source)
+
+	// The same timeline shall be embedded in the delegation if requested
+	url = testServer.Server.URL + delegationRouter + "?include_timeline=true&staking_tx_hash_hex=" + activeStakingEvent.StakingTxHashHex
+	resp, err = http.Get(url)
+	assert.NoError(t, err, "making GET request to delegation by tx hash should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err = io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var delegationResponse handlers.PublicResponse[services.DelegationPublic]
+	err = json.Unmarshal(bodyBytes, &delegationResponse)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	assert.Equal(t, types.Withdrawn.ToString(), delegationResponse.Data.State)
+	assert.Equal(t, history, delegationResponse.Data.Timeline)
+}
+
+func TestGetDelegationHistoryShouldReturnNotFoundForUnknownTxHash(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	_, txHashHex := randomBytes(r, 32)
+	resp, err := http.Get(testServer.Server.URL + delegationHistoryRouter + "?staking_tx_hash_hex=" + txHashHex)
+	assert.NoError(t, err, "making GET request to delegation history should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "expected HTTP 404 Not Found status")
+}
+
+func postDelegationsLookup(t *testing.T, testServer *TestServer, txHashHexes []string) *http.Response {
+	payload, err := json.Marshal(handlers.DelegationsLookupRequestPayload{StakingTxHashHexes: txHashHexes})
+	require.NoError(t, err)
+	resp, err := http.Post(testServer.Server.URL+delegationsLookupRouter, "application/json", bytes.NewReader(payload))
+	require.NoError(t, err, "making POST request to delegations lookup should not fail")
+	return resp
+}
+
+func TestLookupDelegationsByTxHashHexes(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       3,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           generatePks(t, 1),
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	_, unknownTxHashHex := randomBytes(r, 32)
+	txHashHexes := []string{
+		activeStakingEvents[2].StakingTxHashHex,
+		unknownTxHashHex,
+		activeStakingEvents[0].StakingTxHashHex,
+		activeStakingEvents[2].StakingTxHashHex,
+	}
+	resp := postDelegationsLookup(t, testServer, txHashHexes)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	var response handlers.PublicResponse[services.DelegationsLookupPublic]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+
+	// The results follow the request order and the duplicates are reported once
+	require.Len(t, response.Data.Found, 2)
+	assert.Equal(t, activeStakingEvents[2].StakingTxHashHex, response.Data.Found[0].StakingTxHashHex)
+	assert.Equal(t, activeStakingEvents[0].StakingTxHashHex, response.Data.Found[1].StakingTxHashHex)
+	assert.Equal(t, types.Active.ToString(), response.Data.Found[0].State)
+	assert.Equal(t, []string{unknownTxHashHex}, response.Data.NotFound)
+}
+
+func TestLookupDelegationsShouldRejectInvalidPayload(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	tooManyTxHashHexes := make([]string, testServer.Config.Server.MaxDelegationLookupSize+1)
+	for i := range tooManyTxHashHexes {
+		_, tooManyTxHashHexes[i] = randomBytes(r, 32)
+	}
+	_, validTxHashHex := randomBytes(r, 32)
+
+	for _, txHashHexes := range [][]string{
+		nil,
+		tooManyTxHashHexes,
+		{validTxHashHex, "invalid"},
+	} {
+		resp := postDelegationsLookup(t, testServer, txHashHexes)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+	}
+}
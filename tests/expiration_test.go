@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/babylonchain/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonchain/staking-api-service/internal/api/handlers"
+	"github.com/babylonchain/staking-api-service/internal/services"
+)
+
+const expirationsPath = "/v1/expirations"
+
+func TestExpirationsForecastShouldBeNotFoundWithoutBtcHeight(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	resp, err := http.Get(testServer.Server.URL + expirationsPath)
+	require.NoError(t, err, "making GET request to expirations endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "expected HTTP 404 Not Found status")
+}
+
+func TestExpirationsForecastShouldRejectInvalidQuery(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	for _, query := range []string{"within_blocks=abc", "within_blocks=4321", "bucket_blocks=-1"} {
+		resp, err := http.Get(testServer.Server.URL + expirationsPath + "?" + query)
+		require.NoError(t, err, "making GET request to expirations endpoint should not fail")
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+	}
+}
+
+func TestExpirationsForecast(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	events := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       3,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           generatePks(t, 1),
+	})
+	for i, timelock := range []uint64{10, 120, 150} {
+		events[i].StakingStartHeight = 1000
+		events[i].StakingTimeLock = timelock
+	}
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, events)
+	require.NoError(t, err)
+	btcInfoEvent := &client.BtcInfoEvent{
+		EventType:      client.BtcInfoEventType,
+		Height:         1000,
+		ConfirmedTvl:   0,
+		UnconfirmedTvl: 0,
+	}
+	err = sendTestMessage(testServer.Queues.BtcInfoQueueClient, []*client.BtcInfoEvent{btcInfoEvent})
+	require.NoError(t, err)
+	time.Sleep(3 * time.Second)
+
+	resp, err := http.Get(testServer.Server.URL + expirationsPath + "?within_blocks=144&bucket_blocks=100")
+	require.NoError(t, err, "making GET request to expirations endpoint should not fail")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	var response handlers.PublicResponse[services.ExpirationsForecastPublic]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+
+	forecast := response.Data
+	assert.Equal(t, uint64(1000), forecast.BtcHeight)
+	assert.Equal(t, uint64(2), forecast.TotalDelegations, "the expiry after the forecast range should be skipped")
+	assert.Equal(t, events[0].StakingValue+events[1].StakingValue, forecast.TotalStakingValue)
+	require.Len(t, forecast.Buckets, 2)
+	assert.Equal(t, uint64(1001), forecast.Buckets[0].StartHeight)
+	assert.Equal(t, uint64(1100), forecast.Buckets[0].EndHeight)
+	assert.Equal(t, uint64(1), forecast.Buckets[0].Delegations)
+	assert.Equal(t, events[0].StakingValue, forecast.Buckets[0].StakingValue)
+	assert.Equal(t, uint64(1101), forecast.Buckets[1].StartHeight)
+	assert.Equal(t, uint64(1144), forecast.Buckets[1].EndHeight)
+	assert.Equal(t, uint64(1), forecast.Buckets[1].Delegations)
+	assert.Equal(t, events[1].StakingValue, forecast.Buckets[1].StakingValue)
+
+	// The delegation reports its own expiry
+	resp, err = http.Get(testServer.Server.URL + "/v1/delegation?staking_tx_hash_hex=" + events[0].StakingTxHashHex)
+	require.NoError(t, err, "making GET request to delegation endpoint should not fail")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	var delegationResponse handlers.PublicResponse[services.DelegationPublic]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&delegationResponse))
+	assert.Equal(t, uint64(1010), delegationResponse.Data.ExpireHeight)
+	require.NotNil(t, delegationResponse.Data.BlocksRemaining)
+	assert.Equal(t, uint64(10), *delegationResponse.Data.BlocksRemaining)
+	assert.NotEmpty(t, delegationResponse.Data.EstimatedUnlockTime)
+}
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -16,8 +17,10 @@ import (
 	"github.com/babylonchain/staking-api-service/internal/services"
 	"github.com/babylonchain/staking-api-service/internal/types"
 	testmock "github.com/babylonchain/staking-api-service/tests/mocks"
+	"github.com/babylonchain/staking-queue-client/client"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 const (
@@ -329,6 +332,153 @@ func FuzzShouldNotReturnDefaultFpFromParamsWhenPageTokenIsPresent(f *testing.F)
 	})
 }
 
+func TestGetFinalityProviderDetailsWithDelegations(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().Unix()))
+	// Registered finality provider in the test finality providers file
+	fpPk := "0d2f9728abc45c0cdeefdd73f52a0e0102470e35fb689fc5bc681959a61b021f"
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        5,
+		FinalityProviders:  []string{fpPk},
+		Stakers:            generatePks(t, 2),
+		EnforceNotOverflow: true,
+	})
+	uniqueStakers := make(map[string]struct{})
+	var totalTvl int64
+	for _, event := range activeStakingEvents {
+		uniqueStakers[event.StakerPkHex] = struct{}{}
+		totalTvl += int64(event.StakingValue)
+	}
+
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(5 * time.Second)
+
+	fp := fetchFinalityProviderDetails(t, testServer, fpPk, "")
+	assert.Equal(t, fpPk, fp.BtcPk)
+	assert.NotEmpty(t, fp.Description.Moniker)
+	assert.Equal(t, totalTvl, fp.ActiveTvl)
+	assert.Equal(t, totalTvl, fp.TotalTvl)
+	assert.Equal(t, int64(len(activeStakingEvents)), fp.ActiveDelegations)
+	assert.Equal(t, int64(len(uniqueStakers)), fp.ActiveStakers)
+	assert.Equal(t, int64(len(uniqueStakers)), fp.TotalStakers)
+	assert.Equal(t, len(activeStakingEvents), len(fp.Delegations))
+	for i := 0; i < len(fp.Delegations)-1; i++ {
+		assert.True(t, fp.Delegations[i].StakingTx.StartHeight >= fp.Delegations[i+1].StakingTx.StartHeight)
+	}
+
+	// Filter the delegations by state
+	fp = fetchFinalityProviderDetails(t, testServer, fpPk, "?state=unbonded")
+	assert.Equal(t, 0, len(fp.Delegations))
+	assert.Equal(t, int64(len(activeStakingEvents)), fp.ActiveDelegations)
+}
+
+func TestFinalityProviderStakersShouldBeBackfilled(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().Unix()))
+	// Registered finality provider in the test finality providers file
+	fpPk := "0d2f9728abc45c0cdeefdd73f52a0e0102470e35fb689fc5bc681959a61b021f"
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:        5,
+		FinalityProviders:  []string{fpPk},
+		Stakers:            generatePks(t, 2),
+		EnforceNotOverflow: true,
+	})
+	uniqueStakers := make(map[string]struct{})
+	for _, event := range activeStakingEvents {
+		uniqueStakers[event.StakerPkHex] = struct{}{}
+	}
+
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(5 * time.Second)
+
+	// Drop the counters as if the delegations were processed before they were introduced
+	connection := directDbConnection(t)
+	_, err := connection.Client.Database(connection.DbName).
+		Collection(model.FinalityProviderStakerStatsCollection).
+		DeleteMany(context.Background(), bson.M{})
+	assert.NoError(t, err)
+
+	// Unbonding a delegation without counter shall not decrement the stakers
+	unbondingEvent := client.NewUnbondingStakingEvent(
+		activeStakingEvents[0].StakingTxHashHex,
+		activeStakingEvents[0].StakingStartHeight+100,
+		time.Now().Unix(),
+		10,
+		1,
+		activeStakingEvents[0].StakingTxHex,     // mocked data, it doesn't matter in stats calculation
+		activeStakingEvents[0].StakingTxHashHex, // mocked data, it doesn't matter in stats calculation
+	)
+	sendTestMessage(testServer.Queues.UnbondingStakingQueueClient, []client.UnbondingStakingEvent{unbondingEvent})
+	time.Sleep(2 * time.Second)
+
+	fpStakerStats, err := inspectDbDocuments[model.FinalityProviderStakerStatsDocument](
+		t, model.FinalityProviderStakerStatsCollection,
+	)
+	assert.NoError(t, err)
+	assert.Empty(t, fpStakerStats)
+	fp := fetchFinalityProviderDetails(t, testServer, fpPk, "")
+	assert.Equal(t, int64(len(uniqueStakers)), fp.ActiveStakers)
+
+	// The backfill rebuilds the counters from the delegations
+	err = connection.BackfillFinalityProviderStakerStats(context.Background())
+	assert.NoError(t, err)
+
+	fpStakerStats, err = inspectDbDocuments[model.FinalityProviderStakerStatsDocument](
+		t, model.FinalityProviderStakerStatsCollection,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, len(uniqueStakers), len(fpStakerStats))
+	var activeDelegations, totalDelegations int64
+	for _, stats := range fpStakerStats {
+		assert.True(t, stats.ActiveDelegations >= 0)
+		activeDelegations += stats.ActiveDelegations
+		totalDelegations += stats.TotalDelegations
+	}
+	assert.Equal(t, int64(len(activeStakingEvents)-1), activeDelegations)
+	assert.Equal(t, int64(len(activeStakingEvents)), totalDelegations)
+
+	fp = fetchFinalityProviderDetails(t, testServer, fpPk, "")
+	assert.Equal(t, int64(len(uniqueStakers)), fp.TotalStakers)
+	assert.True(t, fp.ActiveStakers >= int64(len(uniqueStakers)-1) && fp.ActiveStakers <= int64(len(uniqueStakers)))
+}
+
+func TestGetFinalityProviderDetailsShouldReturn4xxErrors(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	resp, err := http.Get(testServer.Server.URL + finalityProvidersPath + "/invalid")
+	assert.NoError(t, err, "making GET request to finality provider endpoint should not fail")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 for invalid pk")
+
+	// Neither registered nor delegated to
+	unknownFpPk, err := randomPk()
+	assert.NoError(t, err)
+	resp, err = http.Get(testServer.Server.URL + finalityProvidersPath + "/" + unknownFpPk)
+	assert.NoError(t, err, "making GET request to finality provider endpoint should not fail")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "expected HTTP 404 for unknown finality provider")
+}
+
+func fetchFinalityProviderDetails(
+	t *testing.T, testServer *TestServer, fpPk, query string,
+) services.FpDetailsWithDelegationsPublic {
+	resp, err := http.Get(testServer.Server.URL + finalityProvidersPath + "/" + fpPk + query)
+	assert.NoError(t, err, "making GET request to finality provider endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+
+	var responseBody handlers.PublicResponse[services.FpDetailsWithDelegationsPublic]
+	err = json.Unmarshal(bodyBytes, &responseBody)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	return responseBody.Data
+}
+
 func generateFinalityProviderStatsDocument(r *rand.Rand, pk string) *model.FinalityProviderStatsDocument {
 	return &model.FinalityProviderStatsDocument{
 		FinalityProviderPkHex: pk,
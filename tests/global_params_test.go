@@ -5,7 +5,9 @@ import (
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/babylonchain/staking-queue-client/client"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/babylonchain/staking-api-service/internal/api/handlers"
@@ -13,7 +15,9 @@ import (
 )
 
 const (
-	globalParamsPath = "/v1/global-params"
+	globalParamsPath         = "/v1/global-params"
+	globalParamsAtHeightPath = "/v1/global-params/at-height"
+	currentGlobalParamsPath  = "/v1/global-params/current"
 )
 
 func TestGlobalParams(t *testing.T) {
@@ -84,3 +88,74 @@ func TestGlobalParams(t *testing.T) {
 	assert.Equal(t, uint64(1000), versionedGlobalParam4.CapHeight)
 	assert.Equal(t, uint64(0), versionedGlobalParam4.StakingCap)
 }
+
+func TestGlobalParamsAtHeight(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	// Before the first version is activated
+	result := fetchGlobalParamsAtHeight(t, testServer.Server.URL+globalParamsAtHeightPath+"?height=99")
+	assert.Nil(t, result.Current)
+	assert.Equal(t, uint64(0), result.Next.Version)
+	assert.Equal(t, uint64(1), result.BlocksUntilNext)
+
+	result = fetchGlobalParamsAtHeight(t, testServer.Server.URL+globalParamsAtHeightPath+"?height=250")
+	assert.Equal(t, uint64(250), result.BtcHeight)
+	assert.Equal(t, uint64(1), result.Current.Version)
+	assert.Equal(t, uint64(2), result.Next.Version)
+	assert.Equal(t, uint64(50), result.BlocksUntilNext)
+
+	// The last version has no next version scheduled
+	result = fetchGlobalParamsAtHeight(t, testServer.Server.URL+globalParamsAtHeightPath+"?height=400")
+	assert.Equal(t, uint64(3), result.Current.Version)
+	assert.Nil(t, result.Next)
+	assert.Equal(t, uint64(0), result.BlocksUntilNext)
+
+	for _, query := range []string{"", "?height=abc", "?height=-1"} {
+		resp, err := http.Get(testServer.Server.URL + globalParamsAtHeightPath + query)
+		assert.NoError(t, err, "making GET request to global params at height endpoint should not fail")
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 for query "+query)
+	}
+}
+
+func TestCurrentGlobalParams(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	// No btc info has been processed yet
+	resp, err := http.Get(testServer.Server.URL + currentGlobalParamsPath)
+	assert.NoError(t, err, "making GET request to current global params endpoint should not fail")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "expected HTTP 404 Not Found status")
+
+	btcInfoEvent := &client.BtcInfoEvent{
+		EventType:      client.BtcInfoEventType,
+		Height:         320,
+		ConfirmedTvl:   0,
+		UnconfirmedTvl: 0,
+	}
+	sendTestMessage(testServer.Queues.BtcInfoQueueClient, []*client.BtcInfoEvent{btcInfoEvent})
+	time.Sleep(2 * time.Second)
+
+	result := fetchGlobalParamsAtHeight(t, testServer.Server.URL+currentGlobalParamsPath)
+	assert.Equal(t, uint64(320), result.BtcHeight)
+	assert.Equal(t, uint64(2), result.Current.Version)
+	assert.Equal(t, uint64(3), result.Next.Version)
+	assert.Equal(t, uint64(80), result.BlocksUntilNext)
+}
+
+func fetchGlobalParamsAtHeight(t *testing.T, url string) services.GlobalParamsAtHeightPublic {
+	resp, err := http.Get(url)
+	assert.NoError(t, err, "making GET request to global params endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+
+	var responseBody handlers.PublicResponse[services.GlobalParamsAtHeightPublic]
+	err = json.Unmarshal(bodyBytes, &responseBody)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	return responseBody.Data
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/babylonchain/staking-api-service/internal/api/handlers"
 	testmock "github.com/babylonchain/staking-api-service/tests/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -41,6 +42,28 @@ func TestHealthCheck(t *testing.T) {
 	assert.Equal(t, "Server is up and running", responseBody["data"], "expected response body to match")
 }
 
+func TestHealthCheckWithDetails(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	resp, err := http.Get(testServer.Server.URL + healthCheckPath + "?details=true")
+	assert.NoError(t, err, "making GET request to health check endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+
+	var responseBody handlers.PublicResponse[handlers.HealthCheckDetailsPublic]
+	err = json.Unmarshal(bodyBytes, &responseBody)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	assert.Equal(t, "Server is up and running", responseBody.Data.Status)
+	// The test server is not loading the config files through the watcher
+	assert.Nil(t, responseBody.Data.GlobalParams)
+	assert.Nil(t, responseBody.Data.FinalityProviders)
+}
+
 // Test the db connection error case
 func TestHealthCheckDBError(t *testing.T) {
 	mockDB := new(testmock.DBClient)
@@ -5,11 +5,18 @@ package mocks
 import (
 	context "context"
 
+	bson "go.mongodb.org/mongo-driver/bson"
+
 	db "github.com/babylonchain/staking-api-service/internal/db"
+
 	mock "github.com/stretchr/testify/mock"
 
 	model "github.com/babylonchain/staking-api-service/internal/db/model"
 
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+
+	time "time"
+
 	types "github.com/babylonchain/staking-api-service/internal/types"
 )
 
@@ -18,12 +25,30 @@ type DBClient struct {
 	mock.Mock
 }
 
-// CheckDelegationExistByStakerTaprootAddress provides a mock function with given fields: ctx, address, extraFilter
-func (_m *DBClient) CheckDelegationExistByStakerTaprootAddress(ctx context.Context, address string, extraFilter *db.DelegationFilter) (bool, error) {
+// BackfillFinalityProviderStakerStats provides a mock function with given fields: ctx
+func (_m *DBClient) BackfillFinalityProviderStakerStats(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BackfillFinalityProviderStakerStats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CheckDelegationExistByStakerAddress provides a mock function with given fields: ctx, address, extraFilter
+func (_m *DBClient) CheckDelegationExistByStakerAddress(ctx context.Context, address string, extraFilter *db.DelegationFilter) (bool, error) {
 	ret := _m.Called(ctx, address, extraFilter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CheckDelegationExistByStakerTaprootAddress")
+		panic("no return value specified for CheckDelegationExistByStakerAddress")
 	}
 
 	var r0 bool
@@ -46,6 +71,146 @@ func (_m *DBClient) CheckDelegationExistByStakerTaprootAddress(ctx context.Conte
 	return r0, r1
 }
 
+// CheckDelegationExistByStakerPk provides a mock function with given fields: ctx, stakerPk
+func (_m *DBClient) CheckDelegationExistByStakerPk(ctx context.Context, stakerPk string) (bool, error) {
+	ret := _m.Called(ctx, stakerPk)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckDelegationExistByStakerPk")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, stakerPk)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, stakerPk)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stakerPk)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClaimDueWebhookDelivery provides a mock function with given fields: ctx, now, leaseUntil
+func (_m *DBClient) ClaimDueWebhookDelivery(ctx context.Context, now int64, leaseUntil int64) (*model.WebhookDeliveryDocument, error) {
+	ret := _m.Called(ctx, now, leaseUntil)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDueWebhookDelivery")
+	}
+
+	var r0 *model.WebhookDeliveryDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (*model.WebhookDeliveryDocument, error)); ok {
+		return rf(ctx, now, leaseUntil)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *model.WebhookDeliveryDocument); ok {
+		r0 = rf(ctx, now, leaseUntil)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WebhookDeliveryDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, now, leaseUntil)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompleteIdempotencyKey provides a mock function with given fields: ctx, id, lockedUntil, statusCode, errorCode, errorMessage
+func (_m *DBClient) CompleteIdempotencyKey(ctx context.Context, id string, lockedUntil time.Time, statusCode int, errorCode string, errorMessage string) error {
+	ret := _m.Called(ctx, id, lockedUntil, statusCode, errorCode, errorMessage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, int, string, string) error); ok {
+		r0 = rf(ctx, id, lockedUntil, statusCode, errorCode, errorMessage)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountStakersWithHigherActiveTvl provides a mock function with given fields: ctx, activeTvl
+func (_m *DBClient) CountStakersWithHigherActiveTvl(ctx context.Context, activeTvl int64) (int64, error) {
+	ret := _m.Called(ctx, activeTvl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountStakersWithHigherActiveTvl")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, activeTvl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, activeTvl)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, activeTvl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteIdempotencyKey provides a mock function with given fields: ctx, id, lockedUntil
+func (_m *DBClient) DeleteIdempotencyKey(ctx context.Context, id string, lockedUntil time.Time) error {
+	ret := _m.Called(ctx, id, lockedUntil)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, lockedUntil)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteResponseCacheGroup provides a mock function with given fields: ctx, group
+func (_m *DBClient) DeleteResponseCacheGroup(ctx context.Context, group string) error {
+	ret := _m.Called(ctx, group)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteResponseCacheGroup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, group)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteUnprocessableMessage provides a mock function with given fields: ctx, Receipt
 func (_m *DBClient) DeleteUnprocessableMessage(ctx context.Context, Receipt interface{}) error {
 	ret := _m.Called(ctx, Receipt)
@@ -64,6 +229,72 @@ func (_m *DBClient) DeleteUnprocessableMessage(ctx context.Context, Receipt inte
 	return r0
 }
 
+// DeleteUnprocessableMessageWithAudit provides a mock function with given fields: ctx, id, audit
+func (_m *DBClient) DeleteUnprocessableMessageWithAudit(ctx context.Context, id primitive.ObjectID, audit *model.UnprocessableMessageAuditDocument) error {
+	ret := _m.Called(ctx, id, audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUnprocessableMessageWithAudit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *model.UnprocessableMessageAuditDocument) error); ok {
+		r0 = rf(ctx, id, audit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteWebhookSubscription provides a mock function with given fields: ctx, id
+func (_m *DBClient) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhookSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindApiKeyByHash provides a mock function with given fields: ctx, keyHash
+func (_m *DBClient) FindApiKeyByHash(ctx context.Context, keyHash string) (*model.ApiKeyDocument, error) {
+	ret := _m.Called(ctx, keyHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindApiKeyByHash")
+	}
+
+	var r0 *model.ApiKeyDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.ApiKeyDocument, error)); ok {
+		return rf(ctx, keyHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.ApiKeyDocument); ok {
+		r0 = rf(ctx, keyHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ApiKeyDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, keyHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindDelegationByTxHashHex provides a mock function with given fields: ctx, txHashHex
 func (_m *DBClient) FindDelegationByTxHashHex(ctx context.Context, txHashHex string) (*model.DelegationDocument, error) {
 	ret := _m.Called(ctx, txHashHex)
@@ -94,9 +325,99 @@ func (_m *DBClient) FindDelegationByTxHashHex(ctx context.Context, txHashHex str
 	return r0, r1
 }
 
-// FindDelegationsByStakerPk provides a mock function with given fields: ctx, stakerPk, paginationToken
-func (_m *DBClient) FindDelegationsByStakerPk(ctx context.Context, stakerPk string, paginationToken string) (*db.DbResultMap[model.DelegationDocument], error) {
-	ret := _m.Called(ctx, stakerPk, paginationToken)
+// FindDelegationStateHistory provides a mock function with given fields: ctx, stakingTxHashHex
+func (_m *DBClient) FindDelegationStateHistory(ctx context.Context, stakingTxHashHex string) ([]model.DelegationStateHistoryDocument, error) {
+	ret := _m.Called(ctx, stakingTxHashHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationStateHistory")
+	}
+
+	var r0 []model.DelegationStateHistoryDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.DelegationStateHistoryDocument, error)); ok {
+		return rf(ctx, stakingTxHashHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.DelegationStateHistoryDocument); ok {
+		r0 = rf(ctx, stakingTxHashHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DelegationStateHistoryDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stakingTxHashHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationStateTotalsByStakerPks provides a mock function with given fields: ctx, stakerPks
+func (_m *DBClient) FindDelegationStateTotalsByStakerPks(ctx context.Context, stakerPks []string) ([]model.DelegationStateTotal, error) {
+	ret := _m.Called(ctx, stakerPks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationStateTotalsByStakerPks")
+	}
+
+	var r0 []model.DelegationStateTotal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]model.DelegationStateTotal, error)); ok {
+		return rf(ctx, stakerPks)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []model.DelegationStateTotal); ok {
+		r0 = rf(ctx, stakerPks)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DelegationStateTotal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, stakerPks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationsByFinalityProviderPk provides a mock function with given fields: ctx, fpPkHex, extraFilter, paginationToken
+func (_m *DBClient) FindDelegationsByFinalityProviderPk(ctx context.Context, fpPkHex string, extraFilter *db.DelegationFilter, paginationToken string) (*db.DbResultMap[model.DelegationDocument], error) {
+	ret := _m.Called(ctx, fpPkHex, extraFilter, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationsByFinalityProviderPk")
+	}
+
+	var r0 *db.DbResultMap[model.DelegationDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *db.DelegationFilter, string) (*db.DbResultMap[model.DelegationDocument], error)); ok {
+		return rf(ctx, fpPkHex, extraFilter, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *db.DelegationFilter, string) *db.DbResultMap[model.DelegationDocument]); ok {
+		r0 = rf(ctx, fpPkHex, extraFilter, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[model.DelegationDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *db.DelegationFilter, string) error); ok {
+		r1 = rf(ctx, fpPkHex, extraFilter, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationsByStakerPk provides a mock function with given fields: ctx, stakerPk, extraFilter, sortOrder, paginationToken
+func (_m *DBClient) FindDelegationsByStakerPk(ctx context.Context, stakerPk string, extraFilter *db.DelegationFilter, sortOrder types.SortOrder, paginationToken string) (*db.DbResultMap[model.DelegationDocument], error) {
+	ret := _m.Called(ctx, stakerPk, extraFilter, sortOrder, paginationToken)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindDelegationsByStakerPk")
@@ -104,19 +425,79 @@ func (_m *DBClient) FindDelegationsByStakerPk(ctx context.Context, stakerPk stri
 
 	var r0 *db.DbResultMap[model.DelegationDocument]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*db.DbResultMap[model.DelegationDocument], error)); ok {
-		return rf(ctx, stakerPk, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *db.DelegationFilter, types.SortOrder, string) (*db.DbResultMap[model.DelegationDocument], error)); ok {
+		return rf(ctx, stakerPk, extraFilter, sortOrder, paginationToken)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *db.DbResultMap[model.DelegationDocument]); ok {
-		r0 = rf(ctx, stakerPk, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *db.DelegationFilter, types.SortOrder, string) *db.DbResultMap[model.DelegationDocument]); ok {
+		r0 = rf(ctx, stakerPk, extraFilter, sortOrder, paginationToken)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*db.DbResultMap[model.DelegationDocument])
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, stakerPk, paginationToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *db.DelegationFilter, types.SortOrder, string) error); ok {
+		r1 = rf(ctx, stakerPk, extraFilter, sortOrder, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationsByStakerPks provides a mock function with given fields: ctx, stakerPks, extraFilter, sortOrder, paginationToken
+func (_m *DBClient) FindDelegationsByStakerPks(ctx context.Context, stakerPks []string, extraFilter *db.DelegationFilter, sortOrder types.SortOrder, paginationToken string) (*db.DbResultMap[model.DelegationDocument], error) {
+	ret := _m.Called(ctx, stakerPks, extraFilter, sortOrder, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationsByStakerPks")
+	}
+
+	var r0 *db.DbResultMap[model.DelegationDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, *db.DelegationFilter, types.SortOrder, string) (*db.DbResultMap[model.DelegationDocument], error)); ok {
+		return rf(ctx, stakerPks, extraFilter, sortOrder, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, *db.DelegationFilter, types.SortOrder, string) *db.DbResultMap[model.DelegationDocument]); ok {
+		r0 = rf(ctx, stakerPks, extraFilter, sortOrder, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[model.DelegationDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, *db.DelegationFilter, types.SortOrder, string) error); ok {
+		r1 = rf(ctx, stakerPks, extraFilter, sortOrder, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDelegationsByTxHashHexes provides a mock function with given fields: ctx, txHashHexes
+func (_m *DBClient) FindDelegationsByTxHashHexes(ctx context.Context, txHashHexes []string) ([]model.DelegationDocument, error) {
+	ret := _m.Called(ctx, txHashHexes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDelegationsByTxHashHexes")
+	}
+
+	var r0 []model.DelegationDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]model.DelegationDocument, error)); ok {
+		return rf(ctx, txHashHexes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []model.DelegationDocument); ok {
+		r0 = rf(ctx, txHashHexes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DelegationDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, txHashHexes)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -141,12 +522,550 @@ func (_m *DBClient) FindFinalityProviderStats(ctx context.Context, paginationTok
 		r0 = rf(ctx, paginationToken)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*db.DbResultMap[*model.FinalityProviderStatsDocument])
+			r0 = ret.Get(0).(*db.DbResultMap[*model.FinalityProviderStatsDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindFinalityProviderStatsByFinalityProviderPkHex provides a mock function with given fields: ctx, finalityProviderPkHex
+func (_m *DBClient) FindFinalityProviderStatsByFinalityProviderPkHex(ctx context.Context, finalityProviderPkHex []string) ([]*model.FinalityProviderStatsDocument, error) {
+	ret := _m.Called(ctx, finalityProviderPkHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFinalityProviderStatsByFinalityProviderPkHex")
+	}
+
+	var r0 []*model.FinalityProviderStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*model.FinalityProviderStatsDocument, error)); ok {
+		return rf(ctx, finalityProviderPkHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*model.FinalityProviderStatsDocument); ok {
+		r0 = rf(ctx, finalityProviderPkHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.FinalityProviderStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, finalityProviderPkHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindFinalityProviderStatsSnapshots provides a mock function with given fields: ctx, fpPkHex, from, to, interval
+func (_m *DBClient) FindFinalityProviderStatsSnapshots(ctx context.Context, fpPkHex string, from int64, to int64, interval int64) ([]model.FinalityProviderStatsSnapshotDocument, error) {
+	ret := _m.Called(ctx, fpPkHex, from, to, interval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFinalityProviderStatsSnapshots")
+	}
+
+	var r0 []model.FinalityProviderStatsSnapshotDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64, int64) ([]model.FinalityProviderStatsSnapshotDocument, error)); ok {
+		return rf(ctx, fpPkHex, from, to, interval)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64, int64) []model.FinalityProviderStatsSnapshotDocument); ok {
+		r0 = rf(ctx, fpPkHex, from, to, interval)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.FinalityProviderStatsSnapshotDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64, int64) error); ok {
+		r1 = rf(ctx, fpPkHex, from, to, interval)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindIdempotencyKey provides a mock function with given fields: ctx, id
+func (_m *DBClient) FindIdempotencyKey(ctx context.Context, id string) (*model.IdempotencyKeyDocument, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindIdempotencyKey")
+	}
+
+	var r0 *model.IdempotencyKeyDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.IdempotencyKeyDocument, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.IdempotencyKeyDocument); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.IdempotencyKeyDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindOverallStatsSnapshots provides a mock function with given fields: ctx, from, to, interval
+func (_m *DBClient) FindOverallStatsSnapshots(ctx context.Context, from int64, to int64, interval int64) ([]model.OverallStatsSnapshotDocument, error) {
+	ret := _m.Called(ctx, from, to, interval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOverallStatsSnapshots")
+	}
+
+	var r0 []model.OverallStatsSnapshotDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) ([]model.OverallStatsSnapshotDocument, error)); ok {
+		return rf(ctx, from, to, interval)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) []model.OverallStatsSnapshotDocument); ok {
+		r0 = rf(ctx, from, to, interval)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.OverallStatsSnapshotDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64) error); ok {
+		r1 = rf(ctx, from, to, interval)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindResponseCacheEntry provides a mock function with given fields: ctx, key, now
+func (_m *DBClient) FindResponseCacheEntry(ctx context.Context, key string, now time.Time) (*model.ResponseCacheDocument, error) {
+	ret := _m.Called(ctx, key, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindResponseCacheEntry")
+	}
+
+	var r0 *model.ResponseCacheDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*model.ResponseCacheDocument, error)); ok {
+		return rf(ctx, key, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *model.ResponseCacheDocument); ok {
+		r0 = rf(ctx, key, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ResponseCacheDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, key, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindStakerPkByAddress provides a mock function with given fields: ctx, address
+func (_m *DBClient) FindStakerPkByAddress(ctx context.Context, address string) (string, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStakerPkByAddress")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindStakerPksWithoutSegwitAddresses provides a mock function with given fields: ctx
+func (_m *DBClient) FindStakerPksWithoutSegwitAddresses(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStakerPksWithoutSegwitAddresses")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindStakerStatsByStakerPk provides a mock function with given fields: ctx, stakerPk
+func (_m *DBClient) FindStakerStatsByStakerPk(ctx context.Context, stakerPk string) (*model.StakerStatsDocument, error) {
+	ret := _m.Called(ctx, stakerPk)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStakerStatsByStakerPk")
+	}
+
+	var r0 *model.StakerStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.StakerStatsDocument, error)); ok {
+		return rf(ctx, stakerPk)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.StakerStatsDocument); ok {
+		r0 = rf(ctx, stakerPk)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.StakerStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stakerPk)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindStakerStatsByStakerPks provides a mock function with given fields: ctx, stakerPks
+func (_m *DBClient) FindStakerStatsByStakerPks(ctx context.Context, stakerPks []string) ([]model.StakerStatsDocument, error) {
+	ret := _m.Called(ctx, stakerPks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStakerStatsByStakerPks")
+	}
+
+	var r0 []model.StakerStatsDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]model.StakerStatsDocument, error)); ok {
+		return rf(ctx, stakerPks)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []model.StakerStatsDocument); ok {
+		r0 = rf(ctx, stakerPks)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.StakerStatsDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, stakerPks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindStakerStatsSnapshots provides a mock function with given fields: ctx, stakerPkHex, from, to, interval
+func (_m *DBClient) FindStakerStatsSnapshots(ctx context.Context, stakerPkHex string, from int64, to int64, interval int64) ([]model.StakerStatsSnapshotDocument, error) {
+	ret := _m.Called(ctx, stakerPkHex, from, to, interval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStakerStatsSnapshots")
+	}
+
+	var r0 []model.StakerStatsSnapshotDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64, int64) ([]model.StakerStatsSnapshotDocument, error)); ok {
+		return rf(ctx, stakerPkHex, from, to, interval)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64, int64) []model.StakerStatsSnapshotDocument); ok {
+		r0 = rf(ctx, stakerPkHex, from, to, interval)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.StakerStatsSnapshotDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64, int64) error); ok {
+		r1 = rf(ctx, stakerPkHex, from, to, interval)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTimeLockExpirationBuckets provides a mock function with given fields: ctx, fromHeight, toHeight, bucketBlocks
+func (_m *DBClient) FindTimeLockExpirationBuckets(ctx context.Context, fromHeight uint64, toHeight uint64, bucketBlocks uint64) ([]model.TimeLockExpirationBucket, error) {
+	ret := _m.Called(ctx, fromHeight, toHeight, bucketBlocks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTimeLockExpirationBuckets")
+	}
+
+	var r0 []model.TimeLockExpirationBucket
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint64) ([]model.TimeLockExpirationBucket, error)); ok {
+		return rf(ctx, fromHeight, toHeight, bucketBlocks)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint64) []model.TimeLockExpirationBucket); ok {
+		r0 = rf(ctx, fromHeight, toHeight, bucketBlocks)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TimeLockExpirationBucket)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, uint64) error); ok {
+		r1 = rf(ctx, fromHeight, toHeight, bucketBlocks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTopStakersByTvl provides a mock function with given fields: ctx, paginationToken
+func (_m *DBClient) FindTopStakersByTvl(ctx context.Context, paginationToken string) (*db.DbResultMap[*model.StakerStatsDocument], error) {
+	ret := _m.Called(ctx, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTopStakersByTvl")
+	}
+
+	var r0 *db.DbResultMap[*model.StakerStatsDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.DbResultMap[*model.StakerStatsDocument], error)); ok {
+		return rf(ctx, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *db.DbResultMap[*model.StakerStatsDocument]); ok {
+		r0 = rf(ctx, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[*model.StakerStatsDocument])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, paginationToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUnbondingTxByHashHex provides a mock function with given fields: ctx, unbondingTxHashHex
+func (_m *DBClient) FindUnbondingTxByHashHex(ctx context.Context, unbondingTxHashHex string) (*model.UnbondingDocument, error) {
+	ret := _m.Called(ctx, unbondingTxHashHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnbondingTxByHashHex")
+	}
+
+	var r0 *model.UnbondingDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.UnbondingDocument, error)); ok {
+		return rf(ctx, unbondingTxHashHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.UnbondingDocument); ok {
+		r0 = rf(ctx, unbondingTxHashHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UnbondingDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, unbondingTxHashHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUnbondingTxByStakingTxHashHex provides a mock function with given fields: ctx, stakingTxHashHex
+func (_m *DBClient) FindUnbondingTxByStakingTxHashHex(ctx context.Context, stakingTxHashHex string) (*model.UnbondingDocument, error) {
+	ret := _m.Called(ctx, stakingTxHashHex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnbondingTxByStakingTxHashHex")
+	}
+
+	var r0 *model.UnbondingDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.UnbondingDocument, error)); ok {
+		return rf(ctx, stakingTxHashHex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.UnbondingDocument); ok {
+		r0 = rf(ctx, stakingTxHashHex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UnbondingDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stakingTxHashHex)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUnprocessableMessageById provides a mock function with given fields: ctx, id
+func (_m *DBClient) FindUnprocessableMessageById(ctx context.Context, id primitive.ObjectID) (*model.UnprocessableMessageDocument, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnprocessableMessageById")
+	}
+
+	var r0 *model.UnprocessableMessageDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) (*model.UnprocessableMessageDocument, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) *model.UnprocessableMessageDocument); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UnprocessableMessageDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUnprocessableMessages provides a mock function with given fields: ctx
+func (_m *DBClient) FindUnprocessableMessages(ctx context.Context) ([]model.UnprocessableMessageDocument, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnprocessableMessages")
+	}
+
+	var r0 []model.UnprocessableMessageDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.UnprocessableMessageDocument, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.UnprocessableMessageDocument); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UnprocessableMessageDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUnprocessableMessagesByFilter provides a mock function with given fields: ctx, filter
+func (_m *DBClient) FindUnprocessableMessagesByFilter(ctx context.Context, filter *db.UnprocessableMessageFilter) ([]model.UnprocessableMessageDocument, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnprocessableMessagesByFilter")
+	}
+
+	var r0 []model.UnprocessableMessageDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.UnprocessableMessageFilter) ([]model.UnprocessableMessageDocument, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *db.UnprocessableMessageFilter) []model.UnprocessableMessageDocument); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UnprocessableMessageDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *db.UnprocessableMessageFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUnprocessableMessagesWithPagination provides a mock function with given fields: ctx, filter, paginationToken
+func (_m *DBClient) FindUnprocessableMessagesWithPagination(ctx context.Context, filter *db.UnprocessableMessageFilter, paginationToken string) (*db.DbResultMap[model.UnprocessableMessageDocument], error) {
+	ret := _m.Called(ctx, filter, paginationToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnprocessableMessagesWithPagination")
+	}
+
+	var r0 *db.DbResultMap[model.UnprocessableMessageDocument]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.UnprocessableMessageFilter, string) (*db.DbResultMap[model.UnprocessableMessageDocument], error)); ok {
+		return rf(ctx, filter, paginationToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *db.UnprocessableMessageFilter, string) *db.DbResultMap[model.UnprocessableMessageDocument]); ok {
+		r0 = rf(ctx, filter, paginationToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.DbResultMap[model.UnprocessableMessageDocument])
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, paginationToken)
+	if rf, ok := ret.Get(1).(func(context.Context, *db.UnprocessableMessageFilter, string) error); ok {
+		r1 = rf(ctx, filter, paginationToken)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -154,29 +1073,29 @@ func (_m *DBClient) FindFinalityProviderStats(ctx context.Context, paginationTok
 	return r0, r1
 }
 
-// FindFinalityProviderStatsByFinalityProviderPkHex provides a mock function with given fields: ctx, finalityProviderPkHex
-func (_m *DBClient) FindFinalityProviderStatsByFinalityProviderPkHex(ctx context.Context, finalityProviderPkHex []string) ([]*model.FinalityProviderStatsDocument, error) {
-	ret := _m.Called(ctx, finalityProviderPkHex)
+// FindWebhookDeliveriesBySubscriptionId provides a mock function with given fields: ctx, subscriptionId, paginationToken
+func (_m *DBClient) FindWebhookDeliveriesBySubscriptionId(ctx context.Context, subscriptionId string, paginationToken string) (*db.DbResultMap[model.WebhookDeliveryDocument], error) {
+	ret := _m.Called(ctx, subscriptionId, paginationToken)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindFinalityProviderStatsByFinalityProviderPkHex")
+		panic("no return value specified for FindWebhookDeliveriesBySubscriptionId")
 	}
 
-	var r0 []*model.FinalityProviderStatsDocument
+	var r0 *db.DbResultMap[model.WebhookDeliveryDocument]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*model.FinalityProviderStatsDocument, error)); ok {
-		return rf(ctx, finalityProviderPkHex)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*db.DbResultMap[model.WebhookDeliveryDocument], error)); ok {
+		return rf(ctx, subscriptionId, paginationToken)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, []string) []*model.FinalityProviderStatsDocument); ok {
-		r0 = rf(ctx, finalityProviderPkHex)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *db.DbResultMap[model.WebhookDeliveryDocument]); ok {
+		r0 = rf(ctx, subscriptionId, paginationToken)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.FinalityProviderStatsDocument)
+			r0 = ret.Get(0).(*db.DbResultMap[model.WebhookDeliveryDocument])
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
-		r1 = rf(ctx, finalityProviderPkHex)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, subscriptionId, paginationToken)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -184,29 +1103,29 @@ func (_m *DBClient) FindFinalityProviderStatsByFinalityProviderPkHex(ctx context
 	return r0, r1
 }
 
-// FindTopStakersByTvl provides a mock function with given fields: ctx, paginationToken
-func (_m *DBClient) FindTopStakersByTvl(ctx context.Context, paginationToken string) (*db.DbResultMap[*model.StakerStatsDocument], error) {
-	ret := _m.Called(ctx, paginationToken)
+// FindWebhookSubscriptionById provides a mock function with given fields: ctx, id
+func (_m *DBClient) FindWebhookSubscriptionById(ctx context.Context, id string) (*model.WebhookSubscriptionDocument, error) {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindTopStakersByTvl")
+		panic("no return value specified for FindWebhookSubscriptionById")
 	}
 
-	var r0 *db.DbResultMap[*model.StakerStatsDocument]
+	var r0 *model.WebhookSubscriptionDocument
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*db.DbResultMap[*model.StakerStatsDocument], error)); ok {
-		return rf(ctx, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.WebhookSubscriptionDocument, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *db.DbResultMap[*model.StakerStatsDocument]); ok {
-		r0 = rf(ctx, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.WebhookSubscriptionDocument); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*db.DbResultMap[*model.StakerStatsDocument])
+			r0 = ret.Get(0).(*model.WebhookSubscriptionDocument)
 		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, paginationToken)
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -214,24 +1133,24 @@ func (_m *DBClient) FindTopStakersByTvl(ctx context.Context, paginationToken str
 	return r0, r1
 }
 
-// FindUnprocessableMessages provides a mock function with given fields: ctx
-func (_m *DBClient) FindUnprocessableMessages(ctx context.Context) ([]model.UnprocessableMessageDocument, error) {
+// FindWebhookSubscriptions provides a mock function with given fields: ctx
+func (_m *DBClient) FindWebhookSubscriptions(ctx context.Context) ([]model.WebhookSubscriptionDocument, error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindUnprocessableMessages")
+		panic("no return value specified for FindWebhookSubscriptions")
 	}
 
-	var r0 []model.UnprocessableMessageDocument
+	var r0 []model.WebhookSubscriptionDocument
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]model.UnprocessableMessageDocument, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.WebhookSubscriptionDocument, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []model.UnprocessableMessageDocument); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) []model.WebhookSubscriptionDocument); ok {
 		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.UnprocessableMessageDocument)
+			r0 = ret.Get(0).([]model.WebhookSubscriptionDocument)
 		}
 	}
 
@@ -334,17 +1253,17 @@ func (_m *DBClient) GetOverallStats(ctx context.Context) (*model.OverallStatsDoc
 	return r0, r1
 }
 
-// IncrementFinalityProviderStats provides a mock function with given fields: ctx, stakingTxHashHex, fpPkHex, amount
-func (_m *DBClient) IncrementFinalityProviderStats(ctx context.Context, stakingTxHashHex string, fpPkHex string, amount uint64) error {
-	ret := _m.Called(ctx, stakingTxHashHex, fpPkHex, amount)
+// IncrementFinalityProviderStats provides a mock function with given fields: ctx, stakingTxHashHex, fpPkHex, stakerPkHex, amount
+func (_m *DBClient) IncrementFinalityProviderStats(ctx context.Context, stakingTxHashHex string, fpPkHex string, stakerPkHex string, amount uint64) error {
+	ret := _m.Called(ctx, stakingTxHashHex, fpPkHex, stakerPkHex, amount)
 
 	if len(ret) == 0 {
 		panic("no return value specified for IncrementFinalityProviderStats")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) error); ok {
-		r0 = rf(ctx, stakingTxHashHex, fpPkHex, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, uint64) error); ok {
+		r0 = rf(ctx, stakingTxHashHex, fpPkHex, stakerPkHex, amount)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -406,17 +1325,173 @@ func (_m *DBClient) Ping(ctx context.Context) error {
 	return r0
 }
 
-// SaveActiveStakingDelegation provides a mock function with given fields: ctx, stakingTxHashHex, stakerPkHex, fpPkHex, stakingTxHex, amount, startHeight, timelock, outputIndex, startTimestamp, isOverflow, stakerTaprootAddress
-func (_m *DBClient) SaveActiveStakingDelegation(ctx context.Context, stakingTxHashHex string, stakerPkHex string, fpPkHex string, stakingTxHex string, amount uint64, startHeight uint64, timelock uint64, outputIndex uint64, startTimestamp int64, isOverflow bool, stakerTaprootAddress string) error {
-	ret := _m.Called(ctx, stakingTxHashHex, stakerPkHex, fpPkHex, stakingTxHex, amount, startHeight, timelock, outputIndex, startTimestamp, isOverflow, stakerTaprootAddress)
+// PopMessageProcessingAttempts provides a mock function with given fields: ctx, messageKey
+func (_m *DBClient) PopMessageProcessingAttempts(ctx context.Context, messageKey string) ([]model.MessageProcessingAttempt, error) {
+	ret := _m.Called(ctx, messageKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PopMessageProcessingAttempts")
+	}
+
+	var r0 []model.MessageProcessingAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.MessageProcessingAttempt, error)); ok {
+		return rf(ctx, messageKey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.MessageProcessingAttempt); ok {
+		r0 = rf(ctx, messageKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.MessageProcessingAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, messageKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordMessageProcessingAttempt provides a mock function with given fields: ctx, messageKey, attempt, expiresAt
+func (_m *DBClient) RecordMessageProcessingAttempt(ctx context.Context, messageKey string, attempt *model.MessageProcessingAttempt, expiresAt time.Time) error {
+	ret := _m.Called(ctx, messageKey, attempt, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordMessageProcessingAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.MessageProcessingAttempt, time.Time) error); ok {
+		r0 = rf(ctx, messageKey, attempt, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordWebhookDeliveryAttempt provides a mock function with given fields: ctx, id, attempt, state, nextAttemptAt
+func (_m *DBClient) RecordWebhookDeliveryAttempt(ctx context.Context, id primitive.ObjectID, attempt *model.WebhookDeliveryAttempt, state types.WebhookDeliveryState, nextAttemptAt int64) error {
+	ret := _m.Called(ctx, id, attempt, state, nextAttemptAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordWebhookDeliveryAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, *model.WebhookDeliveryAttempt, types.WebhookDeliveryState, int64) error); ok {
+		r0 = rf(ctx, id, attempt, state, nextAttemptAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeApiKey provides a mock function with given fields: ctx, id, revokedAt
+func (_m *DBClient) RevokeApiKey(ctx context.Context, id string, revokedAt int64) error {
+	ret := _m.Called(ctx, id, revokedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeApiKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, id, revokedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveActiveStakingDelegation provides a mock function with given fields: ctx, stakingTxHashHex, stakerPkHex, fpPkHex, stakingTxHex, amount, startHeight, timelock, outputIndex, startTimestamp, isOverflow, stakerBtcAddress
+func (_m *DBClient) SaveActiveStakingDelegation(ctx context.Context, stakingTxHashHex string, stakerPkHex string, fpPkHex string, stakingTxHex string, amount uint64, startHeight uint64, timelock uint64, outputIndex uint64, startTimestamp int64, isOverflow bool, stakerBtcAddress *model.StakerBtcAddress) error {
+	ret := _m.Called(ctx, stakingTxHashHex, stakerPkHex, fpPkHex, stakingTxHex, amount, startHeight, timelock, outputIndex, startTimestamp, isOverflow, stakerBtcAddress)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveActiveStakingDelegation")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, uint64, uint64, uint64, uint64, int64, bool, string) error); ok {
-		r0 = rf(ctx, stakingTxHashHex, stakerPkHex, fpPkHex, stakingTxHex, amount, startHeight, timelock, outputIndex, startTimestamp, isOverflow, stakerTaprootAddress)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, uint64, uint64, uint64, uint64, int64, bool, *model.StakerBtcAddress) error); ok {
+		r0 = rf(ctx, stakingTxHashHex, stakerPkHex, fpPkHex, stakingTxHex, amount, startHeight, timelock, outputIndex, startTimestamp, isOverflow, stakerBtcAddress)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveApiKey provides a mock function with given fields: ctx, apiKey
+func (_m *DBClient) SaveApiKey(ctx context.Context, apiKey *model.ApiKeyDocument) error {
+	ret := _m.Called(ctx, apiKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveApiKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ApiKeyDocument) error); ok {
+		r0 = rf(ctx, apiKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveIdempotencyKey provides a mock function with given fields: ctx, idempotencyKey
+func (_m *DBClient) SaveIdempotencyKey(ctx context.Context, idempotencyKey *model.IdempotencyKeyDocument) error {
+	ret := _m.Called(ctx, idempotencyKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.IdempotencyKeyDocument) error); ok {
+		r0 = rf(ctx, idempotencyKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveResponseCacheEntry provides a mock function with given fields: ctx, entry
+func (_m *DBClient) SaveResponseCacheEntry(ctx context.Context, entry *model.ResponseCacheDocument) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveResponseCacheEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ResponseCacheDocument) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveStatsSnapshot provides a mock function with given fields: ctx, snapshot
+func (_m *DBClient) SaveStatsSnapshot(ctx context.Context, snapshot *model.OverallStatsSnapshotDocument) error {
+	ret := _m.Called(ctx, snapshot)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveStatsSnapshot")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.OverallStatsSnapshotDocument) error); ok {
+		r0 = rf(ctx, snapshot)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -442,17 +1517,17 @@ func (_m *DBClient) SaveTimeLockExpireCheck(ctx context.Context, stakingTxHashHe
 	return r0
 }
 
-// SaveUnbondingTx provides a mock function with given fields: ctx, stakingTxHashHex, unbondingTxHashHex, txHex, signatureHex
-func (_m *DBClient) SaveUnbondingTx(ctx context.Context, stakingTxHashHex string, unbondingTxHashHex string, txHex string, signatureHex string) error {
-	ret := _m.Called(ctx, stakingTxHashHex, unbondingTxHashHex, txHex, signatureHex)
+// SaveUnbondingTx provides a mock function with given fields: ctx, stakingTxHashHex, unbondingTxHashHex, txHex, signatureHex, transition
+func (_m *DBClient) SaveUnbondingTx(ctx context.Context, stakingTxHashHex string, unbondingTxHashHex string, txHex string, signatureHex string, transition *db.StateTransitionInfo) error {
+	ret := _m.Called(ctx, stakingTxHashHex, unbondingTxHashHex, txHex, signatureHex, transition)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveUnbondingTx")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
-		r0 = rf(ctx, stakingTxHashHex, unbondingTxHashHex, txHex, signatureHex)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, *db.StateTransitionInfo) error); ok {
+		r0 = rf(ctx, stakingTxHashHex, unbondingTxHashHex, txHex, signatureHex, transition)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -460,17 +1535,71 @@ func (_m *DBClient) SaveUnbondingTx(ctx context.Context, stakingTxHashHex string
 	return r0
 }
 
-// SaveUnprocessableMessage provides a mock function with given fields: ctx, messageBody, receipt
-func (_m *DBClient) SaveUnprocessableMessage(ctx context.Context, messageBody string, receipt string) error {
-	ret := _m.Called(ctx, messageBody, receipt)
+// SaveUnprocessableMessage provides a mock function with given fields: ctx, document
+func (_m *DBClient) SaveUnprocessableMessage(ctx context.Context, document *model.UnprocessableMessageDocument) error {
+	ret := _m.Called(ctx, document)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveUnprocessableMessage")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, messageBody, receipt)
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UnprocessableMessageDocument) error); ok {
+		r0 = rf(ctx, document)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveUnprocessableMessageAudit provides a mock function with given fields: ctx, audit
+func (_m *DBClient) SaveUnprocessableMessageAudit(ctx context.Context, audit *model.UnprocessableMessageAuditDocument) error {
+	ret := _m.Called(ctx, audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveUnprocessableMessageAudit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UnprocessableMessageAuditDocument) error); ok {
+		r0 = rf(ctx, audit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveUnprocessableMessageWithAttempts provides a mock function with given fields: ctx, messageKey, document
+func (_m *DBClient) SaveUnprocessableMessageWithAttempts(ctx context.Context, messageKey string, document *model.UnprocessableMessageDocument) error {
+	ret := _m.Called(ctx, messageKey, document)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveUnprocessableMessageWithAttempts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.UnprocessableMessageDocument) error); ok {
+		r0 = rf(ctx, messageKey, document)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveWebhookSubscription provides a mock function with given fields: ctx, subscription
+func (_m *DBClient) SaveWebhookSubscription(ctx context.Context, subscription *model.WebhookSubscriptionDocument) error {
+	ret := _m.Called(ctx, subscription)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveWebhookSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.WebhookSubscriptionDocument) error); ok {
+		r0 = rf(ctx, subscription)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -478,17 +1607,17 @@ func (_m *DBClient) SaveUnprocessableMessage(ctx context.Context, messageBody st
 	return r0
 }
 
-// SubtractFinalityProviderStats provides a mock function with given fields: ctx, stakingTxHashHex, fpPkHex, amount
-func (_m *DBClient) SubtractFinalityProviderStats(ctx context.Context, stakingTxHashHex string, fpPkHex string, amount uint64) error {
-	ret := _m.Called(ctx, stakingTxHashHex, fpPkHex, amount)
+// SubtractFinalityProviderStats provides a mock function with given fields: ctx, stakingTxHashHex, fpPkHex, stakerPkHex, amount
+func (_m *DBClient) SubtractFinalityProviderStats(ctx context.Context, stakingTxHashHex string, fpPkHex string, stakerPkHex string, amount uint64) error {
+	ret := _m.Called(ctx, stakingTxHashHex, fpPkHex, stakerPkHex, amount)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SubtractFinalityProviderStats")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, uint64) error); ok {
-		r0 = rf(ctx, stakingTxHashHex, fpPkHex, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, uint64) error); ok {
+		r0 = rf(ctx, stakingTxHashHex, fpPkHex, stakerPkHex, amount)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -532,17 +1661,75 @@ func (_m *DBClient) SubtractStakerStats(ctx context.Context, stakingTxHashHex st
 	return r0
 }
 
-// TransitionToUnbondedState provides a mock function with given fields: ctx, stakingTxHashHex, eligiblePreviousState
-func (_m *DBClient) TransitionToUnbondedState(ctx context.Context, stakingTxHashHex string, eligiblePreviousState []types.DelegationState) error {
-	ret := _m.Called(ctx, stakingTxHashHex, eligiblePreviousState)
+// TakeOverIdempotencyKey provides a mock function with given fields: ctx, id, requestHash, lockedUntil
+func (_m *DBClient) TakeOverIdempotencyKey(ctx context.Context, id string, requestHash string, lockedUntil time.Time) (bool, error) {
+	ret := _m.Called(ctx, id, requestHash, lockedUntil)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TakeOverIdempotencyKey")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (bool, error)); ok {
+		return rf(ctx, id, requestHash, lockedUntil)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) bool); ok {
+		r0 = rf(ctx, id, requestHash, lockedUntil)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, id, requestHash, lockedUntil)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TakeRateLimitToken provides a mock function with given fields: ctx, key, requestsPerSecond, burst, now
+func (_m *DBClient) TakeRateLimitToken(ctx context.Context, key string, requestsPerSecond float64, burst int, now time.Time) (*model.RateLimitBucketDocument, error) {
+	ret := _m.Called(ctx, key, requestsPerSecond, burst, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TakeRateLimitToken")
+	}
+
+	var r0 *model.RateLimitBucketDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, int, time.Time) (*model.RateLimitBucketDocument, error)); ok {
+		return rf(ctx, key, requestsPerSecond, burst, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, float64, int, time.Time) *model.RateLimitBucketDocument); ok {
+		r0 = rf(ctx, key, requestsPerSecond, burst, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.RateLimitBucketDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, float64, int, time.Time) error); ok {
+		r1 = rf(ctx, key, requestsPerSecond, burst, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TransitionToUnbondedState provides a mock function with given fields: ctx, stakingTxHashHex, eligiblePreviousState, transition
+func (_m *DBClient) TransitionToUnbondedState(ctx context.Context, stakingTxHashHex string, eligiblePreviousState []types.DelegationState, transition *db.StateTransitionInfo) error {
+	ret := _m.Called(ctx, stakingTxHashHex, eligiblePreviousState, transition)
 
 	if len(ret) == 0 {
 		panic("no return value specified for TransitionToUnbondedState")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, []types.DelegationState) error); ok {
-		r0 = rf(ctx, stakingTxHashHex, eligiblePreviousState)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []types.DelegationState, *db.StateTransitionInfo) error); ok {
+		r0 = rf(ctx, stakingTxHashHex, eligiblePreviousState, transition)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -568,17 +1755,53 @@ func (_m *DBClient) TransitionToUnbondingState(ctx context.Context, txHashHex st
 	return r0
 }
 
-// TransitionToWithdrawnState provides a mock function with given fields: ctx, txHashHex
-func (_m *DBClient) TransitionToWithdrawnState(ctx context.Context, txHashHex string) error {
-	ret := _m.Called(ctx, txHashHex)
+// TransitionToWithdrawnState provides a mock function with given fields: ctx, txHashHex, transition
+func (_m *DBClient) TransitionToWithdrawnState(ctx context.Context, txHashHex string, transition *db.StateTransitionInfo) error {
+	ret := _m.Called(ctx, txHashHex, transition)
 
 	if len(ret) == 0 {
 		panic("no return value specified for TransitionToWithdrawnState")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, txHashHex)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *db.StateTransitionInfo) error); ok {
+		r0 = rf(ctx, txHashHex, transition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TransitionUnbondingState provides a mock function with given fields: ctx, stakingTxHashHex, eligiblePreviousStates, transition
+func (_m *DBClient) TransitionUnbondingState(ctx context.Context, stakingTxHashHex string, eligiblePreviousStates []string, transition *model.UnbondingStateTransition) error {
+	ret := _m.Called(ctx, stakingTxHashHex, eligiblePreviousStates, transition)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransitionUnbondingState")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, *model.UnbondingStateTransition) error); ok {
+		r0 = rf(ctx, stakingTxHashHex, eligiblePreviousStates, transition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateStakerBtcAddress provides a mock function with given fields: ctx, stakerPkHex, stakerBtcAddress
+func (_m *DBClient) UpdateStakerBtcAddress(ctx context.Context, stakerPkHex string, stakerBtcAddress *model.StakerBtcAddress) error {
+	ret := _m.Called(ctx, stakerPkHex, stakerBtcAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStakerBtcAddress")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.StakerBtcAddress) error); ok {
+		r0 = rf(ctx, stakerPkHex, stakerBtcAddress)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -604,6 +1827,36 @@ func (_m *DBClient) UpsertLatestBtcInfo(ctx context.Context, height uint64, conf
 	return r0
 }
 
+// WatchDelegations provides a mock function with given fields: ctx, resumeToken, onChange
+func (_m *DBClient) WatchDelegations(ctx context.Context, resumeToken bson.Raw, onChange func(*model.DelegationDocument)) (bson.Raw, error) {
+	ret := _m.Called(ctx, resumeToken, onChange)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchDelegations")
+	}
+
+	var r0 bson.Raw
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bson.Raw, func(*model.DelegationDocument)) (bson.Raw, error)); ok {
+		return rf(ctx, resumeToken, onChange)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bson.Raw, func(*model.DelegationDocument)) bson.Raw); ok {
+		r0 = rf(ctx, resumeToken, onChange)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(bson.Raw)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bson.Raw, func(*model.DelegationDocument)) error); ok {
+		r1 = rf(ctx, resumeToken, onChange)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewDBClient creates a new instance of DBClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDBClient(t interface {
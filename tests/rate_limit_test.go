@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonchain/staking-api-service/internal/config"
+)
+
+const (
+	testRateLimitKeyHeader = "X-Api-Key"
+	testRateLimitClientKey = "test-partner-key"
+)
+
+func rateLimitTestConfig(t *testing.T, backend string) *config.Config {
+	cfg := loadTestConfig(t)
+	cfg.RateLimit = &config.RateLimitConfig{
+		Backend:   backend,
+		KeyHeader: testRateLimitKeyHeader,
+		Groups: map[string]*config.RateLimitGroupConfig{
+			config.RateLimitPublicGroup: {
+				// The bucket is not refilled within the test
+				RateLimitRule: config.RateLimitRule{RequestsPerSecond: 0.001, Burst: 2},
+				Clients: []*config.RateLimitClientConfig{
+					{
+						RateLimitRule: config.RateLimitRule{RequestsPerSecond: 0.001, Burst: 4},
+						Key:           testRateLimitClientKey,
+					},
+				},
+			},
+		},
+	}
+	return cfg
+}
+
+func getWithRateLimitKey(t *testing.T, url, key string) *http.Response {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if key != "" {
+		req.Header.Set(testRateLimitKeyHeader, key)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp
+}
+
+func testRateLimit(t *testing.T, backend string) {
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: rateLimitTestConfig(t, backend)})
+	defer testServer.Close()
+	url := testServer.Server.URL + "/v1/global-params"
+
+	for i := 0; i < 2; i++ {
+		resp := getWithRateLimitKey(t, url, "")
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status within the burst")
+	}
+	resp := getWithRateLimitKey(t, url, "")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "expected HTTP 429 Too Many Requests status")
+	retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	require.NoError(t, err, "expected a Retry-After header in seconds")
+	assert.Greater(t, retryAfter, 0)
+
+	// An unknown key is limited by the client ip
+	resp = getWithRateLimitKey(t, url, "unknown-key")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "expected HTTP 429 Too Many Requests status")
+
+	// A configured client key has its own bucket
+	for i := 0; i < 4; i++ {
+		resp := getWithRateLimitKey(t, url, testRateLimitClientKey)
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status within the client burst")
+	}
+	resp = getWithRateLimitKey(t, url, testRateLimitClientKey)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "expected HTTP 429 Too Many Requests status")
+
+	// The routes outside of the limited groups are not limited
+	resp = getWithRateLimitKey(t, testServer.Server.URL+"/healthcheck", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+}
+
+func TestRateLimitShouldOnlyTrustForwardedIpsOfTrustedProxies(t *testing.T) {
+	cfg := rateLimitTestConfig(t, config.RateLimitMemoryBackend)
+	cfg.RateLimit.ClientIpHeader = "X-Forwarded-For"
+	cfg.RateLimit.TrustedProxies = 1
+	testServer := setupTestServer(t, &TestServerDependency{ConfigOverrides: cfg})
+	defer testServer.Close()
+	url := testServer.Server.URL + "/v1/global-params"
+
+	getWithForwardedFor := func(forwardedFor string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	// The client controlled entries on the left shall not get a new bucket
+	for i := 0; i < 2; i++ {
+		resp := getWithForwardedFor("10.0.0." + strconv.Itoa(i) + ", 203.0.113.1")
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status within the burst")
+	}
+	resp := getWithForwardedFor("10.0.0.99, 203.0.113.1")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "expected HTTP 429 Too Many Requests status")
+
+	// Another client seen by the trusted proxy has its own bucket
+	resp = getWithForwardedFor("203.0.113.2")
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+}
+
+func TestRateLimitWithMemoryBackend(t *testing.T) {
+	testRateLimit(t, config.RateLimitMemoryBackend)
+}
+
+func TestRateLimitWithMongoBackend(t *testing.T) {
+	testRateLimit(t, config.RateLimitMongoBackend)
+}
+
+func TestRateLimitHeadersShouldBeAllowedByCors(t *testing.T) {
+	testServer := setupTestServer(t, &TestServerDependency{
+		ConfigOverrides: rateLimitTestConfig(t, config.RateLimitMemoryBackend),
+	})
+	defer testServer.Close()
+	url := testServer.Server.URL + "/v1/global-params"
+	origin := "https://dashboard.testnet3.babylonchain.io"
+
+	// The browsers can send the rate limit key
+	req, err := http.NewRequest(http.MethodOptions, url, nil)
+	require.NoError(t, err)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	req.Header.Set("Access-Control-Request-Headers", testRateLimitKeyHeader)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode, "expected HTTP 204 status")
+	assert.Equal(t, testRateLimitKeyHeader, resp.Header.Get("Access-Control-Allow-Headers"),
+		"expected the rate limit key header to be allowed")
+
+	// And read when to retry once rate limited
+	for i := 0; i < 3; i++ {
+		req, err = http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", origin)
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode, "expected HTTP 429 Too Many Requests status")
+	assert.Equal(t, "Retry-After", resp.Header.Get("Access-Control-Expose-Headers"),
+		"expected the Retry-After header to be exposed")
+}
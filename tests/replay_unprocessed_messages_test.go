@@ -30,7 +30,9 @@ func TestReplayUnprocessableMessages(t *testing.T) {
 
 	doc := string(data)
 
-	injectDbDocuments(t, model.UnprocessableMsgCollection, model.NewUnprocessableMessageDocument(doc, "receipt"))
+	injectDbDocuments(t, model.UnprocessableMsgCollection, model.NewUnprocessableMessageDocument(
+		doc, "receipt", client.ActiveStakingQueueName, "error", 3, time.Now().Unix(),
+	))
 
 	db := directDbConnection(t)
 
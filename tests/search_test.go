@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/babylonchain/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonchain/staking-api-service/internal/api/handlers"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/services"
+)
+
+const searchPath = "/v1/search"
+
+func search(t *testing.T, testServer *TestServer, query string) []services.SearchResultPublic {
+	resp, err := http.Get(testServer.Server.URL + searchPath + "?q=" + url.QueryEscape(query))
+	require.NoError(t, err, "making GET request to search endpoint should not fail")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	var response handlers.PublicResponse[[]services.SearchResultPublic]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	return response.Data
+}
+
+func TestSearchShouldRejectUnclassifiableQuery(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	for _, query := range []string{"", "not-a-hash", "abcd"} {
+		resp, err := http.Get(testServer.Server.URL + searchPath + "?q=" + url.QueryEscape(query))
+		require.NoError(t, err, "making GET request to search endpoint should not fail")
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+	}
+}
+
+func TestSearchDelegationStakerAndFinalityProvider(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	events := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       1,
+		FinalityProviders: generatePks(t, 1),
+		Stakers:           generatePks(t, 1),
+	})
+	event := events[0]
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []client.ActiveStakingEvent{*event})
+	require.NoError(t, err)
+	time.Sleep(3 * time.Second)
+
+	results := search(t, testServer, event.StakingTxHashHex)
+	require.Len(t, results, 1)
+	assert.Equal(t, services.SearchResultStakingTx, results[0].Type)
+	assert.Equal(t, event.StakerPkHex, results[0].StakerPkHex)
+	assert.Equal(t, "/v1/delegation?staking_tx_hash_hex="+event.StakingTxHashHex, results[0].Link)
+
+	results = search(t, testServer, event.StakerPkHex)
+	require.Len(t, results, 1)
+	assert.Equal(t, services.SearchResultStaker, results[0].Type)
+	assert.Equal(t, "/v1/staker/delegations?staker_btc_pk="+event.StakerPkHex, results[0].Link)
+
+	results = search(t, testServer, event.FinalityProviderPkHex)
+	require.Len(t, results, 1)
+	assert.Equal(t, services.SearchResultFinalityProvider, results[0].Type)
+	assert.Equal(t, "/v1/finality-providers/"+event.FinalityProviderPkHex, results[0].Link)
+
+	// Unknown public key matches nothing
+	results = search(t, testServer, generatePks(t, 1)[0])
+	assert.Empty(t, results)
+}
+
+func TestSearchUnbondingTx(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	unbondingTx, _, err := generateRandomTx(r)
+	require.NoError(t, err)
+	unbondingTxHashHex := unbondingTx.TxHash().String()
+	stakingTxHashHex := generatePks(t, 1)[0] // any 32 bytes hex
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	injectDbDocuments(t, model.UnbondingCollection, model.UnbondingDocument{
+		StakerPkHex:        generatePks(t, 1)[0],
+		UnbondingTxHashHex: unbondingTxHashHex,
+		State:              model.UnbondingInitialState,
+		StakingTxHashHex:   stakingTxHashHex,
+	})
+
+	results := search(t, testServer, unbondingTxHashHex)
+	require.Len(t, results, 1)
+	assert.Equal(t, services.SearchResultUnbondingTx, results[0].Type)
+	assert.Equal(t, unbondingTxHashHex, results[0].Id)
+	assert.Equal(t, stakingTxHashHex, results[0].StakingTxHashHex)
+	assert.Equal(t, "/v1/delegation?staking_tx_hash_hex="+stakingTxHashHex, results[0].Link)
+}
@@ -47,9 +47,11 @@ type TestServer struct {
 	Conn    *amqp091.Connection
 	channel *amqp091.Channel
 	Config  *config.Config
+	cancel  context.CancelFunc
 }
 
 func (ts *TestServer) Close() {
+	ts.cancel()
 	ts.Server.Close()
 	ts.Queues.StopReceivingMessages()
 	ts.Conn.Close()
@@ -131,6 +133,15 @@ func setupTestServer(t *testing.T, dep *TestServerDependency) *TestServer {
 	if err != nil {
 		t.Fatalf("Failed to setup test queue: %v", err)
 	}
+	services.SetQueueMessageSender(queues.SendMessage)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if dep == nil || dep.MockDbClient == nil {
+		services.StartDelegationStream(ctx)
+		if cfg.Webhooks != nil {
+			services.StartWebhookDelivery(ctx)
+		}
+	}
 
 	// Create an httptest server
 	server := httptest.NewServer(r)
@@ -141,6 +152,7 @@ func setupTestServer(t *testing.T, dep *TestServerDependency) *TestServer {
 		Conn:    conn,
 		channel: ch,
 		Config:  cfg,
+		cancel:  cancel,
 	}
 }
 
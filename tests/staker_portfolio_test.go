@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/babylonchain/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonchain/staking-api-service/internal/api/handlers"
+	"github.com/babylonchain/staking-api-service/internal/services"
+	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
+)
+
+const stakerPortfolioPath = "/v1/staker/portfolio"
+
+func fetchStakerPortfolio(
+	t *testing.T, testServer *TestServer, query url.Values,
+) handlers.PublicResponse[services.StakerPortfolioPublic] {
+	resp, err := http.Get(testServer.Server.URL + stakerPortfolioPath + "?" + query.Encode())
+	require.NoError(t, err, "making GET request to staker portfolio should not fail")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	var response handlers.PublicResponse[services.StakerPortfolioPublic]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	return response
+}
+
+func TestStakerPortfolioAcrossMultipleKeys(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	eventsByStaker1 := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       7,
+		FinalityProviders: generatePks(t, 2),
+		Stakers:           generatePks(t, 1),
+	})
+	eventsByStaker2 := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       6,
+		FinalityProviders: generatePks(t, 2),
+		Stakers:           generatePks(t, 1),
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, append(eventsByStaker1, eventsByStaker2...))
+	time.Sleep(3 * time.Second)
+
+	unbondedEvent := eventsByStaker1[0]
+	unbondingEvent := client.NewUnbondingStakingEvent(
+		unbondedEvent.StakingTxHashHex,
+		unbondedEvent.StakingStartHeight+100,
+		time.Now().Unix(),
+		10,
+		1,
+		unbondedEvent.StakingTxHex,     // mocked data, it doesn't matter in stats calculation
+		unbondedEvent.StakingTxHashHex, // mocked data, it doesn't matter in stats calculation
+	)
+	sendTestMessage(testServer.Queues.UnbondingStakingQueueClient, []client.UnbondingStakingEvent{unbondingEvent})
+	time.Sleep(2 * time.Second)
+
+	// The second staker is provided by its address
+	staker1Pk := eventsByStaker1[0].StakerPkHex
+	staker2Pk := eventsByStaker2[0].StakerPkHex
+	staker2Addresses, err := utils.DeriveBtcAddressesFromPk(staker2Pk, testServer.Config.Server.BTCNetParam)
+	require.NoError(t, err)
+	stakerWithoutDelegation, err := randomPk()
+	require.NoError(t, err)
+	query := url.Values{
+		"staker_btc_pk": {staker1Pk, stakerWithoutDelegation},
+		"address":       {staker2Addresses.Taproot},
+	}
+
+	var staker1Tvl, staker2Tvl int64
+	for _, e := range eventsByStaker1 {
+		staker1Tvl += int64(e.StakingValue)
+	}
+	for _, e := range eventsByStaker2 {
+		staker2Tvl += int64(e.StakingValue)
+	}
+
+	// The delegations are merged and paginated with a single cursor
+	var delegations []services.DelegationPublic
+	var pages int
+	for {
+		response := fetchStakerPortfolio(t, testServer, query)
+		pages++
+		portfolio := response.Data
+
+		require.Len(t, portfolio.Stakers, 3)
+		assert.Equal(t, staker1Pk, portfolio.Stakers[0].StakerPkHex)
+		assert.Equal(t, staker1Tvl-int64(unbondedEvent.StakingValue), portfolio.Stakers[0].ActiveTvl)
+		assert.Equal(t, staker1Tvl, portfolio.Stakers[0].TotalTvl)
+		assert.Equal(t, stakerWithoutDelegation, portfolio.Stakers[1].StakerPkHex)
+		assert.Zero(t, portfolio.Stakers[1].TotalTvl)
+		assert.Equal(t, staker2Pk, portfolio.Stakers[2].StakerPkHex)
+		assert.Equal(t, staker2Tvl, portfolio.Stakers[2].ActiveTvl)
+
+		assert.Equal(t, staker1Tvl+staker2Tvl-int64(unbondedEvent.StakingValue), portfolio.Combined.ActiveTvl)
+		assert.Equal(t, staker1Tvl+staker2Tvl, portfolio.Combined.TotalTvl)
+		assert.Equal(t, int64(12), portfolio.Combined.ActiveDelegations)
+		assert.Equal(t, int64(13), portfolio.Combined.TotalDelegations)
+
+		assert.Equal(t, int64(12), portfolio.StateTotals[types.Active.ToString()].Delegations)
+		assert.Equal(t, int64(1), portfolio.StateTotals[types.Unbonding.ToString()].Delegations)
+		assert.Equal(t, unbondedEvent.StakingValue, portfolio.StateTotals[types.Unbonding.ToString()].StakingValue)
+		assert.Zero(t, portfolio.StateTotals[types.Withdrawn.ToString()].Delegations)
+
+		delegations = append(delegations, portfolio.Delegations...)
+		if response.Pagination.NextKey == "" {
+			break
+		}
+		query.Set("pagination_key", response.Pagination.NextKey)
+	}
+
+	assert.Equal(t, 2, pages, "expected the delegations to span two pages")
+	require.Len(t, delegations, 13)
+	seen := make(map[string]bool)
+	for i, d := range delegations {
+		assert.False(t, seen[d.StakingTxHashHex], "expected no duplicated delegation across pages")
+		seen[d.StakingTxHashHex] = true
+		if i > 0 {
+			assert.GreaterOrEqual(
+				t, delegations[i-1].StakingTx.StartHeight, d.StakingTx.StartHeight,
+				"expected the delegations to be sorted by start height in descending order",
+			)
+		}
+	}
+}
+
+func TestStakerPortfolioShouldRejectInvalidStakers(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	tooManyPks := make([]string, 21)
+	for i := range tooManyPks {
+		pk, err := randomPk()
+		require.NoError(t, err)
+		tooManyPks[i] = pk
+	}
+	for _, query := range []url.Values{
+		{},
+		{"staker_btc_pk": {"invalid"}},
+		{"address": {"invalid"}},
+		{"staker_btc_pk": tooManyPks},
+	} {
+		resp, err := http.Get(testServer.Server.URL + stakerPortfolioPath + "?" + query.Encode())
+		require.NoError(t, err, "making GET request to staker portfolio should not fail")
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 for query "+query.Encode())
+	}
+}
@@ -283,6 +283,189 @@ func TestGetDelegationReturnEmptySliceWhenNoDelegation(t *testing.T) {
 	assert.Equal(t, 0, len(response.Data), "expected response body to have no data")
 }
 
+func TestStakerDelegationsLookupByAnyDerivedAddress(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []client.ActiveStakingEvent{*activeStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	addresses, err := utils.DeriveBtcAddressesFromPk(
+		activeStakingEvent.StakerPkHex, testServer.Config.Server.BTCNetParam,
+	)
+	assert.NoError(t, err, "failed to derive btc addresses from staker pk")
+	for _, address := range []string{
+		addresses.Taproot,
+		addresses.NativeSegwitEven,
+		addresses.NativeSegwitOdd,
+		addresses.NestedSegwitEven,
+		addresses.NestedSegwitOdd,
+	} {
+		isExist := fetchCheckStakerActiveDelegations(t, testServer, address, "")
+		assert.True(t, isExist, "expected staker to have active delegation for address "+address)
+
+		delegations := fetchAllStakerDelegations(t, testServer, "?address="+address)
+		assert.Equal(t, 1, len(delegations))
+		assert.Equal(t, activeStakingEvent.StakingTxHashHex, delegations[0].StakingTxHashHex)
+	}
+
+	// An address without any delegation shall return an empty list
+	stakerPkWithoutDelegation, err := randomPk()
+	assert.NoError(t, err)
+	addresses, err = utils.DeriveBtcAddressesFromPk(
+		stakerPkWithoutDelegation, testServer.Config.Server.BTCNetParam,
+	)
+	assert.NoError(t, err, "failed to derive btc addresses from staker pk")
+	delegations := fetchAllStakerDelegations(t, testServer, "?address="+addresses.NestedSegwitOdd)
+	assert.Equal(t, 0, len(delegations))
+}
+
+func TestStakerDelegationsWithFiltersAndSortOrder(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().Unix()))
+	fpPks := generatePks(t, 2)
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       12,
+		FinalityProviders: fpPks,
+		Stakers:           generatePks(t, 1),
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	stakerPk := activeStakingEvents[0].StakerPkHex
+	baseQuery := "?staker_btc_pk=" + stakerPk
+
+	// Ascending order shall be kept across pages
+	delegations := fetchAllStakerDelegations(t, testServer, baseQuery+"&sort_order=asc")
+	assert.Equal(t, len(activeStakingEvents), len(delegations))
+	for i := 0; i < len(delegations)-1; i++ {
+		assert.True(t, delegations[i].StakingTx.StartHeight <= delegations[i+1].StakingTx.StartHeight,
+			"expected delegations to be sorted by start height in ascending order")
+	}
+
+	// Filter by finality provider
+	delegations = fetchAllStakerDelegations(t, testServer, baseQuery+"&finality_provider_pk_hex="+fpPks[0])
+	expected := 0
+	for _, event := range activeStakingEvents {
+		if event.FinalityProviderPkHex == fpPks[0] {
+			expected++
+		}
+	}
+	assert.Equal(t, expected, len(delegations))
+	for _, d := range delegations {
+		assert.Equal(t, fpPks[0], d.FinalityProviderPkHex)
+	}
+
+	// Filter by staking value and start height range
+	minValue, maxValue := activeStakingEvents[0].StakingValue, activeStakingEvents[0].StakingValue
+	minHeight, maxHeight := activeStakingEvents[0].StakingStartHeight, activeStakingEvents[0].StakingStartHeight
+	delegations = fetchAllStakerDelegations(t, testServer, fmt.Sprintf(
+		"%s&min_staking_value=%d&max_staking_value=%d&min_start_height=%d&max_start_height=%d",
+		baseQuery, minValue, maxValue, minHeight, maxHeight,
+	))
+	assert.NotEmpty(t, delegations)
+	for _, d := range delegations {
+		assert.Equal(t, minValue, d.StakingValue)
+		assert.Equal(t, minHeight, d.StakingTx.StartHeight)
+	}
+
+	// Filter by state
+	delegations = fetchAllStakerDelegations(t, testServer, baseQuery+"&state=unbonded&state=withdrawn")
+	assert.Equal(t, 0, len(delegations))
+	delegations = fetchAllStakerDelegations(t, testServer, baseQuery+"&state=active")
+	assert.Equal(t, len(activeStakingEvents), len(delegations))
+
+	// A zero bound shall be honoured instead of being ignored
+	delegations = fetchAllStakerDelegations(t, testServer, baseQuery+"&max_staking_value=0")
+	assert.Equal(t, 0, len(delegations))
+}
+
+func TestStakerDelegationsShouldRejectPaginationKeyOfAnotherQuery(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().Unix()))
+	activeStakingEvents := generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+		NumOfEvents:       12,
+		FinalityProviders: generatePks(t, 2),
+		Stakers:           generatePks(t, 1),
+	})
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	baseUrl := testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + activeStakingEvents[0].StakerPkHex
+	resp, err := http.Get(baseUrl + "&sort_order=asc")
+	assert.NoError(t, err, "making GET request to delegations by staker pk should not fail")
+	bodyBytes, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.NoError(t, err, "reading response body should not fail")
+
+	var response handlers.PublicResponse[[]services.DelegationPublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	assert.NotEmpty(t, response.Pagination.NextKey)
+
+	// Same query with the same pagination key shall be accepted
+	resp, err = http.Get(baseUrl + "&sort_order=asc&pagination_key=" + response.Pagination.NextKey)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The sort order or the filters differ from the ones the key was issued for
+	for _, query := range []string{"&sort_order=desc", "&sort_order=asc&min_staking_value=1"} {
+		resp, err = http.Get(baseUrl + query + "&pagination_key=" + response.Pagination.NextKey)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 for query "+query)
+	}
+}
+
+func TestStakerDelegationsShouldRejectInvalidFilters(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	stakerPk, err := randomPk()
+	assert.NoError(t, err)
+	invalidQueries := []string{
+		"&state=invalid",
+		"&finality_provider_pk_hex=invalid",
+		"&min_staking_value=abc",
+		"&min_staking_value=100&max_staking_value=10",
+		"&min_start_height=-1",
+		"&min_start_height=100&max_start_height=10",
+		"&sort_order=random",
+	}
+	for _, query := range invalidQueries {
+		resp, err := http.Get(testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + stakerPk + query)
+		assert.NoError(t, err, "making GET request to delegations by staker pk should not fail")
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 for query "+query)
+	}
+}
+
+func fetchAllStakerDelegations(t *testing.T, testServer *TestServer, query string) []services.DelegationPublic {
+	var paginationKey string
+	var allDelegations []services.DelegationPublic
+	for {
+		resp, err := http.Get(testServer.Server.URL + stakerDelegations + query + "&pagination_key=" + paginationKey)
+		assert.NoError(t, err, "making GET request to delegations by staker pk should not fail")
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+		bodyBytes, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.NoError(t, err, "reading response body should not fail")
+
+		var response handlers.PublicResponse[[]services.DelegationPublic]
+		err = json.Unmarshal(bodyBytes, &response)
+		assert.NoError(t, err, "unmarshalling response body should not fail")
+
+		allDelegations = append(allDelegations, response.Data...)
+		if response.Pagination.NextKey == "" {
+			return allDelegations
+		}
+		paginationKey = response.Pagination.NextKey
+	}
+}
+
 func fetchCheckStakerActiveDelegations(
 	t *testing.T, testServer *TestServer, btcAddress string, timeframe string,
 ) bool {
@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonchain/staking-api-service/internal/api/handlers"
+	"github.com/babylonchain/staking-api-service/internal/services"
+)
+
+const validateStakingTxPath = "/v1/staking/validate"
+
+func TestValidateStakingTxShouldRejectInvalidPayload(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	url := testServer.Server.URL + validateStakingTxPath
+	for _, body := range []string{
+		`not a json`,
+		`{"staking_tx_hex": ""}`,
+		`{"staking_tx_hex": "zz"}`,
+	} {
+		resp, err := http.Post(url, "application/json", bytes.NewReader([]byte(body)))
+		assert.NoError(t, err, "making POST request to validate staking tx endpoint should not fail")
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+	}
+
+	// No btc height is provided and no btc info has been processed yet
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	_, txHex, err := generateRandomTx(r)
+	require.NoError(t, err)
+	resp, err := postValidateStakingTx(url, handlers.ValidateStakingTxRequestPayload{StakingTxHex: txHex})
+	assert.NoError(t, err, "making POST request to validate staking tx endpoint should not fail")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+}
+
+func TestValidateStakingTxShouldReportFailedRules(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	url := testServer.Server.URL + validateStakingTxPath
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	_, txHex, err := generateRandomTx(r)
+	require.NoError(t, err)
+
+	// No params version is activated before the first activation height
+	result := fetchStakingTxValidation(t, url, handlers.ValidateStakingTxRequestPayload{
+		StakingTxHex: txHex, BtcHeight: 50,
+	})
+	assert.False(t, result.Valid)
+	assert.Nil(t, result.ParamsVersion)
+	require.Len(t, result.Rules, 1)
+	assert.Equal(t, services.StakingRuleParamsVersion, result.Rules[0].Rule)
+	assert.False(t, result.Rules[0].Passed)
+	assert.NotEmpty(t, result.Rules[0].Message)
+
+	// A random tx does not have the staking and op_return outputs
+	result = fetchStakingTxValidation(t, url, handlers.ValidateStakingTxRequestPayload{
+		StakingTxHex: txHex, BtcHeight: 150,
+	})
+	assert.False(t, result.Valid)
+	require.NotNil(t, result.ParamsVersion)
+	assert.Equal(t, uint64(0), *result.ParamsVersion)
+	assert.Equal(t, uint64(150), result.BtcHeight)
+	require.Len(t, result.Rules, 2)
+	assert.True(t, result.Rules[0].Passed)
+	assert.Equal(t, services.StakingRuleTxStructure, result.Rules[1].Rule)
+	assert.False(t, result.Rules[1].Passed)
+	assert.NotEmpty(t, result.Rules[1].Message)
+}
+
+func postValidateStakingTx(url string, payload handlers.ValidateStakingTxRequestPayload) (*http.Response, error) {
+	requestBodyBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return http.Post(url, "application/json", bytes.NewReader(requestBodyBytes))
+}
+
+func fetchStakingTxValidation(
+	t *testing.T, url string, payload handlers.ValidateStakingTxRequestPayload,
+) services.StakingTxValidationPublic {
+	resp, err := postValidateStakingTx(url, payload)
+	assert.NoError(t, err, "making POST request to validate staking tx endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+
+	var responseBody handlers.PublicResponse[services.StakingTxValidationPublic]
+	err = json.Unmarshal(bodyBytes, &responseBody)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+	return responseBody.Data
+}
@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/babylonchain/staking-api-service/internal/api/handlers"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/services"
+	"github.com/babylonchain/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+)
+
+const statsHistoryPath = "/v1/stats/history"
+
+func TestStatsSnapshotShouldBeTakenOnBtcHeightChange(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []client.ActiveStakingEvent{*activeStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	btcInfoEvents := []*client.BtcInfoEvent{
+		{EventType: client.BtcInfoEventType, Height: 100, ConfirmedTvl: 90, UnconfirmedTvl: 100},
+		// Same height shall not trigger a new snapshot
+		{EventType: client.BtcInfoEventType, Height: 100, ConfirmedTvl: 90, UnconfirmedTvl: 100},
+		// Lower height is outdated and shall not trigger a new snapshot
+		{EventType: client.BtcInfoEventType, Height: 99, ConfirmedTvl: 90, UnconfirmedTvl: 100},
+	}
+	sendTestMessage(testServer.Queues.BtcInfoQueueClient, btcInfoEvents)
+	time.Sleep(2 * time.Second)
+
+	overallSnapshots, err := inspectDbDocuments[model.OverallStatsSnapshotDocument](t, model.OverallStatsSnapshotCollection)
+	assert.NoError(t, err, "failed to inspect DB documents")
+	assert.Equal(t, 1, len(overallSnapshots))
+	assert.Equal(t, uint64(100), overallSnapshots[0].BtcHeight)
+	assert.Equal(t, int64(activeStakingEvent.StakingValue), overallSnapshots[0].TotalTvl)
+	assert.Equal(t, uint64(1), overallSnapshots[0].TotalStakers)
+	assert.Equal(t, uint64(90), overallSnapshots[0].ConfirmedTvl)
+
+	fpSnapshots, err := inspectDbDocuments[model.FinalityProviderStatsSnapshotDocument](
+		t, model.FinalityProviderStatsSnapshotCollection,
+	)
+	assert.NoError(t, err, "failed to inspect DB documents")
+	assert.Equal(t, 1, len(fpSnapshots))
+	assert.Equal(t, activeStakingEvent.FinalityProviderPkHex, fpSnapshots[0].FinalityProviderPkHex)
+	assert.Equal(t, uint64(100), fpSnapshots[0].BtcHeight)
+	assert.Equal(t, overallSnapshots[0].Timestamp, fpSnapshots[0].Timestamp)
+	assert.Equal(t, int64(activeStakingEvent.StakingValue), fpSnapshots[0].ActiveTvl)
+
+	stakerSnapshots, err := inspectDbDocuments[model.StakerStatsSnapshotDocument](
+		t, model.StakerStatsSnapshotCollection,
+	)
+	assert.NoError(t, err, "failed to inspect DB documents")
+	assert.Equal(t, 1, len(stakerSnapshots))
+	assert.Equal(t, activeStakingEvent.StakerPkHex, stakerSnapshots[0].StakerPkHex)
+	assert.Equal(t, int64(1), stakerSnapshots[0].ActiveDelegations)
+}
+
+func TestStatsHistoryEndpoint(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	now := time.Now().Unix()
+	hour := int64(time.Hour.Seconds())
+	bucketStart := now - now%hour - 2*hour
+	snapshots := []*model.OverallStatsSnapshotDocument{
+		{Id: "100:1", BtcHeight: 100, Timestamp: bucketStart + 1, TotalTvl: 100, ConfirmedTvl: 10, UnconfirmedTvl: 20},
+		// Both snapshots fall into the same bucket, only the latest one shall be returned
+		{Id: "101:2", BtcHeight: 101, Timestamp: bucketStart + 2, TotalTvl: 200, ConfirmedTvl: 20, UnconfirmedTvl: 30},
+		{Id: "102:3", BtcHeight: 102, Timestamp: bucketStart + hour + 3, TotalTvl: 300, ConfirmedTvl: 30, UnconfirmedTvl: 30},
+	}
+	for _, snapshot := range snapshots {
+		injectDbDocuments(t, model.OverallStatsSnapshotCollection, snapshot)
+	}
+
+	history := fetchStatsHistoryEndpoint(t, testServer, "?interval=1h")
+	assert.Equal(t, 2, len(history))
+	assert.Equal(t, bucketStart, history[0].Timestamp)
+	assert.Equal(t, uint64(101), history[0].BtcHeight)
+	assert.Equal(t, int64(200), history[0].TotalTvl)
+	assert.Equal(t, int64(20), history[0].ActiveTvl)
+	assert.Equal(t, uint64(10), history[0].PendingTvl)
+	assert.Equal(t, bucketStart+hour, history[1].Timestamp)
+	assert.Equal(t, uint64(102), history[1].BtcHeight)
+	assert.Equal(t, uint64(0), history[1].PendingTvl)
+
+	// Snapshots outside of the requested range shall not be returned
+	history = fetchStatsHistoryEndpoint(t, testServer, "?interval=1h&to="+strconv.FormatInt(bucketStart+hour-1, 10))
+	assert.Equal(t, 1, len(history))
+	assert.Equal(t, uint64(101), history[0].BtcHeight)
+}
+
+func TestStatsHistoryEndpointShouldRejectInvalidQuery(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	invalidQueries := []string{
+		"?interval=abc",
+		"?interval=1ms",
+		"?from=100&to=10",
+		"?from=-1",
+		// 1000 days in 1 minute intervals exceeds the max history buckets
+		"?from=0&to=86400000&interval=1m",
+		"?staker_btc_pk=invalid",
+	}
+	for _, query := range invalidQueries {
+		resp, err := http.Get(testServer.Server.URL + statsHistoryPath + query)
+		assert.NoError(t, err, "making GET request to stats history endpoint should not fail")
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 for query "+query)
+	}
+}
+
+func fetchStatsHistoryEndpoint(t *testing.T, testServer *TestServer, query string) []services.OverallStatsHistoryPublic {
+	resp, err := http.Get(testServer.Server.URL + statsHistoryPath + query)
+	assert.NoError(t, err, "making GET request to stats history endpoint should not fail")
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+
+	var responseBody handlers.PublicResponse[[]services.OverallStatsHistoryPublic]
+	err = json.Unmarshal(bodyBytes, &responseBody)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	return responseBody.Data
+}
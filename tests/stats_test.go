@@ -194,6 +194,8 @@ func TestStatsEndpoints(t *testing.T) {
 			assert.Equal(t, int64(activeStakingEvent.StakingValue), r.TotalTvl)
 			assert.Equal(t, int64(1), r.ActiveDelegations)
 			assert.Equal(t, int64(1), r.TotalDelegations)
+			assert.Equal(t, int64(1), r.ActiveStakers)
+			assert.Equal(t, int64(1), r.TotalStakers)
 		} else {
 			assert.Equal(t, int64(0), r.ActiveTvl)
 			assert.Equal(t, int64(0), r.TotalTvl)
@@ -244,6 +246,8 @@ func TestStatsEndpoints(t *testing.T) {
 			assert.Equal(t, int64(activeStakingEvent.StakingValue), r.TotalTvl)
 			assert.Equal(t, int64(0), r.ActiveDelegations)
 			assert.Equal(t, int64(1), r.TotalDelegations)
+			assert.Equal(t, int64(0), r.ActiveStakers)
+			assert.Equal(t, int64(1), r.TotalStakers)
 		} else {
 			assert.Equal(t, int64(0), r.ActiveTvl)
 			assert.Equal(t, int64(0), r.TotalTvl)
@@ -477,3 +481,59 @@ func fetchStakerStatsEndpoint(t *testing.T, testServer *TestServer) ([]services.
 
 	return responseBody.Data, responseBody.Pagination.NextKey
 }
+
+func TestStakerStatsWithRank(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	var activeStakingEvents []*client.ActiveStakingEvent
+	for i := 0; i < 3; i++ {
+		activeStakingEvents = append(activeStakingEvents, generateRandomActiveStakingEvents(t, r, &TestActiveEventGeneratorOpts{
+			NumOfEvents:       1,
+			FinalityProviders: generatePks(t, 1),
+			Stakers:           generatePks(t, 1),
+		})...)
+	}
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, activeStakingEvents)
+	time.Sleep(2 * time.Second)
+
+	for _, event := range activeStakingEvents {
+		expectedRank := int64(1)
+		for _, other := range activeStakingEvents {
+			if other.StakingValue > event.StakingValue {
+				expectedRank++
+			}
+		}
+
+		resp, err := http.Get(testServer.Server.URL + topStakerStatsPath + "/" + event.StakerPkHex)
+		require.NoError(t, err, "making GET request to staker stats endpoint should not fail")
+		require.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+		var response handlers.PublicResponse[services.StakerStatsDetailsPublic]
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		resp.Body.Close()
+
+		stats := response.Data
+		assert.Equal(t, event.StakerPkHex, stats.StakerPkHex)
+		assert.Equal(t, int64(event.StakingValue), stats.ActiveTvl)
+		assert.Equal(t, int64(event.StakingValue), stats.TotalTvl)
+		assert.Equal(t, int64(1), stats.ActiveDelegations)
+		assert.Equal(t, int64(1), stats.TotalDelegations)
+		assert.Equal(t, expectedRank, stats.Rank)
+		assert.Equal(t, int64(1), stats.StateTotals["active"].Delegations)
+		assert.Equal(t, event.StakingValue, stats.StateTotals["active"].StakingValue)
+		assert.Zero(t, stats.StateTotals["unbonded"].Delegations)
+	}
+
+	// The stakers without any delegation are not found
+	stakerPk, err := randomPk()
+	require.NoError(t, err)
+	resp, err := http.Get(testServer.Server.URL + topStakerStatsPath + "/" + stakerPk)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "expected HTTP 404 Not Found status")
+
+	resp, err = http.Get(testServer.Server.URL + topStakerStatsPath + "/invalid")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+}
@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/babylonchain/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonchain/staking-api-service/internal/services"
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+const stakerStreamPath = "/v1/stream/staker"
+
+func TestStreamStakerDelegationsShouldRejectInvalidPk(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	resp, err := http.Get(testServer.Server.URL + stakerStreamPath + "?staker_btc_pk=invalid")
+	assert.NoError(t, err, "making GET request to staker stream endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+}
+
+func TestStreamStakerDelegations(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	resp, err := http.Get(
+		testServer.Server.URL + stakerStreamPath + "?staker_btc_pk=" + activeStakingEvent.StakerPkHex,
+	)
+	require.NoError(t, err, "making GET request to staker stream endpoint should not fail")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := readDelegationEvents(t, resp)
+	// Wait for the change stream to be opened
+	time.Sleep(1 * time.Second)
+
+	err = sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []client.ActiveStakingEvent{*activeStakingEvent})
+	require.NoError(t, err)
+	event := waitForDelegationState(t, events, types.Active)
+	assert.Equal(t, activeStakingEvent.StakingTxHashHex, event.StakingTxHashHex)
+
+	// The unbonding request is submitted through the API
+	requestBodyBytes, err := json.Marshal(getTestUnbondDelegationRequestPayload(activeStakingEvent.StakingTxHashHex))
+	require.NoError(t, err)
+	unbondingResp, err := http.Post(
+		testServer.Server.URL+unbondingPath, "application/json", bytes.NewReader(requestBodyBytes),
+	)
+	require.NoError(t, err)
+	unbondingResp.Body.Close()
+	waitForDelegationState(t, events, types.UnbondingRequested)
+
+	unbondingEvent := client.UnbondingStakingEvent{
+		EventType:               client.UnbondingStakingEventType,
+		StakingTxHashHex:        activeStakingEvent.StakingTxHashHex,
+		UnbondingStartHeight:    activeStakingEvent.StakingStartHeight + 1,
+		UnbondingStartTimestamp: time.Now().Unix(),
+		UnbondingTimeLock:       10,
+		UnbondingOutputIndex:    0,
+		UnbondingTxHex:          getTestUnbondDelegationRequestPayload(activeStakingEvent.StakingTxHashHex).UnbondingTxHex,
+		UnbondingTxHashHex:      getTestUnbondDelegationRequestPayload(activeStakingEvent.StakingTxHashHex).UnbondingTxHashHex,
+	}
+	err = sendTestMessage(testServer.Queues.UnbondingStakingQueueClient, []client.UnbondingStakingEvent{unbondingEvent})
+	require.NoError(t, err)
+	event = waitForDelegationState(t, events, types.Unbonding)
+	require.NotNil(t, event.UnbondingTx)
+}
+
+// readDelegationEvents parses the delegation events of the stream
+func readDelegationEvents(t *testing.T, resp *http.Response) <-chan services.DelegationPublic {
+	events := make(chan services.DelegationPublic, 10)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+			if !ok {
+				continue
+			}
+			var event services.DelegationPublic
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				t.Errorf("failed to unmarshal delegation event: %v", err)
+				return
+			}
+			events <- event
+		}
+	}()
+	return events
+}
+
+// waitForDelegationState waits for a delegation event with the given state,
+// skipping the events of other changes
+func waitForDelegationState(
+	t *testing.T, events <-chan services.DelegationPublic, state types.DelegationState,
+) services.DelegationPublic {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event, ok := <-events:
+			require.True(t, ok, "expected the stream to be open")
+			if event.State == state.ToString() {
+				return event
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for delegation event with state %s", state)
+		}
+	}
+}
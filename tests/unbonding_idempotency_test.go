@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/babylonchain/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/babylonchain/staking-api-service/internal/api/handlers"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+)
+
+func postUnbondingRequest(
+	t *testing.T, testServer *TestServer, query, idempotencyKey string, payload handlers.UnbondDelegationRequestPayload,
+) int {
+	payloadBytes, err := json.Marshal(payload)
+	require.NoError(t, err)
+	req, err := http.NewRequest(
+		http.MethodPost, testServer.Server.URL+unbondingPath+query, bytes.NewReader(payloadBytes),
+	)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if idempotencyKey != "" {
+		req.Header.Set(handlers.IdempotencyKeyHeader, idempotencyKey)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err, "making POST request to unbonding endpoint should not fail")
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestUnbondingDryRunShouldNotSaveRequest(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []client.ActiveStakingEvent{*activeStakingEvent})
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	payload := getTestUnbondDelegationRequestPayload(activeStakingEvent.StakingTxHashHex)
+	statusCode := postUnbondingRequest(t, testServer, "?dry_run=true", "", payload)
+	assert.Equal(t, http.StatusOK, statusCode, "expected HTTP 200 OK status")
+
+	results, err := inspectDbDocuments[model.UnbondingDocument](t, model.UnbondingCollection)
+	require.NoError(t, err)
+	assert.Empty(t, results, "the dry run should not save the unbonding request")
+
+	// The delegation is still eligible for unbonding
+	statusCode = postUnbondingRequest(t, testServer, "", "", payload)
+	assert.Equal(t, http.StatusAccepted, statusCode, "expected HTTP 202 Accepted status")
+
+	statusCode = postUnbondingRequest(t, testServer, "?dry_run=true", "", payload)
+	assert.Equal(t, http.StatusForbidden, statusCode, "expected HTTP 403 Forbidden status")
+}
+
+func TestUnbondingRequestWithIdempotencyKey(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []client.ActiveStakingEvent{*activeStakingEvent})
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	payload := getTestUnbondDelegationRequestPayload(activeStakingEvent.StakingTxHashHex)
+	statusCode := postUnbondingRequest(t, testServer, "", "unbonding-key", payload)
+	assert.Equal(t, http.StatusAccepted, statusCode, "expected HTTP 202 Accepted status")
+
+	// The retry gets the original response
+	statusCode = postUnbondingRequest(t, testServer, "", "unbonding-key", payload)
+	assert.Equal(t, http.StatusAccepted, statusCode, "expected the original HTTP 202 Accepted status")
+
+	// Without the key, the duplicated submission is rejected
+	statusCode = postUnbondingRequest(t, testServer, "", "", payload)
+	assert.Equal(t, http.StatusForbidden, statusCode, "expected HTTP 403 Forbidden status")
+
+	// The key can not be reused for another request
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	otherStakingTx, _, err := generateRandomTx(r)
+	require.NoError(t, err)
+	otherPayload := getTestUnbondDelegationRequestPayload(otherStakingTx.TxHash().String())
+	statusCode = postUnbondingRequest(t, testServer, "", "unbonding-key", otherPayload)
+	assert.Equal(t, http.StatusUnprocessableEntity, statusCode, "expected HTTP 422 Unprocessable Entity status")
+
+	// The failed outcome is replayed as well
+	statusCode = postUnbondingRequest(t, testServer, "", "other-unbonding-key", otherPayload)
+	assert.Equal(t, http.StatusForbidden, statusCode, "expected HTTP 403 Forbidden status")
+	statusCode = postUnbondingRequest(t, testServer, "", "other-unbonding-key", otherPayload)
+	assert.Equal(t, http.StatusForbidden, statusCode, "expected the original HTTP 403 Forbidden status")
+
+	results, err := inspectDbDocuments[model.UnbondingDocument](t, model.UnbondingCollection)
+	require.NoError(t, err)
+	assert.Len(t, results, 1, "expected the unbonding request to be saved once")
+}
+
+func TestAbandonedIdempotencyKeyShouldBeTakenOver(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []client.ActiveStakingEvent{*activeStakingEvent})
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	payload := getTestUnbondDelegationRequestPayload(activeStakingEvent.StakingTxHashHex)
+	statusCode := postUnbondingRequest(t, testServer, "", "abandoned-key", payload)
+	assert.Equal(t, http.StatusAccepted, statusCode, "expected HTTP 202 Accepted status")
+
+	// Pretend the outcome of the request could not be recorded
+	connection := directDbConnection(t)
+	collection := connection.Client.Database(connection.DbName).Collection(model.IdempotencyKeyCollection)
+	_, err = collection.UpdateMany(context.Background(), bson.M{}, bson.M{"$set": bson.M{
+		"completed":    false,
+		"locked_until": time.Now().Add(time.Minute),
+	}})
+	require.NoError(t, err)
+
+	// The key is held while the lease has not expired
+	statusCode = postUnbondingRequest(t, testServer, "", "abandoned-key", payload)
+	assert.Equal(t, http.StatusConflict, statusCode, "expected HTTP 409 Conflict status")
+
+	// Once expired, the retry takes the key over and processes the request again
+	_, err = collection.UpdateMany(context.Background(), bson.M{}, bson.M{"$set": bson.M{
+		"locked_until": time.Now().Add(-time.Second),
+	}})
+	require.NoError(t, err)
+	statusCode = postUnbondingRequest(t, testServer, "", "abandoned-key", payload)
+	assert.Equal(t, http.StatusForbidden, statusCode, "expected HTTP 403 Forbidden status")
+
+	results, err := inspectDbDocuments[model.IdempotencyKeyDocument](t, model.IdempotencyKeyCollection)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Completed, "expected the outcome of the retry to be recorded")
+	assert.Equal(t, http.StatusForbidden, results[0].StatusCode)
+}
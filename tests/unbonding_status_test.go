@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/babylonchain/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonchain/staking-api-service/internal/api/handlers"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/services"
+)
+
+const unbondingStatusPath = "/v1/unbonding/status"
+
+func adminUnbondingStatePath(stakingTxHashHex string) string {
+	return "/v1/admin/unbonding/" + stakingTxHashHex + "/state"
+}
+
+func fetchUnbondingStatus(t *testing.T, testServer *TestServer, stakingTxHashHex string) services.UnbondingStatusPublic {
+	resp, err := http.Get(testServer.Server.URL + unbondingStatusPath + "?staking_tx_hash_hex=" + stakingTxHashHex)
+	require.NoError(t, err, "making GET request to unbonding status endpoint should not fail")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	var response handlers.PublicResponse[services.UnbondingStatusPublic]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	return response.Data
+}
+
+func reportUnbondingState(
+	t *testing.T, testServer *TestServer, stakingTxHashHex string, payload handlers.UpdateUnbondingStateRequestPayload,
+) int {
+	resp := adminRequest(
+		t, http.MethodPost, testServer.Server.URL+adminUnbondingStatePath(stakingTxHashHex), payload,
+	)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestUnbondingStatusShouldBeNotFoundWithoutRequest(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	resp, err := http.Get(
+		testServer.Server.URL + unbondingStatusPath + "?staking_tx_hash_hex=" + activeStakingEvent.StakingTxHashHex,
+	)
+	require.NoError(t, err, "making GET request to unbonding status endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "expected HTTP 404 Not Found status")
+}
+
+func TestUnbondingStatusLifecycle(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []client.ActiveStakingEvent{*activeStakingEvent})
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	requestBody := getTestUnbondDelegationRequestPayload(activeStakingEvent.StakingTxHashHex)
+	requestBodyBytes, err := json.Marshal(requestBody)
+	require.NoError(t, err)
+	resp, err := http.Post(testServer.Server.URL+unbondingPath, "application/json", bytes.NewReader(requestBodyBytes))
+	require.NoError(t, err, "making POST request to unbonding endpoint should not fail")
+	resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode, "expected HTTP 202 Accepted status")
+
+	status := fetchUnbondingStatus(t, testServer, activeStakingEvent.StakingTxHashHex)
+	assert.Equal(t, requestBody.UnbondingTxHashHex, status.UnbondingTxHashHex)
+	assert.Equal(t, model.UnbondingInitialState, status.State)
+	require.Len(t, status.History, 1)
+
+	// The state update requires the admin api key
+	resp, err = http.Post(
+		testServer.Server.URL+adminUnbondingStatePath(activeStakingEvent.StakingTxHashHex), "application/json",
+		bytes.NewReader([]byte(`{"state":"PICKED_UP"}`)),
+	)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "expected HTTP 401 Unauthorized status")
+
+	statusCode := reportUnbondingState(t, testServer, activeStakingEvent.StakingTxHashHex,
+		handlers.UpdateUnbondingStateRequestPayload{State: model.UnbondingPickedUpState})
+	assert.Equal(t, http.StatusOK, statusCode)
+	// Reporting the same state again is a no-op
+	statusCode = reportUnbondingState(t, testServer, activeStakingEvent.StakingTxHashHex,
+		handlers.UpdateUnbondingStateRequestPayload{State: model.UnbondingPickedUpState})
+	assert.Equal(t, http.StatusOK, statusCode)
+	// The unreported states can be skipped
+	statusCode = reportUnbondingState(t, testServer, activeStakingEvent.StakingTxHashHex,
+		handlers.UpdateUnbondingStateRequestPayload{State: model.UnbondingBroadcastState})
+	assert.Equal(t, http.StatusOK, statusCode)
+	// But the request never goes back
+	statusCode = reportUnbondingState(t, testServer, activeStakingEvent.StakingTxHashHex,
+		handlers.UpdateUnbondingStateRequestPayload{State: model.UnbondingCovenantSignaturesCollectedState})
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	// The failed state requires a reason
+	statusCode = reportUnbondingState(t, testServer, activeStakingEvent.StakingTxHashHex,
+		handlers.UpdateUnbondingStateRequestPayload{State: model.UnbondingFailedState})
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+	statusCode = reportUnbondingState(t, testServer, activeStakingEvent.StakingTxHashHex,
+		handlers.UpdateUnbondingStateRequestPayload{State: model.UnbondingFailedState, Reason: "input already spent"})
+	assert.Equal(t, http.StatusOK, statusCode)
+	// Nothing happens after the failure
+	statusCode = reportUnbondingState(t, testServer, activeStakingEvent.StakingTxHashHex,
+		handlers.UpdateUnbondingStateRequestPayload{State: model.UnbondingConfirmedState})
+	assert.Equal(t, http.StatusForbidden, statusCode)
+
+	status = fetchUnbondingStatus(t, testServer, activeStakingEvent.StakingTxHashHex)
+	assert.Equal(t, model.UnbondingFailedState, status.State)
+	assert.Equal(t, "input already spent", status.FailureReason)
+	require.Len(t, status.History, 4)
+	assert.Equal(t, model.UnbondingInitialState, status.History[0].State)
+	assert.Equal(t, model.UnbondingPickedUpState, status.History[1].State)
+	assert.Equal(t, model.UnbondingBroadcastState, status.History[2].State)
+	assert.Equal(t, model.UnbondingFailedState, status.History[3].State)
+	assert.Equal(t, "input already spent", status.History[3].Reason)
+}
+
+func TestUnbondingStateUpdateShouldRejectInvalidState(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	for _, state := range []string{"", "unknown", model.UnbondingInitialState} {
+		statusCode := reportUnbondingState(t, testServer, activeStakingEvent.StakingTxHashHex,
+			handlers.UpdateUnbondingStateRequestPayload{State: state})
+		assert.Equal(t, http.StatusBadRequest, statusCode, "expected HTTP 400 Bad Request status")
+	}
+	statusCode := reportUnbondingState(t, testServer, activeStakingEvent.StakingTxHashHex,
+		handlers.UpdateUnbondingStateRequestPayload{State: model.UnbondingPickedUpState})
+	assert.Equal(t, http.StatusNotFound, statusCode, "expected HTTP 404 Not Found status")
+}
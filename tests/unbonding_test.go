@@ -26,6 +26,7 @@ import (
 const (
 	unbondingEligibilityPath = "/v1/unbonding/eligibility"
 	unbondingPath            = "/v1/unbonding"
+	unbondingTemplatePath    = "/v1/unbonding/template"
 )
 
 func TestUnbondingRequest(t *testing.T) {
@@ -161,6 +162,49 @@ func TestUnbondingRequestEligibilityWhenNoMatchingDelegation(t *testing.T) {
 	assert.Equal(t, "NOT_FOUND", response.ErrorCode, "expected error code to be NOT_FOUND")
 }
 
+func TestUnbondingTemplate(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	templateUrl := testServer.Server.URL + unbondingTemplatePath + "?staking_tx_hash_hex="
+
+	resp, err := http.Get(templateUrl + "invalid")
+	assert.NoError(t, err, "making GET request to unbonding template endpoint should not fail")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+
+	resp, err = http.Get(templateUrl + activeStakingEvent.StakingTxHashHex)
+	assert.NoError(t, err, "making GET request to unbonding template endpoint should not fail")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "expected HTTP 404 Not Found status")
+
+	err = sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []client.ActiveStakingEvent{*activeStakingEvent})
+	require.NoError(t, err)
+	time.Sleep(2 * time.Second)
+
+	resp, err = http.Get(templateUrl + activeStakingEvent.StakingTxHashHex)
+	assert.NoError(t, err, "making GET request to unbonding template endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[services.UnbondingTemplatePublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	// The template must match the unsigned unbonding tx accepted by the unbonding endpoint
+	template := response.Data
+	expected := getTestUnbondDelegationRequestPayload(activeStakingEvent.StakingTxHashHex)
+	assert.Equal(t, activeStakingEvent.StakingTxHashHex, template.StakingTxHashHex)
+	assert.Equal(t, expected.UnbondingTxHashHex, template.UnbondingTxHashHex)
+	assert.NotEmpty(t, template.UnbondingTxHex)
+	assert.NotEmpty(t, template.UnbondingTxPsbt)
+	assert.Len(t, template.SigHashHex, 64)
+	assert.Equal(t, activeStakingEvent.StakingValue-template.UnbondingFee, template.UnbondingValue)
+}
+
 func getTestActiveStakingEvent() *client.ActiveStakingEvent {
 	return &client.ActiveStakingEvent{
 		EventType:             client.ActiveStakingEventType,
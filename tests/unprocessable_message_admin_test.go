@@ -0,0 +1,160 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/babylonchain/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/babylonchain/staking-api-service/internal/api/handlers"
+	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/services"
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+const adminUnprocessableMessagesPath = "/v1/admin/unprocessable-messages"
+
+func injectTestUnprocessableMessage(
+	t *testing.T, messageBody, queueName string, failedAt int64,
+) primitive.ObjectID {
+	doc := model.NewUnprocessableMessageDocument(
+		messageBody, "receipt", queueName, "error while processing message", 3, failedAt,
+	)
+	doc.Id = primitive.NewObjectID()
+	injectDbDocuments(t, model.UnprocessableMsgCollection, doc)
+	return doc.Id
+}
+
+func getTestUnprocessableMessages(t *testing.T, testServer *TestServer, query string) []services.UnprocessableMessagePublic {
+	resp := adminRequest(t, http.MethodGet, testServer.Server.URL+adminUnprocessableMessagesPath+query, nil)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	var response handlers.PublicResponse[[]services.UnprocessableMessagePublic]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	return response.Data
+}
+
+func TestUnprocessableMessagesRequireAdminApiKey(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	resp, err := http.Get(testServer.Server.URL + adminUnprocessableMessagesPath)
+	assert.NoError(t, err, "making GET request to unprocessable messages endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "expected HTTP 401 Unauthorized status")
+}
+
+func TestInspectAndDiscardUnprocessableMessage(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	id := injectTestUnprocessableMessage(t, "a rubbish message", client.ActiveStakingQueueName, time.Now().Unix())
+	injectTestUnprocessableMessage(t, "another rubbish message", client.UnbondingStakingQueueName, time.Now().Unix())
+
+	messages := getTestUnprocessableMessages(t, testServer, "?queue_name="+client.ActiveStakingQueueName)
+	require.Len(t, messages, 1)
+	assert.Equal(t, id.Hex(), messages[0].Id)
+	assert.Equal(t, client.ActiveStakingQueueName, messages[0].QueueName)
+
+	resp := adminRequest(t, http.MethodGet, testServer.Server.URL+adminUnprocessableMessagesPath+"/"+id.Hex(), nil)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	var response handlers.PublicResponse[services.UnprocessableMessagePublic]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	assert.Equal(t, "a rubbish message", response.Data.MessageBody)
+	assert.Equal(t, "error while processing message", response.Data.Error)
+	assert.Equal(t, int32(3), response.Data.Attempts)
+
+	discardPath := testServer.Server.URL + adminUnprocessableMessagesPath + "/" + id.Hex() + "/discard"
+	resp = adminRequest(t, http.MethodPost, discardPath, handlers.DiscardUnprocessableMessageRequestPayload{})
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+
+	resp = adminRequest(t, http.MethodPost, discardPath, handlers.DiscardUnprocessableMessageRequestPayload{
+		Reason: "malformed message",
+	})
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	resp = adminRequest(t, http.MethodPost, discardPath, handlers.DiscardUnprocessableMessageRequestPayload{
+		Reason: "malformed message",
+	})
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "expected HTTP 404 Not Found status")
+
+	messages = getTestUnprocessableMessages(t, testServer, "")
+	require.Len(t, messages, 1)
+	assert.Equal(t, client.UnbondingStakingQueueName, messages[0].QueueName)
+
+	audits, err := inspectDbDocuments[model.UnprocessableMessageAuditDocument](t, model.UnprocessableMsgAuditCollection)
+	require.NoError(t, err)
+	require.Len(t, audits, 4)
+	assert.Equal(t, types.UnprocessableMessageList, audits[0].Action)
+	assert.Equal(t, types.UnprocessableMessageInspect, audits[1].Action)
+	assert.Equal(t, types.UnprocessableMessageDiscard, audits[2].Action)
+	assert.Equal(t, "malformed message", audits[2].Reason)
+	require.NotNil(t, audits[2].Message)
+	assert.Equal(t, "a rubbish message", audits[2].Message.MessageBody)
+	assert.Equal(t, types.UnprocessableMessageList, audits[3].Action)
+}
+
+func TestReplayUnprocessableMessageThroughAdminApi(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	activeStakingEvent := buildActiveStakingEvent(t, 1)[0]
+	data, err := json.Marshal(activeStakingEvent)
+	require.NoError(t, err)
+	id := injectTestUnprocessableMessage(t, string(data), client.ActiveStakingQueueName, time.Now().Unix())
+
+	resp := adminRequest(
+		t, http.MethodPost, testServer.Server.URL+adminUnprocessableMessagesPath+"/"+id.Hex()+"/replay", nil,
+	)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	time.Sleep(2 * time.Second)
+
+	resp, err = http.Get(testServer.Server.URL + stakerDelegations + "?staker_btc_pk=" + activeStakingEvent.StakerPkHex)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var delegations handlers.PublicResponse[[]services.DelegationPublic]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&delegations))
+	require.Len(t, delegations.Data, 1)
+	assert.Equal(t, activeStakingEvent.StakingTxHashHex, delegations.Data[0].StakingTxHashHex)
+
+	messages := getTestUnprocessableMessages(t, testServer, "")
+	assert.Empty(t, messages)
+}
+
+func TestReplayUnprocessableMessagesByFilter(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	activeStakingEvent := buildActiveStakingEvent(t, 1)[0]
+	data, err := json.Marshal(activeStakingEvent)
+	require.NoError(t, err)
+	// The message dead-lettered without its queue is routed by its event type
+	replayedId := injectTestUnprocessableMessage(t, string(data), "", time.Now().Unix())
+	// The message failed before the filtered range is not replayed
+	injectTestUnprocessableMessage(
+		t, "a rubbish message", client.UnbondingStakingQueueName, time.Now().Add(-time.Hour).Unix(),
+	)
+
+	resp := adminRequest(
+		t, http.MethodPost, testServer.Server.URL+adminUnprocessableMessagesPath+"/replay",
+		handlers.ReplayUnprocessableMessagesRequestPayload{FailedAfter: time.Now().Add(-time.Minute).Unix()},
+	)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	var response handlers.PublicResponse[services.UnprocessableMessagesReplayPublic]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	assert.Equal(t, []string{replayedId.Hex()}, response.Data.Replayed)
+	require.Len(t, response.Data.Failed, 0)
+}
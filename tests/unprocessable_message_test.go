@@ -1,12 +1,15 @@
 package tests
 
 import (
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/types"
 	"github.com/babylonchain/staking-queue-client/client"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUnprocessableMessageShouldBeStoredInDB(t *testing.T) {
@@ -27,6 +30,24 @@ func TestUnprocessableMessageShouldBeStoredInDB(t *testing.T) {
 	}
 
 	assert.Equal(t, "\"a rubbish message\"", docs[0].MessageBody)
+	assert.Equal(t, client.ActiveStakingQueueName, docs[0].QueueName)
+	assert.NotEmpty(t, docs[0].Error)
+	assert.Equal(t, types.BadRequest.String(), docs[0].ErrorCode)
+	assert.Equal(t, http.StatusBadRequest, docs[0].StatusCode)
+	assert.NotEmpty(t, docs[0].TraceId)
+	assert.NotZero(t, docs[0].FailedAt)
+	// Each attempt is recorded, the final one last
+	require.Len(t, docs[0].AttemptHistory, int(docs[0].Attempts)+1)
+	finalAttempt := docs[0].AttemptHistory[len(docs[0].AttemptHistory)-1]
+	assert.Equal(t, docs[0].TraceId, finalAttempt.TraceId)
+	assert.Equal(t, docs[0].FailedAt, finalAttempt.Timestamp)
+
+	// The attempts are not tracked anymore once the message is dumped
+	attempts, err := inspectDbDocuments[model.MessageProcessingAttemptsDocument](
+		t, model.MessageProcessingAttemptsCollection,
+	)
+	require.NoError(t, err)
+	assert.Empty(t, attempts)
 
 	// Also make sure the message is not in the queue anymore
 	count, err := inspectQueueMessageCount(t, testServer.Conn, client.ActiveStakingQueueName)
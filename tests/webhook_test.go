@@ -0,0 +1,232 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/babylonchain/staking-queue-client/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonchain/staking-api-service/internal/api/handlers"
+	"github.com/babylonchain/staking-api-service/internal/api/middlewares"
+	"github.com/babylonchain/staking-api-service/internal/services"
+	"github.com/babylonchain/staking-api-service/internal/types"
+)
+
+const (
+	adminWebhooksPath = "/v1/admin/webhooks"
+	testAdminApiKey   = "test-admin-api-key"
+)
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+func adminRequest(t *testing.T, method, url string, payload interface{}) *http.Response {
+	var body io.Reader
+	if payload != nil {
+		payloadBytes, err := json.Marshal(payload)
+		require.NoError(t, err)
+		body = bytes.NewReader(payloadBytes)
+	}
+	req, err := http.NewRequest(method, url, body)
+	require.NoError(t, err)
+	req.Header.Set(middlewares.AdminApiKeyHeader, testAdminApiKey)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func createTestWebhookSubscription(
+	t *testing.T, testServer *TestServer, payload handlers.CreateWebhookSubscriptionRequestPayload,
+) services.WebhookSubscriptionPublic {
+	resp := adminRequest(t, http.MethodPost, testServer.Server.URL+adminWebhooksPath, payload)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	var response handlers.PublicResponse[services.WebhookSubscriptionPublic]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	require.NotEmpty(t, response.Data.Secret, "expected the secret to be returned on creation")
+	return response.Data
+}
+
+func getTestWebhookDeliveries(
+	t *testing.T, testServer *TestServer, subscriptionId string,
+) []services.WebhookDeliveryPublic {
+	resp := adminRequest(
+		t, http.MethodGet, testServer.Server.URL+adminWebhooksPath+"/"+subscriptionId+"/deliveries", nil,
+	)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	var response handlers.PublicResponse[[]services.WebhookDeliveryPublic]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	return response.Data
+}
+
+// startWebhookReceiver starts a subscriber responding with the given status
+// codes in turn, the last one is repeated once they are exhausted
+func startWebhookReceiver(statusCodes ...int) (*httptest.Server, <-chan receivedWebhook) {
+	received := make(chan receivedWebhook, 10)
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- receivedWebhook{header: r.Header.Clone(), body: body}
+		statusCode := statusCodes[len(statusCodes)-1]
+		if calls < len(statusCodes) {
+			statusCode = statusCodes[calls]
+		}
+		calls++
+		w.WriteHeader(statusCode)
+	}))
+	return server, received
+}
+
+func waitForWebhook(t *testing.T, received <-chan receivedWebhook) receivedWebhook {
+	select {
+	case webhook := <-received:
+		return webhook
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for webhook delivery")
+	}
+	return receivedWebhook{}
+}
+
+func TestWebhookSubscriptionsRequireAdminApiKey(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	resp, err := http.Get(testServer.Server.URL + adminWebhooksPath)
+	assert.NoError(t, err, "making GET request to webhooks endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "expected HTTP 401 Unauthorized status")
+
+	req, err := http.NewRequest(http.MethodGet, testServer.Server.URL+adminWebhooksPath, nil)
+	require.NoError(t, err)
+	req.Header.Set(middlewares.AdminApiKeyHeader, "invalid-admin-api-key")
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err, "making GET request to webhooks endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "expected HTTP 401 Unauthorized status")
+}
+
+func TestCreateWebhookSubscriptionShouldRejectInvalidPayload(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	payloads := []handlers.CreateWebhookSubscriptionRequestPayload{
+		{Url: "not-a-url"},
+		{Url: "ftp://example.com/webhook"},
+		{Url: "https://example.com/webhook", StakerPkHex: "invalid"},
+		{Url: "https://example.com/webhook", EventTypes: []string{"unknown"}},
+	}
+	for _, payload := range payloads {
+		resp := adminRequest(t, http.MethodPost, testServer.Server.URL+adminWebhooksPath, payload)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+	}
+}
+
+func TestWebhookSubscriptionLifecycle(t *testing.T) {
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	subscription := createTestWebhookSubscription(t, testServer, handlers.CreateWebhookSubscriptionRequestPayload{
+		Url: "https://example.com/webhook",
+	})
+
+	resp := adminRequest(t, http.MethodGet, testServer.Server.URL+adminWebhooksPath, nil)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+	var listResponse handlers.PublicResponse[[]services.WebhookSubscriptionPublic]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&listResponse))
+	require.Len(t, listResponse.Data, 1)
+	assert.Equal(t, subscription.Id, listResponse.Data[0].Id)
+	assert.Empty(t, listResponse.Data[0].Secret, "the secret should only be returned on creation")
+
+	resp = adminRequest(t, http.MethodDelete, testServer.Server.URL+adminWebhooksPath+"/"+subscription.Id, nil)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	resp = adminRequest(t, http.MethodDelete, testServer.Server.URL+adminWebhooksPath+"/"+subscription.Id, nil)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "expected HTTP 404 Not Found status")
+}
+
+func TestWebhookDeliveryOfActiveDelegation(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	receiver, received := startWebhookReceiver(http.StatusOK)
+	defer receiver.Close()
+	subscription := createTestWebhookSubscription(t, testServer, handlers.CreateWebhookSubscriptionRequestPayload{
+		Url:         receiver.URL,
+		StakerPkHex: activeStakingEvent.StakerPkHex,
+		EventTypes:  []string{types.DelegationActiveEvent.ToString()},
+	})
+
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []client.ActiveStakingEvent{*activeStakingEvent})
+	require.NoError(t, err)
+
+	webhook := waitForWebhook(t, received)
+	timestamp, err := strconv.ParseInt(webhook.header.Get(services.WebhookTimestampHeader), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(
+		t, services.SignWebhookPayload(subscription.Secret, timestamp, webhook.body),
+		webhook.header.Get(services.WebhookSignatureHeader),
+	)
+	assert.Equal(t, types.DelegationActiveEvent.ToString(), webhook.header.Get(services.WebhookEventHeader))
+
+	var event services.WebhookEventPublic
+	require.NoError(t, json.Unmarshal(webhook.body, &event))
+	assert.Equal(t, activeStakingEvent.StakingTxHashHex, event.StakingTxHashHex)
+	assert.Equal(t, types.Active.ToString(), event.NewState)
+
+	// Wait for the delivery to be recorded
+	time.Sleep(1 * time.Second)
+	deliveries := getTestWebhookDeliveries(t, testServer, subscription.Id)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, webhook.header.Get(services.WebhookDeliveryHeader), deliveries[0].Id)
+	assert.Equal(t, types.WebhookDeliveryDelivered.ToString(), deliveries[0].State)
+	assert.Len(t, deliveries[0].Attempts, 1)
+}
+
+func TestWebhookDeliveryShouldBeRetried(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	receiver, received := startWebhookReceiver(http.StatusInternalServerError, http.StatusOK)
+	defer receiver.Close()
+	subscription := createTestWebhookSubscription(t, testServer, handlers.CreateWebhookSubscriptionRequestPayload{
+		Url: receiver.URL,
+	})
+
+	err := sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []client.ActiveStakingEvent{*activeStakingEvent})
+	require.NoError(t, err)
+
+	first := waitForWebhook(t, received)
+	second := waitForWebhook(t, received)
+	assert.Equal(
+		t, first.header.Get(services.WebhookDeliveryHeader), second.header.Get(services.WebhookDeliveryHeader),
+		"expected the same delivery to be retried",
+	)
+
+	// Wait for the delivery to be recorded
+	time.Sleep(1 * time.Second)
+	deliveries := getTestWebhookDeliveries(t, testServer, subscription.Id)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, types.WebhookDeliveryDelivered.ToString(), deliveries[0].State)
+	require.Len(t, deliveries[0].Attempts, 2)
+	assert.Equal(t, http.StatusInternalServerError, deliveries[0].Attempts[0].StatusCode)
+	assert.NotEmpty(t, deliveries[0].Attempts[0].Error)
+}
@@ -3,16 +3,23 @@ package tests
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"net/http"
 	"testing"
 	"time"
 
+	"github.com/babylonchain/staking-api-service/internal/api/handlers"
 	"github.com/babylonchain/staking-api-service/internal/db/model"
+	"github.com/babylonchain/staking-api-service/internal/services"
 	"github.com/babylonchain/staking-api-service/internal/types"
+	"github.com/babylonchain/staking-api-service/internal/utils"
 	"github.com/babylonchain/staking-queue-client/client"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+const withdrawalTemplatePath = "/v1/withdraw/template"
+
 func TestWithdrawFromActiveStaking(t *testing.T) {
 	activeStakingEvent := getTestActiveStakingEvent()
 	testServer := setupTestServer(t, nil)
@@ -278,3 +285,86 @@ func TestShouldIgnoreWithdrawnEventIfAlreadyWithdrawn(t *testing.T) {
 	}
 	assert.Equal(t, 0, count, "expected no message in the queue")
 }
+
+func TestWithdrawalTemplate(t *testing.T) {
+	activeStakingEvent := getTestActiveStakingEvent()
+	testServer := setupTestServer(t, nil)
+	defer testServer.Close()
+
+	addresses, err := utils.DeriveBtcAddressesFromPk(
+		activeStakingEvent.StakerPkHex, testServer.Config.Server.BTCNetParam,
+	)
+	require.NoError(t, err)
+	templateUrl := testServer.Server.URL + withdrawalTemplatePath +
+		"?staking_tx_hash_hex=" + activeStakingEvent.StakingTxHashHex +
+		"&destination=" + addresses.Taproot
+
+	// Invalid query parameters
+	for _, url := range []string{
+		templateUrl,
+		templateUrl + "&fee_rate=0",
+		templateUrl + "&fee_rate=invalid",
+		templateUrl + "&fee_rate=10001",
+		templateUrl + "&fee_rate=18446744073709551615",
+		testServer.Server.URL + withdrawalTemplatePath + "?staking_tx_hash_hex=" +
+			activeStakingEvent.StakingTxHashHex + "&destination=invalid&fee_rate=10",
+	} {
+		resp, err := http.Get(url)
+		assert.NoError(t, err, "making GET request to withdrawal template endpoint should not fail")
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+	}
+
+	resp, err := http.Get(templateUrl + "&fee_rate=10")
+	assert.NoError(t, err, "making GET request to withdrawal template endpoint should not fail")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "expected HTTP 404 Not Found status")
+
+	sendTestMessage(testServer.Queues.ActiveStakingQueueClient, []client.ActiveStakingEvent{*activeStakingEvent})
+	time.Sleep(2 * time.Second)
+
+	// The delegation is not unbonded yet
+	resp, err = http.Get(templateUrl + "&fee_rate=10")
+	assert.NoError(t, err, "making GET request to withdrawal template endpoint should not fail")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, "expected HTTP 403 Forbidden status")
+
+	expiredEvent := client.ExpiredStakingEvent{
+		EventType:        client.ExpiredStakingEventType,
+		StakingTxHashHex: activeStakingEvent.StakingTxHashHex,
+		TxType:           types.ActiveTxType.ToString(),
+	}
+	sendTestMessage(testServer.Queues.ExpiredStakingQueueClient, []client.ExpiredStakingEvent{expiredEvent})
+	time.Sleep(2 * time.Second)
+
+	resp, err = http.Get(templateUrl + "&fee_rate=10")
+	assert.NoError(t, err, "making GET request to withdrawal template endpoint should not fail")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected HTTP 200 OK status")
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err, "reading response body should not fail")
+	var response handlers.PublicResponse[services.WithdrawalTemplatePublic]
+	err = json.Unmarshal(bodyBytes, &response)
+	assert.NoError(t, err, "unmarshalling response body should not fail")
+
+	// The staking output is spent as the delegation expired without unbonding
+	template := response.Data
+	assert.Equal(t, activeStakingEvent.StakingTxHashHex, template.SpendingTxHashHex)
+	assert.Equal(t, activeStakingEvent.StakingOutputIndex, template.SpendingOutputIndex)
+	assert.False(t, template.SpendingFromUnbonding)
+	assert.Equal(t, activeStakingEvent.StakingTimeLock, template.TimeLock)
+	assert.Equal(t, addresses.Taproot, template.Destination)
+	assert.NotEmpty(t, template.WithdrawalTxPsbt)
+	assert.NotEmpty(t, template.TimeLockScriptHex)
+	assert.NotEmpty(t, template.ControlBlockHex)
+	assert.Len(t, template.SigHashHex, 64)
+	assert.Greater(t, template.Fee, uint64(0))
+	assert.Equal(t, activeStakingEvent.StakingValue, template.WithdrawalValue+template.Fee)
+
+	// The fee would leave a dust output
+	resp, err = http.Get(templateUrl + "&fee_rate=1000")
+	assert.NoError(t, err, "making GET request to withdrawal template endpoint should not fail")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "expected HTTP 400 Bad Request status")
+}